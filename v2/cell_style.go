@@ -0,0 +1,181 @@
+package output
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// CellStyle is a semantic style for a table cell. Renderers map it to their
+// own output: ANSI colors in table output, CSS classes in HTML and an emoji
+// marker in Markdown. CSV, JSON and YAML output is not styled.
+type CellStyle string
+
+// Cell styles, from most to least severe
+const (
+	CellStyleCritical CellStyle = "critical"
+	CellStyleNegative CellStyle = "negative"
+	CellStyleWarning  CellStyle = "warning"
+	CellStylePositive CellStyle = "positive"
+	CellStyleInfo     CellStyle = "info"
+)
+
+// StyleRule styles a cell when its condition holds. When is an expression
+// (see Expression) evaluated against the cell's record, in which `value`
+// refers to the cell's own value unless the table has a column named value,
+// so "value > 90" and `Status == "failed"` are both valid conditions.
+// Conditions that cannot be evaluated for a record, such as comparing text
+// with a number, do not match.
+type StyleRule struct {
+	When  string
+	Style CellStyle
+
+	expr *Expression // Parsed When, set by compileStyleRules
+}
+
+// NewStyleRule creates a rule, parsing the condition so syntax errors are
+// reported immediately. Errors are returned as *ExpressionError.
+func NewStyleRule(when string, style CellStyle) (StyleRule, error) {
+	expr, err := ParseExpression(when)
+	if err != nil {
+		return StyleRule{}, err
+	}
+	return StyleRule{When: when, Style: style, expr: expr}, nil
+}
+
+// WithStyleRules adds conditional style rules to a column, after the rules
+// set on its Field. The first rule whose condition holds styles the cell.
+// NewTableContent returns an error when a condition does not parse or the
+// column does not exist.
+func WithStyleRules(column string, rules ...StyleRule) TableOption {
+	return func(tc *tableConfig) {
+		if tc.styleRules == nil {
+			tc.styleRules = make(map[string][]StyleRule)
+		}
+		tc.styleRules[column] = append(tc.styleRules[column], rules...)
+	}
+}
+
+// applyStyleRules adds the WithStyleRules rules to the schema fields and
+// parses every rule's condition
+func applyStyleRules(schema *Schema, rules map[string][]StyleRule) error {
+	for column, columnRules := range rules {
+		field := schema.FindField(column)
+		if field == nil {
+			return fmt.Errorf("style rules for unknown column %q", column)
+		}
+		field.StyleRules = append(slices.Clip(field.StyleRules), columnRules...)
+	}
+	for i := range schema.Fields {
+		if err := compileStyleRules(&schema.Fields[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compileStyleRules parses the conditions of a field's rules. The rules are
+// copied first so fields sharing a rule slice are not modified.
+func compileStyleRules(field *Field) error {
+	if len(field.StyleRules) == 0 {
+		return nil
+	}
+	field.StyleRules = append([]StyleRule(nil), field.StyleRules...)
+	for i, rule := range field.StyleRules {
+		if rule.expr != nil {
+			continue
+		}
+		expr, err := ParseExpression(rule.When)
+		if err != nil {
+			return fmt.Errorf("style rule for field %q: %w", field.Name, err)
+		}
+		field.StyleRules[i].expr = expr
+	}
+	return nil
+}
+
+// cellStyle returns the style of the first rule of field that matches the
+// cell of key in record, or "" when none does
+func cellStyle(field *Field, record Record, key string) CellStyle {
+	if field == nil || len(field.StyleRules) == 0 {
+		return ""
+	}
+	env := record
+	if _, exists := record["value"]; !exists {
+		env = maps.Clone(record)
+		env["value"] = record[key]
+	}
+	for _, rule := range field.StyleRules {
+		expr := rule.expr
+		if expr == nil {
+			// Rules on schemas built without NewTableContent are parsed here
+			parsed, err := ParseExpression(rule.When)
+			if err != nil {
+				continue
+			}
+			expr = parsed
+		}
+		if matched, err := expr.EvalBool(env); err == nil && matched {
+			return rule.Style
+		}
+	}
+	return ""
+}
+
+// cellStyleColors are the ANSI attributes table output uses for each style
+var cellStyleColors = map[CellStyle][]color.Attribute{
+	CellStyleCritical: {color.FgRed, color.Bold},
+	CellStyleNegative: {color.FgRed},
+	CellStyleWarning:  {color.FgYellow},
+	CellStylePositive: {color.FgGreen},
+	CellStyleInfo:     {color.FgBlue},
+}
+
+// styleCellANSI colors each line of text for style. Lines are colored
+// separately so wrapping and multi-line cells keep their colors.
+func styleCellANSI(text string, style CellStyle) string {
+	attrs, ok := cellStyleColors[style]
+	if !ok || text == "" {
+		return text
+	}
+	col := color.New(attrs...)
+	col.EnableColor()
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = col.Sprint(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// cellStyleMarkers are the prefixes Markdown output uses for each style
+var cellStyleMarkers = map[CellStyle]string{
+	CellStyleCritical: "🚨",
+	CellStyleNegative: "❌",
+	CellStyleWarning:  "⚠️",
+	CellStylePositive: "✅",
+	CellStyleInfo:     "ℹ️",
+}
+
+// styleCellMarkdown prefixes an escaped cell with the style's emoji; critical
+// cells are also bold
+func styleCellMarkdown(cell string, style CellStyle) string {
+	marker, ok := cellStyleMarkers[style]
+	if !ok || cell == "" {
+		return cell
+	}
+	if style == CellStyleCritical {
+		cell = "**" + cell + "**"
+	}
+	return marker + " " + cell
+}
+
+// cellStyleClass returns the HTML class for style, or "" for no style
+func cellStyleClass(style CellStyle) string {
+	if _, ok := cellStyleColors[style]; !ok {
+		return ""
+	}
+	return "cell-" + string(style)
+}
@@ -0,0 +1,149 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCellStyle_Rules(t *testing.T) {
+	table, err := NewTableContent("", []Record{
+		{"Host": "web", "CPU": 95, "Status": "failed"},
+		{"Host": "db", "CPU": 40, "Status": "ok"},
+	}, WithSchema(
+		Field{Name: "Host"},
+		Field{Name: "CPU", StyleRules: []StyleRule{
+			{When: "value > 90", Style: CellStyleCritical},
+			{When: "value > 30", Style: CellStyleWarning},
+		}},
+		Field{Name: "Status"},
+	), WithStyleRules("Status",
+		StyleRule{When: `Status == "failed"`, Style: CellStyleNegative},
+		StyleRule{When: `value == "ok"`, Style: CellStylePositive},
+	))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	schema := table.getSchema()
+	records := table.Records()
+
+	tests := map[string]struct {
+		record int
+		key    string
+		want   CellStyle
+	}{
+		"first match wins":      {record: 0, key: "CPU", want: CellStyleCritical},
+		"later rule":            {record: 1, key: "CPU", want: CellStyleWarning},
+		"column reference":      {record: 0, key: "Status", want: CellStyleNegative},
+		"value alias":           {record: 1, key: "Status", want: CellStylePositive},
+		"field without rules":   {record: 0, key: "Host", want: ""},
+		"unknown field ignored": {record: 0, key: "Missing", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := cellStyle(schema.FindField(tc.key), records[tc.record], tc.key); got != tc.want {
+				t.Errorf("cellStyle() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCellStyle_EvaluationErrorDoesNotMatch(t *testing.T) {
+	field := &Field{Name: "CPU", StyleRules: []StyleRule{{When: "value > 90", Style: CellStyleCritical}}}
+	if got := cellStyle(field, Record{"CPU": "n/a"}, "CPU"); got != "" {
+		t.Errorf("cellStyle() = %q, want no style", got)
+	}
+}
+
+func TestCellStyle_InvalidRules(t *testing.T) {
+	tests := map[string][]TableOption{
+		"syntax error in field rule": {WithSchema(Field{Name: "A", StyleRules: []StyleRule{{When: "value >", Style: CellStyleInfo}}})},
+		"unknown column":             {WithKeys("A"), WithStyleRules("B", StyleRule{When: "value > 1", Style: CellStyleInfo})},
+	}
+
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewTableContent("", []Record{{"A": 1}}, opts...); err == nil {
+				t.Error("NewTableContent() error = nil, want error")
+			}
+		})
+	}
+
+	_, err := NewStyleRule("value >", CellStyleInfo)
+	var exprErr *ExpressionError
+	if !errors.As(err, &exprErr) {
+		t.Errorf("NewStyleRule() error = %v, want *ExpressionError", err)
+	}
+}
+
+func TestCellStyle_Render(t *testing.T) {
+	doc := New().Table("", []Record{
+		{"Host": "web", "CPU": 95, "Status": "failed"},
+		{"Host": "db", "CPU": 40, "Status": "ok"},
+	}, WithSchema(
+		Field{Name: "Host"},
+		Field{Name: "CPU", StyleRules: []StyleRule{
+			{When: "value > 90", Style: CellStyleCritical},
+			{When: "value > 30", Style: CellStyleWarning},
+		}},
+		Field{Name: "Status"},
+	), WithStyleRules("Status",
+		StyleRule{When: `Status == "failed"`, Style: CellStyleNegative},
+		StyleRule{When: `value == "ok"`, Style: CellStylePositive},
+	)).Build()
+
+	tests := map[string]struct {
+		format  Format
+		want    []string
+		notWant []string
+	}{
+		"table": {
+			format:  TableWithStyle("Default"),
+			want:    []string{styleCellANSI("95", CellStyleCritical), styleCellANSI("failed", CellStyleNegative), styleCellANSI("ok", CellStylePositive)},
+			notWant: []string{styleCellANSI("web", CellStyleCritical)},
+		},
+		"html": {
+			format: HTML(),
+			want:   []string{`<td class="cell-critical">95</td>`, `<td class="cell-warning">40</td>`, `<td class="cell-negative">failed</td>`, "<td>web</td>"},
+		},
+		"markdown": {
+			format: Markdown(),
+			want:   []string{"| web | 🚨 **95** | ❌ failed |", "| db | ⚠️ 40 | ✅ ok |"},
+		},
+		"csv": {
+			format: CSV(),
+			want:   []string{"web,95,failed\n"},
+		},
+		"json": {
+			format:  JSON(),
+			want:    []string{`"CPU": 95`},
+			notWant: []string{"critical", "\x1b["},
+		},
+		"yaml": {
+			format:  YAML(),
+			want:    []string{"CPU: 95"},
+			notWant: []string{"critical", "\x1b["},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.format.Renderer.Render(context.Background(), doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("output missing %q\n%s", want, out)
+				}
+			}
+			for _, notWant := range tc.notWant {
+				if strings.Contains(string(out), notWant) {
+					t.Errorf("output contains %q\n%s", notWant, out)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package output
 
 import (
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestChartContent_Creation(t *testing.T) {
@@ -151,6 +153,24 @@ func TestChartContent_AppendText(t *testing.T) {
 			{Label: "Product B", Value: 30.2},
 		}, true),
 		contains: []string{"Market Share", "Chart Type: pie", "Product A: 45.50", "Product B: 30.20"},
+	}, "bar chart text": {
+
+		chart: NewBarChart("Sales", []string{"Q1", "Q2"}, []ChartSeries{
+			{Name: "2024", Values: []float64{10, 12.5}},
+		}),
+		contains: []string{"Sales", "Chart Type: bar", "2024: Q1=10.00, Q2=12.50"},
+	}, "line chart text": {
+
+		chart: NewLineChart("Latency", []string{"Mon"}, []ChartSeries{
+			{Name: "p99", Values: []float64{120, 130}},
+		}),
+		contains: []string{"Chart Type: line", "p99: Mon=120.00, 130.00"},
+	}, "scatter chart text": {
+
+		chart: NewScatterChart("Latency", []ScatterSeries{
+			{Name: "api", Points: []XYPoint{{X: 1, Y: 2.5}, {X: 3, Y: 4}}},
+		}),
+		contains: []string{"Latency", "Chart Type: scatter", "api: (1.00, 2.50), (3.00, 4.00)"},
 	}}
 
 	for name, tt := range tests {
@@ -222,6 +242,85 @@ func TestBuilder_ChartMethods(t *testing.T) {
 	}
 }
 
+func TestNewTimeSeriesChart(t *testing.T) {
+	tests := map[string]struct {
+		times []time.Time
+		want  []string
+	}{
+		"dates": {
+			times: []time.Time{
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+			},
+			want: []string{"2024-01-01", "2024-01-08"},
+		},
+		"dates and times": {
+			times: []time.Time{
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 6, 30, 0, 0, time.UTC),
+			},
+			want: []string{"2024-01-01 00:00", "2024-01-01 06:30"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			chart := NewTimeSeriesChart("Requests", tc.times, []ChartSeries{{Name: "api", Values: []float64{1, 2}}})
+			if chart.GetChartType() != ChartTypeLine {
+				t.Errorf("GetChartType() = %q, want %q", chart.GetChartType(), ChartTypeLine)
+			}
+			data, ok := chart.GetData().(*LineData)
+			if !ok {
+				t.Fatalf("GetData() = %T, want *LineData", chart.GetData())
+			}
+			if !slices.Equal(data.Categories, tc.want) {
+				t.Errorf("Categories = %v, want %v", data.Categories, tc.want)
+			}
+			if !slices.EqualFunc(data.Times, tc.times, time.Time.Equal) {
+				t.Errorf("Times = %v, want %v", data.Times, tc.times)
+			}
+		})
+	}
+}
+
+func TestBuilder_SeriesChartMethods(t *testing.T) {
+	categories := []string{"Q1", "Q2"}
+	series := []ChartSeries{{Name: "2024", Values: []float64{1, 2}}}
+	doc := New().
+		BarChart("Bars", categories, series).
+		StackedBarChart("Stacked", categories, series).
+		LineChart("Lines", categories, series).
+		TimeSeriesChart("Over time", []time.Time{time.Now(), time.Now().Add(time.Hour)}, series).
+		ScatterChart("Points", []ScatterSeries{{Points: []XYPoint{{X: 1, Y: 1}}}}).
+		Build()
+
+	want := []struct {
+		chartType string
+		stacked   bool
+	}{
+		{ChartTypeBar, false},
+		{ChartTypeBar, true},
+		{ChartTypeLine, false},
+		{ChartTypeLine, false},
+		{ChartTypeScatter, false},
+	}
+	contents := doc.GetContents()
+	if len(contents) != len(want) {
+		t.Fatalf("got %d contents, want %d", len(contents), len(want))
+	}
+	for i, content := range contents {
+		chart, ok := content.(*ChartContent)
+		if !ok {
+			t.Fatalf("content %d = %T, want *ChartContent", i, content)
+		}
+		if chart.GetChartType() != want[i].chartType {
+			t.Errorf("content %d chart type = %q, want %q", i, chart.GetChartType(), want[i].chartType)
+		}
+		if bar, ok := chart.GetData().(*BarData); ok && bar.Stacked != want[i].stacked {
+			t.Errorf("content %d Stacked = %v, want %v", i, bar.Stacked, want[i].stacked)
+		}
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
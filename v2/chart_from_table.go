@@ -0,0 +1,236 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GanttColumns names the table columns NewGanttChartFromTable reads each
+// task field from. Title is required; the other fields are left empty when
+// their column is not set.
+type GanttColumns struct {
+	ID        string
+	Title     string
+	StartDate string
+	EndDate   string
+	// Duration values may be Mermaid durations such as "3d", time.Duration
+	// values or numbers of days
+	Duration string
+	// Dependencies values may be string slices or task IDs separated by
+	// commas or spaces
+	Dependencies string
+	Status       string
+	Section      string
+}
+
+// NewPieChartFromTable creates a pie chart with a slice per table row,
+// labelled by labelColumn and sized by valueColumn. Numeric strings are
+// converted to numbers; rows without a value are left out, and any other
+// value that is not a finite number is an error. The chart takes the table's
+// title and, like the other table chart builders, reads the rows left after
+// the table's transformations (see WithTransformations).
+func NewPieChartFromTable(table *TableContent, labelColumn, valueColumn string) (*ChartContent, error) {
+	table, err := chartTable(table)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkChartColumns(table, labelColumn, valueColumn); err != nil {
+		return nil, err
+	}
+
+	pieSlices := make([]PieSlice, 0, len(table.records))
+	for i, record := range table.records {
+		value, ok, err := chartValue(record, valueColumn, i)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		pieSlices = append(pieSlices, PieSlice{Label: formatValue(record[labelColumn]), Value: value})
+	}
+	return NewPieChart(table.title, pieSlices, false), nil
+}
+
+// NewBarChartFromTable creates a bar chart with a category per table row,
+// named by categoryColumn, and a series per column of seriesColumns, named
+// after the column. Numeric strings are converted to numbers; missing values
+// count as 0, so every format can write them, and any other value that is
+// not a finite number is an error. The chart takes the table's title and
+// reads the rows left after the table's transformations.
+func NewBarChartFromTable(table *TableContent, categoryColumn string, seriesColumns ...string) (*ChartContent, error) {
+	table, err := chartTable(table)
+	if err != nil {
+		return nil, err
+	}
+	if len(seriesColumns) == 0 {
+		return nil, fmt.Errorf("bar chart needs at least one series column")
+	}
+	if err := checkChartColumns(table, append([]string{categoryColumn}, seriesColumns...)...); err != nil {
+		return nil, err
+	}
+
+	categories := make([]string, len(table.records))
+	series := make([]ChartSeries, len(seriesColumns))
+	for j, column := range seriesColumns {
+		series[j] = ChartSeries{Name: column, Values: make([]float64, len(table.records))}
+	}
+	for i, record := range table.records {
+		categories[i] = formatValue(record[categoryColumn])
+		for j, column := range seriesColumns {
+			value, _, err := chartValue(record, column, i)
+			if err != nil {
+				return nil, err
+			}
+			series[j].Values[i] = value
+		}
+	}
+	return NewBarChart(table.title, categories, series), nil
+}
+
+// NewGanttChartFromTable creates a Gantt chart with a task per table row,
+// reading the task fields from the columns named in columns. time.Time
+// start and end dates are written as YYYY-MM-DD. Every row needs a title.
+// The chart takes the table's title and reads the rows left after the
+// table's transformations.
+func NewGanttChartFromTable(table *TableContent, columns GanttColumns) (*ChartContent, error) {
+	table, err := chartTable(table)
+	if err != nil {
+		return nil, err
+	}
+	if columns.Title == "" {
+		return nil, fmt.Errorf("gantt chart needs a title column")
+	}
+	if err := checkChartColumns(table, columns.ID, columns.Title, columns.StartDate, columns.EndDate,
+		columns.Duration, columns.Dependencies, columns.Status, columns.Section); err != nil {
+		return nil, err
+	}
+
+	// text returns the value of column as text, or "" when column is unset
+	text := func(record Record, column string) string {
+		if column == "" {
+			return ""
+		}
+		if t, ok := record[column].(time.Time); ok {
+			return t.Format(time.DateOnly)
+		}
+		return formatValue(record[column])
+	}
+
+	tasks := make([]GanttTask, len(table.records))
+	for i, record := range table.records {
+		task := GanttTask{
+			ID:        text(record, columns.ID),
+			Title:     text(record, columns.Title),
+			StartDate: text(record, columns.StartDate),
+			EndDate:   text(record, columns.EndDate),
+			Status:    text(record, columns.Status),
+			Section:   text(record, columns.Section),
+		}
+		if task.Title == "" {
+			return nil, fmt.Errorf("row %d: column %q has no task title", i+1, columns.Title)
+		}
+		if columns.Duration != "" {
+			task.Duration = ganttDuration(record[columns.Duration])
+		}
+		if columns.Dependencies != "" {
+			task.Dependencies = ganttDependencies(record[columns.Dependencies])
+		}
+		tasks[i] = task
+	}
+	return NewGanttChart(table.title, tasks), nil
+}
+
+// chartTable returns table with its transformations applied, so a chart
+// shows the rows the table shows when it is rendered
+func chartTable(table *TableContent) (*TableContent, error) {
+	if table == nil {
+		return nil, fmt.Errorf("table content cannot be nil")
+	}
+	transformed, err := applyContentTransformations(context.Background(), table)
+	if err != nil {
+		return nil, err
+	}
+	result, ok := transformed.(*TableContent)
+	if !ok {
+		return nil, fmt.Errorf("table transformations produced %T, not a table", transformed)
+	}
+	return result, nil
+}
+
+// checkChartColumns returns an error for the first column that is set but
+// not in the table's schema
+func checkChartColumns(table *TableContent, columns ...string) error {
+	for _, column := range columns {
+		if column != "" && (table.schema == nil || !table.schema.HasField(column)) {
+			return fmt.Errorf("unknown column %q", column)
+		}
+	}
+	return nil
+}
+
+// chartValue returns the value of column in the row-th record as a number.
+// It reports false for a missing value and returns an error for values that
+// are not finite numbers or numeric strings, such as "NaN" or "Inf".
+func chartValue(record Record, column string, row int) (float64, bool, error) {
+	val := record[column]
+	if fv, ok := val.(FormattedValue); ok {
+		val = fv.Raw
+	}
+	if val == nil {
+		return 0, false, nil
+	}
+	n, _, ok := exprNumber(val, true)
+	if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, false, fmt.Errorf("row %d: column %q has non-numeric value %q", row+1, column, formatValue(val))
+	}
+	return n, true, nil
+}
+
+// ganttDuration converts a duration value to a Mermaid task duration.
+// Strings are kept as they are unless they are numbers, which like other
+// numbers count days.
+func ganttDuration(val any) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
+	case time.Duration:
+		for _, unit := range []struct {
+			size   time.Duration
+			suffix string
+		}{{24 * time.Hour, "d"}, {time.Hour, "h"}, {time.Minute, "m"}} {
+			if v%unit.size == 0 {
+				return strconv.FormatInt(int64(v/unit.size), 10) + unit.suffix
+			}
+		}
+		return strconv.FormatFloat(v.Seconds(), 'f', -1, 64) + "s"
+	}
+	if days, _, ok := exprNumber(val, true); ok {
+		return strconv.FormatFloat(days, 'f', -1, 64) + "d"
+	}
+	return formatValue(val)
+}
+
+// ganttDependencies converts a dependencies value, a slice or a list of task
+// IDs separated by commas or spaces, to task IDs
+func ganttDependencies(val any) []string {
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case []string:
+		return v
+	case []any:
+		ids := make([]string, len(v))
+		for i, id := range v {
+			ids[i] = formatValue(id)
+		}
+		return ids
+	}
+	return strings.FieldsFunc(formatValue(val), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
@@ -0,0 +1,259 @@
+package output
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func chartTestTable(t *testing.T, records []Record, keys ...string) *TableContent {
+	t.Helper()
+	table, err := NewTableContent("Costs", records, WithKeys(keys...))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	return table
+}
+
+func TestNewPieChartFromTable(t *testing.T) {
+	table := chartTestTable(t, []Record{
+		{"Service": "EC2", "Cost": 12.5},
+		{"Service": "S3", "Cost": "3"},
+		{"Service": "Lambda", "Cost": NewFormattedValue(2, "$2.00")},
+		{"Service": "Unused", "Cost": nil},
+	}, "Service", "Cost")
+
+	chart, err := NewPieChartFromTable(table, "Service", "Cost")
+	if err != nil {
+		t.Fatalf("NewPieChartFromTable() error = %v", err)
+	}
+	if chart.GetTitle() != "Costs" || chart.GetChartType() != ChartTypePie {
+		t.Errorf("chart = %q %q, want Costs pie", chart.GetTitle(), chart.GetChartType())
+	}
+	want := []PieSlice{{Label: "EC2", Value: 12.5}, {Label: "S3", Value: 3}, {Label: "Lambda", Value: 2}}
+	if got := chart.GetData().(*PieData).Slices; !slices.Equal(got, want) {
+		t.Errorf("Slices = %v, want %v", got, want)
+	}
+}
+
+func TestNewBarChartFromTable(t *testing.T) {
+	table := chartTestTable(t, []Record{
+		{"Quarter": "Q1", "EU": 10, "US": uint8(7)},
+		{"Quarter": "Q2", "EU": " 12.5 ", "US": nil},
+	}, "Quarter", "EU", "US")
+
+	chart, err := NewBarChartFromTable(table, "Quarter", "EU", "US")
+	if err != nil {
+		t.Fatalf("NewBarChartFromTable() error = %v", err)
+	}
+	data := chart.GetData().(*BarData)
+	if !slices.Equal(data.Categories, []string{"Q1", "Q2"}) {
+		t.Errorf("Categories = %v", data.Categories)
+	}
+	if len(data.Series) != 2 || data.Series[0].Name != "EU" || data.Series[1].Name != "US" {
+		t.Fatalf("Series = %+v", data.Series)
+	}
+	if got := data.Series[0].Values; !slices.Equal(got, []float64{10, 12.5}) {
+		t.Errorf("EU values = %v", got)
+	}
+	if got := data.Series[1].Values; !slices.Equal(got, []float64{7, 0}) {
+		t.Errorf("US values = %v, want [7 0]", got)
+	}
+
+	// Missing values must not stop formats such as JSON writing the chart
+	out, err := JSON().Renderer.Render(context.Background(), New().AddContent(chart).Build())
+	if err != nil {
+		t.Fatalf("JSON Render() error = %v", err)
+	}
+	if !strings.Contains(string(out), `"Name": "US"`) {
+		t.Errorf("JSON output missing series US\n%s", out)
+	}
+}
+
+func TestNewGanttChartFromTable(t *testing.T) {
+	table := chartTestTable(t, []Record{
+		{"Key": "a", "Task": "Build", "Start": time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), "Length": 72 * time.Hour, "Phase": "Dev"},
+		{"Key": "b", "Task": "Test", "Start": "", "Length": 2, "After": "a", "State": "crit"},
+		{"Key": "c", "Task": "Ship", "Start": "2024-01-10", "End": "2024-01-11", "After": []string{"a", "b"}},
+	}, "Key", "Task", "Start", "End", "Length", "After", "State", "Phase")
+
+	chart, err := NewGanttChartFromTable(table, GanttColumns{
+		ID: "Key", Title: "Task", StartDate: "Start", EndDate: "End", Duration: "Length",
+		Dependencies: "After", Status: "State", Section: "Phase",
+	})
+	if err != nil {
+		t.Fatalf("NewGanttChartFromTable() error = %v", err)
+	}
+	tasks := chart.GetData().(*GanttData).Tasks
+	want := []GanttTask{
+		{ID: "a", Title: "Build", StartDate: "2024-01-01", Duration: "3d", Section: "Dev"},
+		{ID: "b", Title: "Test", Duration: "2d", Dependencies: []string{"a"}, Status: "crit"},
+		{ID: "c", Title: "Ship", StartDate: "2024-01-10", EndDate: "2024-01-11", Dependencies: []string{"a", "b"}},
+	}
+	if len(tasks) != len(want) {
+		t.Fatalf("got %d tasks, want %d", len(tasks), len(want))
+	}
+	for i := range want {
+		got := tasks[i]
+		if got.ID != want[i].ID || got.Title != want[i].Title || got.StartDate != want[i].StartDate ||
+			got.EndDate != want[i].EndDate || got.Duration != want[i].Duration || got.Status != want[i].Status ||
+			got.Section != want[i].Section || !slices.Equal(got.Dependencies, want[i].Dependencies) {
+			t.Errorf("task %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestGanttDuration(t *testing.T) {
+	tests := map[string]struct {
+		val  any
+		want string
+	}{
+		"mermaid duration": {val: "1.5w", want: "1.5w"},
+		"numeric string":   {val: "4", want: "4d"},
+		"float days":       {val: 0.5, want: "0.5d"},
+		"hours":            {val: 36 * time.Hour, want: "36h"},
+		"minutes":          {val: 90 * time.Minute, want: "90m"},
+		"seconds":          {val: 1500 * time.Millisecond, want: "1.5s"},
+		"missing":          {val: nil, want: ""},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ganttDuration(tc.val); got != tc.want {
+				t.Errorf("ganttDuration(%v) = %q, want %q", tc.val, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestChartFromTable_Transformations(t *testing.T) {
+	table, err := NewTableContent("Costs", []Record{
+		{"Service": "EC2", "Cost": 12.5},
+		{"Service": "S3", "Cost": 3.0},
+		{"Service": "Lambda", "Cost": 0.5},
+		{"Service": "RDS", "Cost": 8.0},
+	}, WithKeys("Service", "Cost"), WithTransformations(
+		NewFilterOp(func(r Record) bool { return r["Cost"].(float64) > 1 }),
+		NewSortOp(SortKey{Column: "Cost", Direction: Descending}),
+		NewLimitOp(2),
+	))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+
+	pie, err := NewPieChartFromTable(table, "Service", "Cost")
+	if err != nil {
+		t.Fatalf("NewPieChartFromTable() error = %v", err)
+	}
+	if got, want := pie.GetData().(*PieData).Slices, []PieSlice{{Label: "EC2", Value: 12.5}, {Label: "RDS", Value: 8}}; !slices.Equal(got, want) {
+		t.Errorf("Slices = %v, want %v", got, want)
+	}
+
+	bar, err := NewBarChartFromTable(table, "Service", "Cost")
+	if err != nil {
+		t.Fatalf("NewBarChartFromTable() error = %v", err)
+	}
+	if got := bar.GetData().(*BarData).Categories; !slices.Equal(got, []string{"EC2", "RDS"}) {
+		t.Errorf("Categories = %v, want [EC2 RDS]", got)
+	}
+
+	gantt, err := NewGanttChartFromTable(table, GanttColumns{Title: "Service"})
+	if err != nil {
+		t.Fatalf("NewGanttChartFromTable() error = %v", err)
+	}
+	if got := gantt.GetData().(*GanttData).Tasks; len(got) != 2 || got[0].Title != "EC2" || got[1].Title != "RDS" {
+		t.Errorf("Tasks = %+v, want EC2 and RDS", got)
+	}
+
+	// The table itself is left untransformed
+	if got := len(table.Records()); got != 4 {
+		t.Errorf("table has %d records after building charts, want 4", got)
+	}
+
+	invalid, err := NewTableContent("Costs", []Record{{"Service": "EC2"}}, WithTransformations(NewLimitOp(-1)))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	if _, err := NewPieChartFromTable(invalid, "Service", "Service"); err == nil {
+		t.Error("NewPieChartFromTable() error = nil, want invalid transformation error")
+	}
+}
+
+func TestChartFromTable_Errors(t *testing.T) {
+	table := chartTestTable(t, []Record{
+		{"Name": "a", "Value": 1},
+		{"Name": "", "Value": "n/a"},
+	}, "Name", "Value")
+
+	tests := map[string]struct {
+		build func() error
+		want  string
+	}{
+		"pie nil table": {
+			build: func() error { _, err := NewPieChartFromTable(nil, "Name", "Value"); return err },
+			want:  "table content cannot be nil",
+		},
+		"pie unknown column": {
+			build: func() error { _, err := NewPieChartFromTable(table, "Name", "Cost"); return err },
+			want:  `unknown column "Cost"`,
+		},
+		"pie non-numeric value": {
+			build: func() error { _, err := NewPieChartFromTable(table, "Name", "Value"); return err },
+			want:  `row 2: column "Value" has non-numeric value "n/a"`,
+		},
+		"pie NaN string": {
+			build: func() error {
+				_, err := NewPieChartFromTable(chartTestTable(t, []Record{{"Name": "a", "Value": "NaN"}}, "Name", "Value"), "Name", "Value")
+				return err
+			},
+			want: `row 1: column "Value" has non-numeric value "NaN"`,
+		},
+		"bar infinite value": {
+			build: func() error {
+				_, err := NewBarChartFromTable(chartTestTable(t, []Record{{"Name": "a", "Value": "Infinity"}}, "Name", "Value"), "Name", "Value")
+				return err
+			},
+			want: `row 1: column "Value" has non-numeric value "Infinity"`,
+		},
+		"bar nil table": {
+			build: func() error { _, err := NewBarChartFromTable(nil, "Name", "Value"); return err },
+			want:  "table content cannot be nil",
+		},
+		"bar without series": {
+			build: func() error { _, err := NewBarChartFromTable(table, "Name"); return err },
+			want:  "bar chart needs at least one series column",
+		},
+		"bar non-numeric value": {
+			build: func() error { _, err := NewBarChartFromTable(table, "Name", "Value"); return err },
+			want:  `row 2: column "Value" has non-numeric value "n/a"`,
+		},
+		"gantt nil table": {
+			build: func() error { _, err := NewGanttChartFromTable(nil, GanttColumns{Title: "Name"}); return err },
+			want:  "table content cannot be nil",
+		},
+		"gantt without title column": {
+			build: func() error { _, err := NewGanttChartFromTable(table, GanttColumns{ID: "Name"}); return err },
+			want:  "gantt chart needs a title column",
+		},
+		"gantt unknown column": {
+			build: func() error {
+				_, err := NewGanttChartFromTable(table, GanttColumns{Title: "Name", StartDate: "Start"})
+				return err
+			},
+			want: `unknown column "Start"`,
+		},
+		"gantt empty title": {
+			build: func() error { _, err := NewGanttChartFromTable(table, GanttColumns{Title: "Name"}); return err },
+			want:  `row 2: column "Name" has no task title`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.build()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
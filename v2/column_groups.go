@@ -0,0 +1,216 @@
+package output
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// columnGroupSeparator joins group and column names in flattened headers
+const columnGroupSeparator = " / "
+
+// ColumnGroup places columns under a shared header. Table output merges the
+// group name over its columns in an extra header row, HTML uses colspan
+// headers, and CSV, Markdown and XLSX flatten the header to "Group / Column".
+// Columns of a group are shown together wherever they are adjacent in the
+// key order.
+type ColumnGroup struct {
+	Name    string
+	Columns []string
+	// Labels optionally replaces column names in headers under the group,
+	// so columns named "prod_cpu" and "staging_cpu" can both show as "CPU"
+	Labels map[string]string
+	// Nest makes JSON and YAML records hold the group's values in an object
+	// named after the group, keyed by label, instead of at the top level
+	Nest bool
+}
+
+// label returns the header text of column under the group
+func (g *ColumnGroup) label(column string) string {
+	if label, ok := g.Labels[column]; ok && label != "" {
+		return label
+	}
+	return column
+}
+
+// WithColumnGroups groups columns under shared headers (see ColumnGroup).
+// NewTableContent returns an error when a group has no name, names a column
+// that does not exist or a column that is already in another group, or
+// shows two columns under the same label.
+func WithColumnGroups(groups ...ColumnGroup) TableOption {
+	return func(tc *tableConfig) {
+		tc.columnGroups = append(tc.columnGroups, groups...)
+	}
+}
+
+// applyColumnGroups adds the WithColumnGroups groups to the schema and
+// validates every group on it
+func applyColumnGroups(schema *Schema, groups []ColumnGroup) error {
+	if len(groups) == 0 && len(schema.Groups) == 0 {
+		return nil
+	}
+	schema.Groups = append(cloneColumnGroups(schema.Groups), cloneColumnGroups(groups)...)
+	return validateColumnGroups(schema.Groups, joinSchemaColumns(schema))
+}
+
+// validateColumnGroups checks groups against the table's columns: every
+// group needs a name, its columns must exist and be in no other group, its
+// labels must be for its own columns and tell them apart, and a nested group
+// cannot share its name with a column, since JSON and YAML would write both
+// under the same key
+func validateColumnGroups(groups []ColumnGroup, columns []string) error {
+	grouped := make(map[string]string)
+	for _, group := range groups {
+		if group.Name == "" {
+			return fmt.Errorf("column group for %v has no name", group.Columns)
+		}
+		if group.Nest && slices.Contains(columns, group.Name) {
+			return fmt.Errorf("nested column group %q has the same name as a column", group.Name)
+		}
+		labelled := make(map[string]string)
+		for _, column := range group.Columns {
+			if !slices.Contains(columns, column) {
+				return fmt.Errorf("column group %q has unknown column %q", group.Name, column)
+			}
+			if other, ok := grouped[column]; ok {
+				return fmt.Errorf("column %q is in column groups %q and %q", column, other, group.Name)
+			}
+			grouped[column] = group.Name
+			label := group.label(column)
+			if other, ok := labelled[label]; ok {
+				return fmt.Errorf("column group %q shows columns %q and %q both as %q", group.Name, other, column, label)
+			}
+			labelled[label] = column
+		}
+		for column := range group.Labels {
+			if !slices.Contains(group.Columns, column) {
+				return fmt.Errorf("column group %q has a label for column %q, which is not in the group", group.Name, column)
+			}
+		}
+	}
+	return nil
+}
+
+// cloneColumnGroups returns a copy of groups that shares no slices or maps
+func cloneColumnGroups(groups []ColumnGroup) []ColumnGroup {
+	if groups == nil {
+		return nil
+	}
+	cloned := make([]ColumnGroup, len(groups))
+	for i, group := range groups {
+		cloned[i] = group
+		cloned[i].Columns = slices.Clone(group.Columns)
+		cloned[i].Labels = maps.Clone(group.Labels)
+	}
+	return cloned
+}
+
+// columnGroup returns the group column belongs to, or nil
+func (s *Schema) columnGroup(column string) *ColumnGroup {
+	if s == nil {
+		return nil
+	}
+	for i := range s.Groups {
+		if slices.Contains(s.Groups[i].Columns, column) {
+			return &s.Groups[i]
+		}
+	}
+	return nil
+}
+
+// hasColumnGroups reports whether any of keys is in a column group
+func (s *Schema) hasColumnGroups(keys []string) bool {
+	return slices.ContainsFunc(keys, func(key string) bool {
+		return s.columnGroup(key) != nil
+	})
+}
+
+// columnHeader returns the header text of column under its group, which is
+// the column name for ungrouped columns
+func (s *Schema) columnHeader(column string) string {
+	if group := s.columnGroup(column); group != nil {
+		return group.label(column)
+	}
+	return column
+}
+
+// flatColumnHeader returns "Group / Column" for grouped columns and the
+// column name otherwise
+func (s *Schema) flatColumnHeader(column string) string {
+	if group := s.columnGroup(column); group != nil {
+		return group.Name + columnGroupSeparator + group.label(column)
+	}
+	return column
+}
+
+// flatColumnHeaders returns flatColumnHeader for each of keys
+func (s *Schema) flatColumnHeaders(keys []string) []string {
+	headers := make([]string, len(keys))
+	for i, key := range keys {
+		headers[i] = s.flatColumnHeader(key)
+	}
+	return headers
+}
+
+// columnGroupSpan is a run of adjacent columns sharing a group; group is nil
+// for a run of ungrouped columns
+type columnGroupSpan struct {
+	group   *ColumnGroup
+	columns []string
+}
+
+// columnGroupSpans splits keys into runs of adjacent columns in the same
+// group. Each ungrouped column is a run of its own.
+func (s *Schema) columnGroupSpans(keys []string) []columnGroupSpan {
+	var spans []columnGroupSpan
+	for _, key := range keys {
+		group := s.columnGroup(key)
+		if n := len(spans); group != nil && n > 0 && spans[n-1].group == group {
+			spans[n-1].columns = append(spans[n-1].columns, key)
+			continue
+		}
+		spans = append(spans, columnGroupSpan{group: group, columns: []string{key}})
+	}
+	return spans
+}
+
+// renameColumnGroups returns groups with columns renamed by renamed, and
+// an error when the groups are not valid for the renamed columns
+func renameColumnGroups(groups []ColumnGroup, renamed func(string) string, columns []string) ([]ColumnGroup, error) {
+	groups = cloneColumnGroups(groups)
+	for i := range groups {
+		for j, column := range groups[i].Columns {
+			groups[i].Columns[j] = renamed(column)
+		}
+		if groups[i].Labels != nil {
+			labels := make(map[string]string, len(groups[i].Labels))
+			for column, label := range groups[i].Labels {
+				labels[renamed(column)] = label
+			}
+			groups[i].Labels = labels
+		}
+	}
+	if err := validateColumnGroups(groups, columns); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// pruneColumnGroups returns groups without the columns keep rejects, leaving
+// out groups with no columns left
+func pruneColumnGroups(groups []ColumnGroup, keep func(string) bool) []ColumnGroup {
+	var pruned []ColumnGroup
+	for _, group := range cloneColumnGroups(groups) {
+		group.Columns = slices.DeleteFunc(group.Columns, func(column string) bool {
+			return !keep(column)
+		})
+		if len(group.Columns) == 0 {
+			continue
+		}
+		maps.DeleteFunc(group.Labels, func(column, _ string) bool {
+			return !keep(column)
+		})
+		pruned = append(pruned, group)
+	}
+	return pruned
+}
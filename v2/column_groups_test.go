@@ -0,0 +1,223 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func columnGroupTestTable(t *testing.T, nest bool) *TableContent {
+	t.Helper()
+	table, err := NewTableContent("Compare", []Record{
+		{"Host": "web", "prod_cpu": 12, "prod_mem": "2G", "stg_cpu": 3, "stg_mem": "1G", "Note": "ok"},
+	},
+		WithKeys("Host", "prod_cpu", "prod_mem", "stg_cpu", "stg_mem", "Note"),
+		WithColumnGroups(
+			ColumnGroup{Name: "Production", Columns: []string{"prod_cpu", "prod_mem"},
+				Labels: map[string]string{"prod_cpu": "CPU", "prod_mem": "Memory"}, Nest: nest},
+			ColumnGroup{Name: "Staging", Columns: []string{"stg_cpu", "stg_mem"},
+				Labels: map[string]string{"stg_cpu": "CPU", "stg_mem": "Memory"}, Nest: nest},
+		),
+	)
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	return table
+}
+
+func TestColumnGroups_Render(t *testing.T) {
+	tests := map[string]struct {
+		format Format
+		want   []string
+	}{
+		"table": {
+			format: TableWithStyle("Default"),
+			want: []string{
+				"|      |        PRODUCTION       |      STAGING      |      |",
+				"| HOST | CPU        | MEMORY     | CPU     | MEMORY  | NOTE |",
+			},
+		},
+		"html": {
+			format: HTMLFragment(),
+			want: []string{
+				`<th rowspan="2">Host</th>`,
+				`<th colspan="2" scope="colgroup">Production</th>`,
+				"<th>CPU</th>\n        <th>Memory</th>\n        <th>CPU</th>",
+			},
+		},
+		"markdown": {
+			format: Markdown(),
+			want:   []string{"| Host | Production / CPU | Production / Memory | Staging / CPU | Staging / Memory | Note |"},
+		},
+		"csv": {
+			format: CSV(),
+			want:   []string{"Host,Production / CPU,Production / Memory,Staging / CPU,Staging / Memory,Note\n"},
+		},
+		"expanded": {
+			format: TableExpanded("Default"),
+			want:   []string{"Production / Memory | 2G"},
+		},
+		"json stays flat": {
+			format: JSON(),
+			want:   []string{`"prod_cpu": 12`, `"name": "Production"`},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.format.Renderer.Render(context.Background(), New().AddContent(columnGroupTestTable(t, false)).Build())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("output missing %q\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestColumnGroups_Nest(t *testing.T) {
+	doc := New().AddContent(columnGroupTestTable(t, true)).Build()
+
+	out, err := JSON().Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `"Production": {
+        "CPU": 12,
+        "Memory": "2G"
+      },`
+	if !strings.Contains(string(out), want) {
+		t.Errorf("JSON missing nested group %q\n%s", want, out)
+	}
+
+	out, err = YAML().Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(string(out), "Staging:\n        CPU: 3\n        Memory: 1G\n") {
+		t.Errorf("YAML missing nested group\n%s", out)
+	}
+}
+
+func TestColumnGroups_ParseDocument(t *testing.T) {
+	for name, format := range map[string]Format{"json": JSON(), "yaml": YAML()} {
+		t.Run(name, func(t *testing.T) {
+			original := columnGroupTestTable(t, true)
+			out, err := format.Renderer.Render(context.Background(), New().AddContent(original).Build())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			parse := ParseJSONDocument
+			if name == "yaml" {
+				parse = ParseYAMLDocument
+			}
+			doc, err := parse(bytes.NewReader(out))
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			table := doc.GetContents()[0].(*TableContent)
+			if !reflect.DeepEqual(table.Schema().Groups, original.Schema().Groups) {
+				t.Errorf("parsed groups = %+v, want %+v", table.Schema().Groups, original.Schema().Groups)
+			}
+			if record := table.Records()[0]; record["prod_mem"] != "2G" || record["stg_cpu"] != 3 {
+				t.Errorf("parsed record = %v", record)
+			}
+		})
+	}
+}
+
+func TestWithColumnGroups_Errors(t *testing.T) {
+	tests := map[string]ColumnGroup{
+		"no name":                 {Columns: []string{"A"}},
+		"unknown column":          {Name: "G", Columns: []string{"Z"}},
+		"label not in group":      {Name: "G", Columns: []string{"A"}, Labels: map[string]string{"B": "b"}},
+		"nested name is a column": {Name: "B", Columns: []string{"A"}, Nest: true},
+		"duplicate labels":        {Name: "G", Columns: []string{"A", "B"}, Labels: map[string]string{"A": "X", "B": "X"}, Nest: true},
+		"label of another column": {Name: "G", Columns: []string{"A", "B"}, Labels: map[string]string{"B": "A"}},
+	}
+	for name, group := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewTableContent("", []Record{{"A": 1, "B": 2}}, WithKeys("A", "B"), WithColumnGroups(group)); err == nil {
+				t.Error("NewTableContent() error = nil, want error")
+			}
+		})
+	}
+
+	t.Run("column in two groups", func(t *testing.T) {
+		_, err := NewTableContent("", []Record{{"A": 1}}, WithKeys("A"), WithColumnGroups(
+			ColumnGroup{Name: "G1", Columns: []string{"A"}},
+			ColumnGroup{Name: "G2", Columns: []string{"A"}},
+		))
+		if err == nil {
+			t.Error("NewTableContent() error = nil, want error")
+		}
+	})
+}
+
+func TestColumnGroups_ColumnOperations(t *testing.T) {
+	table := columnGroupTestTable(t, false)
+	renamed, err := NewRenameColumnsOp(map[string]string{"prod_cpu": "production_cpu"}).Apply(context.Background(), table)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	group := renamed.(*TableContent).Schema().Groups[0]
+	if !reflect.DeepEqual(group.Columns, []string{"production_cpu", "prod_mem"}) || group.Labels["production_cpu"] != "CPU" {
+		t.Errorf("renamed group = %+v", group)
+	}
+	if table.Schema().Groups[0].Columns[0] != "prod_cpu" {
+		t.Error("rename modified the original table's groups")
+	}
+
+	dropped, err := NewDropColumnsOp("prod_mem").Apply(context.Background(), table)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	out, err := Markdown().Renderer.Render(context.Background(), New().AddContent(dropped).Build())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(string(out), "| Host | Production / CPU | Staging / CPU |") {
+		t.Errorf("output after drop\n%s", out)
+	}
+}
+
+func TestColumnGroups_ColumnOperationsPruneAndValidate(t *testing.T) {
+	table := columnGroupTestTable(t, true)
+
+	_, err := NewRenameColumnsOp(map[string]string{"Host": "Production"}).Apply(context.Background(), table)
+	if err == nil || !strings.Contains(err.Error(), `nested column group "Production" has the same name as a column`) {
+		t.Errorf("rename onto a nested group error = %v", err)
+	}
+
+	tests := map[string]struct {
+		op   Operation
+		want []ColumnGroup
+	}{
+		"drop": {
+			op: NewDropColumnsOp("prod_mem", "stg_cpu", "stg_mem"),
+			want: []ColumnGroup{{Name: "Production", Columns: []string{"prod_cpu"},
+				Labels: map[string]string{"prod_cpu": "CPU"}, Nest: true}},
+		},
+		"select": {
+			op: NewSelectColumnsOp("Host", "stg_mem"),
+			want: []ColumnGroup{{Name: "Staging", Columns: []string{"stg_mem"},
+				Labels: map[string]string{"stg_mem": "Memory"}, Nest: true}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			transformed, err := tc.op.Apply(context.Background(), table)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if got := transformed.(*TableContent).Schema().Groups; !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("groups = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
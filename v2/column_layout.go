@@ -0,0 +1,158 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// Alignment is the horizontal alignment of a column's header and cells in
+// table, Markdown and HTML output
+type Alignment string
+
+// Column alignments. The zero value keeps each renderer's default, which is
+// left-aligned.
+const (
+	AlignDefault Alignment = ""
+	AlignLeft    Alignment = "left"
+	AlignCenter  Alignment = "center"
+	AlignRight   Alignment = "right"
+	// AlignAuto right-aligns numeric columns and left-aligns the rest. A
+	// column is numeric when its Field.Type is int, uint or float, or, for
+	// fields without a type, when every value in it is a number.
+	AlignAuto Alignment = "auto"
+)
+
+// validAlignments are the values the align struct tag option accepts
+var validAlignments = []Alignment{AlignLeft, AlignCenter, AlignRight, AlignAuto}
+
+// columnAlignment resolves the alignment of the column of key. AlignAuto is
+// resolved against records; with no records, such as for a streaming table
+// whose header is written first, only the field type decides.
+func columnAlignment(field *Field, key string, records []Record) Alignment {
+	if field == nil {
+		return AlignDefault
+	}
+	if field.Align != AlignAuto {
+		return field.Align
+	}
+	switch field.Type {
+	case fieldTypeInt, fieldTypeUint, fieldTypeFloat:
+		return AlignRight
+	case "", fieldTypeInterface:
+	default:
+		return AlignLeft
+	}
+
+	numeric := false
+	for _, record := range records {
+		val := record[key]
+		if fv, ok := val.(FormattedValue); ok {
+			val = fv.Raw
+		}
+		if val == nil {
+			continue
+		}
+		if _, _, ok := exprNumber(val, false); !ok {
+			return AlignLeft
+		}
+		numeric = true
+	}
+	if numeric {
+		return AlignRight
+	}
+	return AlignLeft
+}
+
+// textAlign maps an alignment to go-pretty
+func (a Alignment) textAlign() text.Align {
+	switch a {
+	case AlignLeft:
+		return text.AlignLeft
+	case AlignCenter:
+		return text.AlignCenter
+	case AlignRight:
+		return text.AlignRight
+	default:
+		return text.AlignDefault
+	}
+}
+
+// markdownSeparator returns the separator row cell for the alignment
+func (a Alignment) markdownSeparator() string {
+	switch a {
+	case AlignLeft:
+		return ":---"
+	case AlignCenter:
+		return ":---:"
+	case AlignRight:
+		return "---:"
+	default:
+		return "---"
+	}
+}
+
+// htmlClass returns the HTML class for the alignment, or "" for the default
+func (a Alignment) htmlClass() string {
+	switch a {
+	case AlignLeft, AlignCenter, AlignRight:
+		return "align-" + string(a)
+	default:
+		return ""
+	}
+}
+
+// tableColumnConfigs returns the go-pretty column configuration for the
+// columns of fields: alignment of the header, cells and footer, and widths
+// from the field hints and the renderer's maximum column width
+func (t *tableRenderer) tableColumnConfigs(fields []*Field, keys []string, records []Record) []table.ColumnConfig {
+	configs := make([]table.ColumnConfig, len(fields))
+	for i, field := range fields {
+		configs[i] = table.ColumnConfig{
+			Number:   i + 1, // Column numbers are 1-indexed
+			WidthMax: t.maxColumnWidth,
+		}
+		if field == nil {
+			continue
+		}
+		align := columnAlignment(field, keys[i], records).textAlign()
+		configs[i].Align = align
+		configs[i].AlignHeader = align
+		configs[i].AlignFooter = align
+		if field.MaxWidth > 0 && (configs[i].WidthMax == 0 || field.MaxWidth < configs[i].WidthMax) {
+			configs[i].WidthMax = field.MaxWidth
+		}
+		if field.MinWidth > 0 {
+			configs[i].WidthMin = field.MinWidth
+			if configs[i].WidthMax > 0 {
+				configs[i].WidthMin = min(field.MinWidth, configs[i].WidthMax)
+			}
+		}
+	}
+	return configs
+}
+
+// htmlHeaderAttrs returns the class and style attributes of a column's
+// header cell, with a leading space, for its alignment and width hints
+func htmlHeaderAttrs(field *Field, align Alignment) string {
+	var attrs strings.Builder
+	if class := align.htmlClass(); class != "" {
+		fmt.Fprintf(&attrs, ` class="%s"`, class)
+	}
+	if field == nil {
+		return attrs.String()
+	}
+	var styles []string
+	if field.MinWidth > 0 {
+		styles = append(styles, fmt.Sprintf("min-width: %dch", field.MinWidth))
+	}
+	if field.MaxWidth > 0 {
+		styles = append(styles, fmt.Sprintf("max-width: %dch", field.MaxWidth))
+	}
+	if len(styles) > 0 {
+		fmt.Fprintf(&attrs, ` style="%s"`, strings.Join(styles, "; "))
+	}
+	return attrs.String()
+}
@@ -0,0 +1,134 @@
+package output
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestColumnAlignment(t *testing.T) {
+	records := []Record{{"n": 1, "f": 2.5, "s": "x", "mixed": 1, "empty": nil}, {"n": nil, "f": 3, "s": "y", "mixed": "two"}}
+	tests := map[string]struct {
+		field *Field
+		key   string
+		want  Alignment
+	}{
+		"no field":           {nil, "n", AlignDefault},
+		"default":            {&Field{Name: "n"}, "n", AlignDefault},
+		"explicit":           {&Field{Name: "s", Align: AlignRight}, "s", AlignRight},
+		"auto numbers":       {&Field{Name: "n", Align: AlignAuto}, "n", AlignRight},
+		"auto floats":        {&Field{Name: "f", Align: AlignAuto}, "f", AlignRight},
+		"auto text":          {&Field{Name: "s", Align: AlignAuto}, "s", AlignLeft},
+		"auto mixed":         {&Field{Name: "mixed", Align: AlignAuto}, "mixed", AlignLeft},
+		"auto empty":         {&Field{Name: "empty", Align: AlignAuto}, "empty", AlignLeft},
+		"auto by float type": {&Field{Name: "s", Type: "float", Align: AlignAuto}, "s", AlignRight},
+		"auto by other type": {&Field{Name: "n", Type: "string", Align: AlignAuto}, "n", AlignLeft},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := columnAlignment(tc.field, tc.key, records); got != tc.want {
+				t.Errorf("columnAlignment() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestColumnAlignment_Render(t *testing.T) {
+	doc := New().Table("", []Record{
+		{"Service": "api", "Cost": 1250.5, "Status": "ok"},
+		{"Service": "storage", "Cost": 3, "Status": "degraded"},
+	}, WithSchema(
+		Field{Name: "Service", Align: AlignLeft},
+		Field{Name: "Cost", Align: AlignAuto},
+		Field{Name: "Status", Align: AlignCenter, MinWidth: 12},
+	)).Build()
+
+	tests := map[string]struct {
+		format Format
+		want   []string
+	}{
+		"table": {
+			format: TableWithStyle("Default"),
+			want: []string{
+				"| SERVICE |   COST |    STATUS    |",
+				"| api     | 1250.5 |      ok      |",
+				"| storage |      3 |   degraded   |",
+			},
+		},
+		"markdown": {
+			format: Markdown(),
+			want:   []string{"| :--- | ---: | :---: |"},
+		},
+		"html": {
+			format: HTMLFragment(),
+			want: []string{
+				`<th class="align-left">Service</th>`,
+				`<th class="align-right">Cost</th>`,
+				`<th class="align-center" style="min-width: 12ch">Status</th>`,
+				`<td class="align-right">1250.5</td>`,
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.format.Renderer.Render(context.Background(), doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("output missing %q\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestColumnAlignment_HTMLWithCellStyle(t *testing.T) {
+	doc := New().Table("", []Record{{"CPU": 95}}, WithSchema(
+		Field{Name: "CPU", Align: AlignRight, StyleRules: []StyleRule{{When: "value > 90", Style: CellStyleCritical}}},
+	)).Build()
+	out, err := HTMLFragment().Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(string(out), `<td class="cell-critical align-right">95</td>`) {
+		t.Errorf("output missing combined classes\n%s", out)
+	}
+}
+
+func TestFieldMaxWidth_Table(t *testing.T) {
+	doc := New().Table("", []Record{{"Note": "a long note that wraps"}}, WithSchema(Field{Name: "Note", MaxWidth: 10})).Build()
+	out, err := TableWithStyle("Default").Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if width := maxLineWidth(string(out)); width != 14 {
+		t.Errorf("widest line = %d, want 14\n%s", width, out)
+	}
+}
+
+func TestStructTagAlignment(t *testing.T) {
+	type row struct {
+		Name string  `output:"name,maxwidth=20"`
+		Cost float64 `output:"cost,align=right,minwidth=8"`
+	}
+	table, err := NewTableContent("", []row{{Name: "api", Cost: 1}})
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	if got := table.Schema().FindField("name").MaxWidth; got != 20 {
+		t.Errorf("name MaxWidth = %d, want 20", got)
+	}
+	cost := table.Schema().FindField("cost")
+	if cost.Align != AlignRight || cost.MinWidth != 8 {
+		t.Errorf("cost field = %+v, want right aligned with MinWidth 8", cost)
+	}
+
+	for _, tag := range []string{"a,align=middle", "a,minwidth=-1", "a,maxwidth=wide"} {
+		_, err := parseStructTag(tag)
+		if err == nil {
+			t.Errorf("parseStructTag(%q) error = nil, want error", tag)
+		}
+	}
+}
@@ -2,8 +2,10 @@ package output
 
 // Content type name constants used by ContentType.String and renderers.
 const (
-	contentTypeNameRaw     = "raw"
-	contentTypeNameSection = "section"
+	contentTypeNameRaw                = "raw"
+	contentTypeNameSection            = "section"
+	contentTypeNameCollapsibleSection = "collapsible_section"
+	valueTypeNameCollapsible          = "collapsible"
 )
 
 // File extension constants that do not map directly to a format name.
@@ -31,22 +33,34 @@ const (
 // and CSV/collapsible output. These values are part of the serialization
 // contract, so they are defined once and reused.
 const (
-	keyType     = "type"
-	keyContent  = "content"
-	keyFormat   = "format"
-	keyData     = "data"
-	keyKeys     = "keys"
-	keyFields   = "fields"
-	keySummary  = "summary"
-	keyDetails  = "details"
-	keyExpanded = "expanded"
-	keyTitle    = "title"
-	keyLevel    = "level"
-	keyName     = "name"
-	keyHidden   = "hidden"
-	keyBold     = "bold"
-	keyItalic   = "italic"
-	keyColor    = "color"
-	keySize     = "size"
-	keyHeader   = "header"
+	keyType      = "type"
+	keyContent   = "content"
+	keyFormat    = "format"
+	keyData      = "data"
+	keyKeys      = "keys"
+	keyFields    = "fields"
+	keySummary   = "summary"
+	keyDetails   = "details"
+	keyExpanded  = "expanded"
+	keyTitle     = "title"
+	keyLevel     = "level"
+	keyName      = "name"
+	keyHidden    = "hidden"
+	keyBold      = "bold"
+	keyItalic    = "italic"
+	keyColor     = "color"
+	keySize      = "size"
+	keyHeader    = "header"
+	keySchema    = "schema"
+	keyContents  = "contents"
+	keyStyle     = "style"
+	keyChartType = "chart_type"
+	keyNodes     = "nodes"
+	keyEdges     = "edges"
+	keyRecords   = "records"
+	keyFooter    = "footer"
+	keyGroups    = "groups"
+	keyColumns   = "columns"
+	keyLabels    = "labels"
+	keyNest      = "nest"
 )
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"reflect"
 	"sync/atomic"
 )
 
@@ -87,6 +88,7 @@ type TableContent struct {
 	schema          *Schema
 	records         []Record
 	transformations []Operation
+	footer          map[string]AggregateFunc
 
 	// sealed marks this table as attached to a document. Documents are
 	// immutable after Build(), so mutating methods (Transform) refuse to
@@ -122,6 +124,30 @@ var ErrTableKeyOrderGuessed = errors.New("key order auto-detected from map data
 // DetectSchemaFromMap). Callers that need a specific column order must pass
 // WithKeys or WithSchema; Builder.Table additionally records a non-fatal
 // ErrTableKeyOrderGuessed warning when this fallback guessed an order.
+//
+// Data may also be a slice of structs ([]T or []*T). Exported fields become
+// columns in declaration order, configured with an `output` struct tag:
+//
+//	type Instance struct {
+//	    ID     string    `output:"id,order=1"`
+//	    Size   int64     `output:"size,format=bytes"`
+//	    Secret string    `output:"secret,hidden"`
+//	    Launch time.Time `output:"launched"`
+//	    Notes  string    `output:"-"`
+//	}
+//
+// The first tag element renames the column; hidden, order=N, format=NAME and
+// type=TYPE are options. Columns with order= come first, sorted ascending.
+// Embedded structs without a tag name are flattened the way encoding/json
+// does it, with shallower fields shadowing promoted ones. Nil pointer fields
+// (and fields of a nil embedded pointer) become nil; other pointers are
+// dereferenced. time.Time values are kept as-is, while other
+// encoding.TextMarshaler values are stored as their text form. Nil elements
+// of a []*T slice are skipped. Formatter names resolve against
+// WithFieldFormatters first, then the built-in names number, percent, bytes,
+// bytes-si, duration, datetime, date and relative-time; unknown names and
+// malformed tags are errors. The struct layout determines the key order, so
+// struct data never triggers ErrTableKeyOrderGuessed.
 func NewTableContent(title string, data any, opts ...TableOption) (*TableContent, error) {
 	table, _, err := newTableContent(title, data, opts...)
 	return table, err
@@ -140,6 +166,33 @@ func newTableContent(title string, data any, opts ...TableOption) (*TableContent
 		title: title,
 	}
 
+	// Struct slices carry their own schema, so nothing is guessed.
+	records, structSchema, isStruct, err := structTableData(data, tc.formatters)
+	if isStruct {
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to convert struct data to records: %w", err)
+		}
+		switch {
+		case tc.schema != nil:
+			table.schema = tc.schema
+		case len(tc.keys) > 0:
+			structSchema.SetKeyOrder(tc.keys)
+			table.schema = structSchema
+		default:
+			table.schema = structSchema
+		}
+		if err := applyStyleRules(table.schema, tc.styleRules); err != nil {
+			return nil, false, err
+		}
+		if err := applyColumnGroups(table.schema, tc.columnGroups); err != nil {
+			return nil, false, err
+		}
+		table.records = records
+		table.transformations = tc.transformations
+		table.footer = tc.footer
+		return table, false, nil
+	}
+
 	// Determine schema based on options
 	switch {
 	case tc.schema != nil:
@@ -159,15 +212,23 @@ func newTableContent(title string, data any, opts ...TableOption) (*TableContent
 	// when neither an explicit schema nor explicit keys were provided.
 	keyOrderGuessed := tc.schema == nil && len(tc.keys) == 0 && len(table.schema.GetKeyOrder()) > 1
 
+	if err := applyStyleRules(table.schema, tc.styleRules); err != nil {
+		return nil, false, err
+	}
+	if err := applyColumnGroups(table.schema, tc.columnGroups); err != nil {
+		return nil, false, err
+	}
+
 	// Convert data to records
-	records, err := convertToRecords(data)
+	records, err = convertToRecords(data)
 	if err != nil {
 		return nil, false, fmt.Errorf("failed to convert data to records: %w", err)
 	}
 	table.records = records
 
-	// Store transformations from config
+	// Store transformations and footer from config
 	table.transformations = tc.transformations
+	table.footer = tc.footer
 
 	return table, keyOrderGuessed, nil
 }
@@ -213,6 +274,21 @@ func (t *TableContent) Records() []Record {
 	return records
 }
 
+// Footer returns the footer row set with WithFooter, computing each
+// aggregate over the current records. It returns nil when the table has no
+// footer.
+func (t *TableContent) Footer() Record {
+	if len(t.footer) == 0 {
+		return nil
+	}
+	records := t.Records()
+	footer := make(Record, len(t.footer))
+	for column, agg := range t.footer {
+		footer[column] = agg(records, column)
+	}
+	return footer
+}
+
 // seal marks the table as attached to a document, disabling in-place
 // mutation through Transform. See sealContents in transform_data.go.
 func (t *TableContent) seal() {
@@ -248,6 +324,7 @@ func (t *TableContent) Clone() Content {
 		records:         newRecords,
 		schema:          newSchema,
 		transformations: newTransformations,
+		footer:          maps.Clone(t.footer),
 	}
 }
 
@@ -357,6 +434,13 @@ func convertToRecords(data any) ([]Record, error) {
 		}
 		return records, nil
 	default:
+		if elemType := structElemType(data); elemType != nil {
+			plan, err := planForStruct(elemType)
+			if err != nil {
+				return nil, err
+			}
+			return plan.records(reflect.ValueOf(data))
+		}
 		return nil, fmt.Errorf("unsupported data type: %T", data)
 	}
 }
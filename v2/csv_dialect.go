@@ -0,0 +1,302 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// defaultCSVTimeLayouts are the layouts tried when inferring time columns
+var defaultCSVTimeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+// csvDialect describes how CSV is written and read. The zero value is the
+// encoding/csv default: comma delimited, LF line endings, minimal quoting,
+// no byte order mark and a header row for every table.
+type csvDialect struct {
+	delimiter      rune
+	quoteAll       bool
+	crlf           bool
+	bom            bool
+	noHeader       bool
+	null           string
+	tableSeparator *string
+	footer         bool
+	// formulaEscaping escapes cells spreadsheets would evaluate as formulas
+	formulaEscaping bool
+
+	// Reading only
+	comment     rune
+	columns     []string
+	columnTypes map[string]string
+	timeLayouts []string
+}
+
+// CSVOption configures a CSV dialect. Options are shared by CSVWithOptions,
+// ReadCSVTable, ReadCSVTables, WithCSVDialect and WithS3CSVDialect; options
+// that do not apply to an operation are ignored by it.
+type CSVOption func(*csvDialect)
+
+// newCSVDialect applies opts to the default dialect. Nil options are ignored.
+func newCSVDialect(opts ...CSVOption) csvDialect {
+	d := csvDialect{timeLayouts: defaultCSVTimeLayouts}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(&d)
+	}
+	return d
+}
+
+// WithCSVDelimiter sets the field delimiter, e.g. ';' for spreadsheets in
+// locales using a decimal comma or '\t' for TSV. The default is ','.
+func WithCSVDelimiter(delimiter rune) CSVOption {
+	return func(d *csvDialect) {
+		d.delimiter = delimiter
+	}
+}
+
+// WithCSVQuoteAll quotes every written field, including headers and empty
+// values. By default fields are only quoted when they need to be.
+func WithCSVQuoteAll() CSVOption {
+	return func(d *csvDialect) {
+		d.quoteAll = true
+	}
+}
+
+// WithCSVCRLF ends written lines with "\r\n" instead of "\n"
+func WithCSVCRLF() CSVOption {
+	return func(d *csvDialect) {
+		d.crlf = true
+	}
+}
+
+// WithCSVBOM starts written output with a UTF-8 byte order mark, which Excel
+// needs to detect UTF-8 encoded CSV. A byte order mark is always stripped
+// when reading.
+func WithCSVBOM() CSVOption {
+	return func(d *csvDialect) {
+		d.bom = true
+	}
+}
+
+// WithCSVNull sets the text written for nil and missing values, such as
+// "NULL" or `\N`. When reading, cells holding it are nil in every column.
+// The default is an empty field.
+func WithCSVNull(null string) CSVOption {
+	return func(d *csvDialect) {
+		d.null = null
+	}
+}
+
+// WithCSVTableSeparator sets the line written between tables when a document
+// holds more than one, such as "---". An empty separator writes an empty
+// line, which is the default and the only separator the readers recognise.
+func WithCSVTableSeparator(separator string) CSVOption {
+	return func(d *csvDialect) {
+		d.tableSeparator = &separator
+	}
+}
+
+// WithCSVFooter writes the footer of tables created with WithFooter as a
+// trailing row. By default footers are left out, so every row is a record.
+func WithCSVFooter() CSVOption {
+	return func(d *csvDialect) {
+		d.footer = true
+	}
+}
+
+// WithCSVComment skips lines starting with comment, such as '#', when
+// reading. By default no lines are treated as comments.
+func WithCSVComment(comment rune) CSVOption {
+	return func(d *csvDialect) {
+		d.comment = comment
+	}
+}
+
+// WithCSVNoHeader leaves out header rows. When writing, only data rows are
+// written. When reading, columns are named by columns, or Column1, Column2,
+// ... for columns beyond those given.
+func WithCSVNoHeader(columns ...string) CSVOption {
+	return func(d *csvDialect) {
+		d.noHeader = true
+		d.columns = slices.Clone(columns)
+	}
+}
+
+// WithCSVColumnType sets the type of a column instead of inferring it when
+// reading. Supported types are "string", "int", "float", "bool" and "time".
+// Values that cannot be converted are an error wrapping ErrCSVValue.
+func WithCSVColumnType(column, fieldType string) CSVOption {
+	return func(d *csvDialect) {
+		if d.columnTypes == nil {
+			d.columnTypes = make(map[string]string)
+		}
+		d.columnTypes[column] = fieldType
+	}
+}
+
+// WithCSVTimeLayouts sets the layouts used to infer and parse time columns
+// when reading, replacing the defaults (RFC 3339, time.DateTime and
+// time.DateOnly).
+func WithCSVTimeLayouts(layouts ...string) CSVOption {
+	return func(d *csvDialect) {
+		d.timeLayouts = slices.Clone(layouts)
+	}
+}
+
+// CSVWithOptions returns a CSV Format using the given dialect options.
+// CSVWithOptions() without options is equivalent to CSV().
+//
+// Example:
+//
+//	// Semicolon delimited with a byte order mark, for Excel
+//	output.CSVWithOptions(output.WithCSVDelimiter(';'), output.WithCSVBOM())
+//
+//	// TSV with CRLF line endings and every field quoted
+//	output.CSVWithOptions(output.WithCSVDelimiter('\t'), output.WithCSVCRLF(), output.WithCSVQuoteAll())
+func CSVWithOptions(opts ...CSVOption) Format {
+	return Format{Name: FormatCSV, Renderer: &csvRenderer{dialect: newCSVDialect(opts...)}}
+}
+
+// delimiterRune returns the field delimiter, defaulting to ','
+func (d *csvDialect) delimiterRune() rune {
+	if d.delimiter == 0 {
+		return ','
+	}
+	return d.delimiter
+}
+
+// lineEnding returns the line ending written by the dialect
+func (d *csvDialect) lineEnding() string {
+	if d.crlf {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// appendRows returns the part of rendered CSV data to append to existing
+// output: the byte order mark and, unless the dialect has no header, the
+// first line are removed. Line endings are normalised to "\n" unless the
+// dialect writes CRLF. It returns nil when no rows remain.
+func (d *csvDialect) appendRows(data []byte) []byte {
+	data = bytes.TrimPrefix(data, []byte(drawioUTF8BOM))
+	if !d.crlf {
+		// Normalize line endings (handle both LF and CRLF)
+		data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	}
+	if d.noHeader {
+		return data
+	}
+
+	// Strip first line (header) from data
+	lines := bytes.SplitN(data, []byte(d.lineEnding()), 2)
+	if len(lines) < 2 {
+		// Only one line (or empty) - nothing to append after removing header
+		return nil
+	}
+	return lines[1]
+}
+
+// csvRowWriter writes rows in a dialect. Rows are written with csv.Writer
+// unless every field must be quoted, which encoding/csv does not support.
+// Both paths write through one buffer, so output stays in order.
+type csvRowWriter struct {
+	dialect *csvDialect
+	buf     *bufio.Writer
+	csv     *csv.Writer
+}
+
+// newCSVRowWriter creates a row writer for w, writing a byte order mark
+// first when the dialect asks for one.
+func newCSVRowWriter(w io.Writer, dialect *csvDialect) *csvRowWriter {
+	buf := bufio.NewWriter(w)
+	csvWriter := csv.NewWriter(buf)
+	csvWriter.Comma = dialect.delimiterRune()
+	csvWriter.UseCRLF = dialect.crlf
+	if dialect.bom {
+		_, _ = buf.WriteString(drawioUTF8BOM)
+	}
+	return &csvRowWriter{dialect: dialect, buf: buf, csv: csvWriter}
+}
+
+// Write writes one row
+func (rw *csvRowWriter) Write(row []string) error {
+	if !rw.dialect.quoteAll {
+		return rw.csv.Write(row)
+	}
+	if !validCSVDelimiter(rw.csv.Comma) {
+		return fmt.Errorf("invalid CSV delimiter %q", rw.csv.Comma)
+	}
+	if err := rw.flushCSV(); err != nil {
+		return err
+	}
+
+	lineEnding := rw.dialect.lineEnding()
+	for i, field := range row {
+		if i > 0 {
+			_, _ = rw.buf.WriteRune(rw.csv.Comma)
+		}
+		field = strings.ReplaceAll(field, `"`, `""`)
+		if rw.dialect.crlf {
+			field = strings.ReplaceAll(strings.ReplaceAll(field, "\r\n", "\n"), "\n", lineEnding)
+		}
+		_ = rw.buf.WriteByte('"')
+		_, _ = rw.buf.WriteString(field)
+		_ = rw.buf.WriteByte('"')
+	}
+	_, err := rw.buf.WriteString(lineEnding)
+	return err
+}
+
+// WriteHeader writes a header row unless the dialect has no header
+func (rw *csvRowWriter) WriteHeader(keys []string) error {
+	if rw.dialect.noHeader {
+		return nil
+	}
+	if rw.dialect.formulaEscaping {
+		escaped := make([]string, len(keys))
+		for i, key := range keys {
+			escaped[i] = escapeCSVFormula(key)
+		}
+		keys = escaped
+	}
+	return rw.Write(keys)
+}
+
+// WriteSeparator writes the line separating two tables
+func (rw *csvRowWriter) WriteSeparator() error {
+	if rw.dialect.tableSeparator == nil || *rw.dialect.tableSeparator == "" {
+		return rw.csv.Write([]string{})
+	}
+	if err := rw.flushCSV(); err != nil {
+		return err
+	}
+	_, err := rw.buf.WriteString(*rw.dialect.tableSeparator + rw.dialect.lineEnding())
+	return err
+}
+
+// Flush writes buffered rows to the destination and reports any write error
+func (rw *csvRowWriter) Flush() error {
+	if err := rw.flushCSV(); err != nil {
+		return err
+	}
+	return rw.buf.Flush()
+}
+
+// flushCSV moves rows buffered by csv.Writer into the shared buffer
+func (rw *csvRowWriter) flushCSV() error {
+	rw.csv.Flush()
+	return rw.csv.Error()
+}
+
+// validCSVDelimiter mirrors the delimiter check of encoding/csv
+func validCSVDelimiter(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
@@ -0,0 +1,187 @@
+package output
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCSVWithOptions_Render(t *testing.T) {
+	doc := New().
+		Table("", []Record{
+			{"Name": "web", "Note": `say "hi"`, "Count": 3},
+			{"Name": "db", "Count": nil},
+		}, WithKeys("Name", "Note", "Count")).
+		Table("", []Record{{"Region": "eu"}}, WithKeys("Region")).
+		Build()
+
+	tests := map[string]struct {
+		opts []CSVOption
+		want string
+	}{
+		"defaults match CSV()": {
+			want: "Name,Note,Count\nweb,\"say \"\"hi\"\"\",3\ndb,,\n\nRegion\neu\n",
+		},
+		"semicolon with BOM": {
+			opts: []CSVOption{WithCSVDelimiter(';'), WithCSVBOM()},
+			want: "\xef\xbb\xbfName;Note;Count\nweb;\"say \"\"hi\"\"\";3\ndb;;\n\nRegion\neu\n",
+		},
+		"tsv quote all CRLF": {
+			opts: []CSVOption{WithCSVDelimiter('\t'), WithCSVQuoteAll(), WithCSVCRLF()},
+			want: "\"Name\"\t\"Note\"\t\"Count\"\r\n\"web\"\t\"say \"\"hi\"\"\"\t\"3\"\r\n\"db\"\t\"\"\t\"\"\r\n\r\n\"Region\"\r\n\"eu\"\r\n",
+		},
+		"no header": {
+			opts: []CSVOption{WithCSVNoHeader()},
+			want: "web,\"say \"\"hi\"\"\",3\ndb,,\n\neu\n",
+		},
+		"null and table separator": {
+			opts: []CSVOption{WithCSVNull("NULL"), WithCSVTableSeparator("---")},
+			want: "Name,Note,Count\nweb,\"say \"\"hi\"\"\",3\ndb,NULL,NULL\n---\nRegion\neu\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := CSVWithOptions(tc.opts...).Renderer.Render(context.Background(), doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if string(out) != tc.want {
+				t.Errorf("Render() = %q, want %q", out, tc.want)
+			}
+		})
+	}
+}
+
+func TestCSVWithOptions_StreamingTable(t *testing.T) {
+	table, err := NewStreamingTableContent("", countingSeq([]Record{
+		{"Name": "web", "Count": 3},
+		{"Name": "db"},
+	}, new(int)), WithKeys("Name", "Count"))
+	if err != nil {
+		t.Fatalf("NewStreamingTableContent() error = %v", err)
+	}
+
+	var buf strings.Builder
+	format := CSVWithOptions(WithCSVQuoteAll(), WithCSVNull(`\N`))
+	if err := format.Renderer.RenderTo(context.Background(), New().AddContent(table).Build(), &buf); err != nil {
+		t.Fatalf("RenderTo() error = %v", err)
+	}
+	if want := "\"Name\",\"Count\"\n\"web\",\"3\"\n\"db\",\"\\N\"\n"; buf.String() != want {
+		t.Errorf("RenderTo() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCSVWithOptions_InvalidDelimiter(t *testing.T) {
+	doc := New().Table("", []Record{{"Name": "web"}}, WithKeys("Name")).Build()
+	for name, opts := range map[string][]CSVOption{
+		"minimal quoting": {WithCSVDelimiter('"')},
+		"quote all":       {WithCSVDelimiter('\n'), WithCSVQuoteAll()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := CSVWithOptions(opts...).Renderer.Render(context.Background(), doc); err == nil {
+				t.Error("Render() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestCSVDialect_ReadsRenderedOutput(t *testing.T) {
+	doc := New().
+		Table("", []Record{
+			{"Name": "web", "Note": `say "hi"`, "Count": 3},
+			{"Name": "db", "Count": nil},
+		}, WithKeys("Name", "Note", "Count")).
+		Table("", []Record{{"Region": "eu"}}, WithKeys("Region")).
+		Build()
+	opts := []CSVOption{WithCSVDelimiter(';'), WithCSVBOM(), WithCSVQuoteAll(), WithCSVCRLF(), WithCSVNull("NULL")}
+	out, err := CSVWithOptions(opts...).Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	tables, err := ReadCSVTables(strings.NewReader(string(out)), opts...)
+	if err != nil {
+		t.Fatalf("ReadCSVTables() error = %v", err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("read %d tables, want 2", len(tables))
+	}
+	records := tables[0].Records()
+	if records[0]["Note"] != `say "hi"` || records[0]["Count"] != 3 {
+		t.Errorf("first record = %v", records[0])
+	}
+	if records[1]["Note"] != nil || records[1]["Count"] != nil {
+		t.Errorf("second record = %v, want nil Note and Count", records[1])
+	}
+}
+
+func TestCSVDialect_Append(t *testing.T) {
+	tests := map[string]struct {
+		opts     []CSVOption
+		existing string
+		appended string
+		want     string
+	}{
+		"default": {
+			existing: "a,b\n1,2",
+			appended: "a,b\r\n3,4\r\n",
+			want:     "a,b\n1,2\n3,4\n",
+		},
+		"BOM and CRLF": {
+			opts:     []CSVOption{WithCSVBOM(), WithCSVCRLF()},
+			existing: "\xef\xbb\xbfa;b\r\n1;2",
+			appended: "\xef\xbb\xbfa;b\r\n3;4\r\n",
+			want:     "\xef\xbb\xbfa;b\r\n1;2\r\n3;4\r\n",
+		},
+		"no header": {
+			opts:     []CSVOption{WithCSVNoHeader()},
+			existing: "1,2\n",
+			appended: "3,4\n",
+			want:     "1,2\n3,4\n",
+		},
+		"header only": {
+			existing: "a,b\n1,2\n",
+			appended: "a,b\n",
+			want:     "a,b\n1,2\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name+"/file", func(t *testing.T) {
+			dir := t.TempDir()
+			fw, err := NewFileWriterWithOptions(dir, "out.{ext}", WithAppendMode(), WithCSVDialect(tc.opts...))
+			if err != nil {
+				t.Fatalf("NewFileWriterWithOptions() error = %v", err)
+			}
+			path := filepath.Join(dir, "out.csv")
+			if err := os.WriteFile(path, []byte(tc.existing), 0644); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+			if err := fw.Write(context.Background(), FormatCSV, []byte(tc.appended)); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("file = %q, want %q", got, tc.want)
+			}
+		})
+
+		t.Run(name+"/s3", func(t *testing.T) {
+			sw := &S3Writer{}
+			WithS3CSVDialect(tc.opts...)(sw)
+			got, err := sw.combineCSVData([]byte(tc.existing), []byte(tc.appended))
+			if err != nil {
+				t.Fatalf("combineCSVData() error = %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("combineCSVData() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
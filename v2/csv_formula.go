@@ -0,0 +1,213 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+)
+
+// csvFormulaTriggers are the leading characters that make spreadsheet
+// applications evaluate a cell as a formula
+const csvFormulaTriggers = "=+-@\t\r"
+
+// CSVFormulaPolicy selects how an Output protects CSV cells that spreadsheet
+// applications would evaluate as formulas
+type CSVFormulaPolicy int
+
+const (
+	// CSVFormulaAllow writes cells unchanged. This is the default.
+	CSVFormulaAllow CSVFormulaPolicy = iota
+	// CSVFormulaEscape prefixes cells starting with '=', '+', '-', '@', a tab
+	// or a carriage return with a single quote, so spreadsheets show them as
+	// text. Numbers such as "-5" or "+1.5" are left unchanged.
+	CSVFormulaEscape
+)
+
+// WithCSVFormulaEscaping escapes written cells, including headers, that
+// spreadsheet applications would evaluate as formulas (CSV injection). See
+// CSVFormulaEscape for the rule.
+func WithCSVFormulaEscaping() CSVOption {
+	return func(d *csvDialect) {
+		d.formulaEscaping = true
+	}
+}
+
+// WithCSVFormulaPolicy sets the formula policy for every CSV format of the
+// Output. With CSVFormulaEscape, CSV() and CSVWithOptions formats escape
+// cells as if created with WithCSVFormulaEscaping, output of other renderers
+// for the CSV format passes through a CSVFormulaTransformer, and the DrawIO()
+// format escapes its CSV header and data rows, so every writer receives
+// escaped data. The "# " configuration lines of Draw.io output are kept.
+func WithCSVFormulaPolicy(policy CSVFormulaPolicy) OutputOption {
+	return func(o *Output) {
+		o.csvFormulaPolicy = policy
+	}
+}
+
+// escapeCSVFormula returns val prefixed with a single quote when a
+// spreadsheet would evaluate it as a formula
+func escapeCSVFormula(val string) string {
+	if !isCSVFormula(val) {
+		return val
+	}
+	return "'" + val
+}
+
+// isCSVFormula reports whether val starts with a formula trigger and is not
+// a plain number
+func isCSVFormula(val string) bool {
+	return val != "" && strings.IndexByte(csvFormulaTriggers, val[0]) >= 0 && !isCSVDecimal(val)
+}
+
+// applyCSVFormulaPolicy returns format configured for policy. Formats other
+// than CSV and Draw.io CSV, and every format under CSVFormulaAllow, are
+// returned unchanged.
+func applyCSVFormulaPolicy(format Format, policy CSVFormulaPolicy) Format {
+	if policy != CSVFormulaEscape {
+		return format
+	}
+	if format.Name == FormatDrawIO {
+		if _, ok := format.Renderer.(*drawioRenderer); ok {
+			format.Renderer = &drawioRenderer{formulaEscaping: true}
+		}
+		return format
+	}
+	if format.Name != FormatCSV {
+		return format
+	}
+	if renderer, ok := format.Renderer.(*csvRenderer); ok {
+		escaping := *renderer
+		escaping.dialect.formulaEscaping = true
+		format.Renderer = &escaping
+		return format
+	}
+	format.Renderer = &csvFormulaRenderer{Renderer: format.Renderer, transformer: NewCSVFormulaTransformer()}
+	return format
+}
+
+// csvFormulaRenderer escapes the output of a CSV renderer that is not a
+// csvRenderer
+type csvFormulaRenderer struct {
+	Renderer
+	transformer *CSVFormulaTransformer
+}
+
+// Render renders doc and escapes formula cells in the result
+func (r *csvFormulaRenderer) Render(ctx context.Context, doc *Document) ([]byte, error) {
+	data, err := r.Renderer.Render(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	return r.transformer.Transform(ctx, data, FormatCSV)
+}
+
+// RenderTo renders doc to a buffer, escapes formula cells and writes the
+// result to w
+func (r *csvFormulaRenderer) RenderTo(ctx context.Context, doc *Document, w io.Writer) error {
+	data, err := r.Render(ctx, doc)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// CSVFormulaTransformer escapes CSV cells that spreadsheet applications
+// would evaluate as formulas, following the CSVFormulaEscape rule. It works
+// on the rendered bytes and keeps everything else, including quoting, line
+// endings and a byte order mark, unchanged.
+type CSVFormulaTransformer struct {
+	delimiter string
+}
+
+// NewCSVFormulaTransformer creates a formula escaping transformer. Only the
+// WithCSVDelimiter option is used; the default delimiter is ','.
+func NewCSVFormulaTransformer(opts ...CSVOption) *CSVFormulaTransformer {
+	dialect := newCSVDialect(opts...)
+	return &CSVFormulaTransformer{delimiter: string(dialect.delimiterRune())}
+}
+
+// Name returns the transformer name
+func (t *CSVFormulaTransformer) Name() string {
+	return "csv-formula"
+}
+
+// Priority returns the transformer priority (lower = earlier)
+func (t *CSVFormulaTransformer) Priority() int {
+	return 900 // Run late, after transformers that rewrite CSV cells
+}
+
+// CanTransform checks if this transformer applies to the given format
+func (t *CSVFormulaTransformer) CanTransform(format string) bool {
+	return format == FormatCSV
+}
+
+// Transform escapes formula cells in the CSV input
+func (t *CSVFormulaTransformer) Transform(_ context.Context, input []byte, _ string) ([]byte, error) {
+	out := make([]byte, 0, len(input))
+	if bytes.HasPrefix(input, []byte(drawioUTF8BOM)) {
+		out = append(out, drawioUTF8BOM...)
+		input = input[len(drawioUTF8BOM):]
+	}
+
+	for i := 0; i < len(input); {
+		// i is at the start of a field
+		quoted := input[i] == '"'
+		if quoted {
+			end := quotedFieldEnd(input, i)
+			content := input[i+1 : end]
+			if end > i+1 && input[end-1] == '"' {
+				content = input[i+1 : end-1]
+			}
+			out = append(out, '"')
+			if isCSVFormula(strings.ReplaceAll(string(content), `""`, `"`)) {
+				out = append(out, '\'')
+			}
+			out = append(out, input[i+1:end]...)
+			i = end
+		}
+
+		// Copy the unquoted field, or anything after a closing quote, up to
+		// the next delimiter or line ending
+		start := i
+		for i < len(input) && input[i] != '\n' && input[i] != '\r' && !bytes.HasPrefix(input[i:], []byte(t.delimiter)) {
+			i++
+		}
+		if !quoted && isCSVFormula(string(input[start:i])) {
+			out = append(out, '\'')
+		}
+		out = append(out, input[start:i]...)
+
+		// Copy the delimiter or line ending
+		switch {
+		case i >= len(input):
+		case bytes.HasPrefix(input[i:], []byte(t.delimiter)):
+			out = append(out, t.delimiter...)
+			i += len(t.delimiter)
+		case input[i] == '\r' && i+1 < len(input) && input[i+1] == '\n':
+			out = append(out, "\r\n"...)
+			i += 2
+		default:
+			out = append(out, input[i])
+			i++
+		}
+	}
+	return out, nil
+}
+
+// quotedFieldEnd returns the index just past the closing quote of the quoted
+// field starting at start, or len(input) for an unterminated field
+func quotedFieldEnd(input []byte, start int) int {
+	for i := start + 1; i < len(input); i++ {
+		if input[i] != '"' {
+			continue
+		}
+		if i+1 < len(input) && input[i+1] == '"' {
+			i++ // escaped quote
+			continue
+		}
+		return i + 1
+	}
+	return len(input)
+}
@@ -0,0 +1,144 @@
+package output
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCSVFormulaEscaping_Renderer(t *testing.T) {
+	doc := New().Table("", []Record{
+		{"Name": "=HYPERLINK(\"http://x\")", "Tag": "+cmd", "Delta": -5, "Note": "-"},
+		{"Name": "@SUM(A1)", "Tag": "-1.5", "Delta": 2.5, "Note": "plain"},
+	}, WithKeys("Name", "Tag", "Delta", "Note", "=Total")).Build()
+
+	want := "Name,Tag,Delta,Note,'=Total\n" +
+		"\"'=HYPERLINK(\"\"http://x\"\")\",'+cmd,-5,'-,\n" +
+		"'@SUM(A1),-1.5,2.5,plain,\n"
+
+	out, err := CSVWithOptions(WithCSVFormulaEscaping()).Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if string(out) != want {
+		t.Errorf("Render() = %q, want %q", out, want)
+	}
+
+	// Without the option cells are unchanged
+	out, err = CSV().Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if strings.Contains(string(out), "'") {
+		t.Errorf("Render() = %q, want no escaping by default", out)
+	}
+}
+
+func TestCSVFormulaTransformer(t *testing.T) {
+	tests := map[string]struct {
+		opts  []CSVOption
+		input string
+		want  string
+	}{
+		"unquoted and quoted cells": {
+			input: "a,b,c\n=1+2,\"@x\",\"say \"\"hi\"\"\"\n",
+			want:  "a,b,c\n'=1+2,\"'@x\",\"say \"\"hi\"\"\"\n",
+		},
+		"numbers untouched": {
+			input: "-5,+1.5,-1e3,-\n",
+			want:  "-5,+1.5,-1e3,'-\n",
+		},
+		"quoted tab and CRLF": {
+			input: "\"\tcmd\",\"\r\nx\"\r\nok,=y\r\n",
+			want:  "\"'\tcmd\",\"'\r\nx\"\r\nok,'=y\r\n",
+		},
+		"delimiter and BOM": {
+			opts:  []CSVOption{WithCSVDelimiter(';')},
+			input: "\xef\xbb\xbfa,=b;=c\n",
+			want:  "\xef\xbb\xbfa,=b;'=c\n",
+		},
+		"quoted quote at start": {
+			input: "\"\"\"=x\"\"\",\"\"\n",
+			want:  "\"\"\"=x\"\"\",\"\"\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			transformer := NewCSVFormulaTransformer(tc.opts...)
+			if !transformer.CanTransform(FormatCSV) || transformer.CanTransform(FormatJSON) {
+				t.Fatal("CanTransform() should only accept CSV")
+			}
+			got, err := transformer.Transform(context.Background(), []byte(tc.input), FormatCSV)
+			if err != nil {
+				t.Fatalf("Transform() error = %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("Transform() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// stubCSVRenderer renders fixed CSV, standing in for a custom CSV renderer
+type stubCSVRenderer struct{ out string }
+
+func (s *stubCSVRenderer) Format() string { return FormatCSV }
+func (s *stubCSVRenderer) Render(context.Context, *Document) ([]byte, error) {
+	return []byte(s.out), nil
+}
+func (s *stubCSVRenderer) RenderTo(_ context.Context, _ *Document, w io.Writer) error {
+	_, err := w.Write([]byte(s.out))
+	return err
+}
+func (s *stubCSVRenderer) SupportsStreaming() bool { return false }
+
+func TestOutput_CSVFormulaPolicy(t *testing.T) {
+	tests := map[string]struct {
+		format Format
+		policy CSVFormulaPolicy
+		want   string
+	}{
+		"csv renderer escaped": {
+			format: CSVWithOptions(WithCSVDelimiter(';')),
+			policy: CSVFormulaEscape,
+			want:   "Cell\n'=cmd\n",
+		},
+		"allow leaves cells": {
+			format: CSV(),
+			policy: CSVFormulaAllow,
+			want:   "Cell\n=cmd\n",
+		},
+		"custom renderer escaped": {
+			format: Format{Name: FormatCSV, Renderer: &stubCSVRenderer{out: "Cell\n@cmd\n"}},
+			policy: CSVFormulaEscape,
+			want:   "Cell\n'@cmd\n",
+		},
+		"draw.io rows escaped": {
+			format: DrawIO(),
+			policy: CSVFormulaEscape,
+			want: "# label: %Name%\n# style: %Image%\n# namespace: csvimport-\n# height: auto\n# width: auto\n" +
+				"# ignore: Image\n# nodespacing: 40\n# levelspacing: 100\n# edgespacing: 40\n# layout: auto\nCell\n'=cmd\n",
+		},
+		"other formats untouched": {
+			format: Format{Name: FormatMarkdown, Renderer: &stubCSVRenderer{out: "=cmd\n"}},
+			policy: CSVFormulaEscape,
+			want:   "=cmd\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc := New().Table("", []map[string]any{{"Cell": "=cmd"}}, WithKeys("Cell")).Build()
+			writer := &mockWriter{}
+			out := NewOutput(WithFormat(tc.format), WithWriter(writer), WithCSVFormulaPolicy(tc.policy))
+			if err := out.Render(context.Background(), doc); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if writer.String() != tc.want {
+				t.Errorf("written = %q, want %q", writer.String(), tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package output
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors returned by ReadCSVTable and ReadCSVTables. They are
+// wrapped with line or column context, so callers can discriminate with
+// errors.Is while messages stay informative.
+var (
+	// ErrCSVNoTable indicates the input holds no table (empty input, or
+	// comments and blank lines only).
+	ErrCSVNoTable = errors.New("csv: no table found")
+	// ErrCSVMultipleTables indicates ReadCSVTable found more than one table;
+	// use ReadCSVTables to read them all.
+	ErrCSVMultipleTables = errors.New("csv: input contains more than one table")
+	// ErrCSVDuplicateColumn indicates a header row contains a duplicate
+	// column name.
+	ErrCSVDuplicateColumn = errors.New("csv: duplicate column name")
+	// ErrCSVValue indicates a value cannot be converted to the type set for
+	// its column with WithCSVColumnType.
+	ErrCSVValue = errors.New("csv: invalid value")
+)
+
+// CSVReadOption configures ReadCSVTable and ReadCSVTables. It is a CSVOption,
+// so a dialect written with CSVWithOptions can be read back with the same
+// options.
+type CSVReadOption = CSVOption
+
+// ReadCSVTable reads a single table from CSV input. The header row becomes
+// the schema key order, and each column's type is inferred from its values:
+// int, float, bool and time columns hold converted values, other columns
+// hold strings. Bool values are true or false in any letter case, and
+// numbers with leading zeros, such as IDs like "007", are inferred as
+// strings. Empty cells are nil in typed columns and "" in string
+// columns, and cells matching WithCSVNull are nil. A UTF-8 byte order mark
+// is stripped. Options that only affect writing are ignored.
+//
+// Input holding several tables separated by blank lines returns an error
+// wrapping ErrCSVMultipleTables; use ReadCSVTables for it.
+func ReadCSVTable(r io.Reader, opts ...CSVReadOption) (*TableContent, error) {
+	tables, err := ReadCSVTables(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch len(tables) {
+	case 0:
+		return nil, ErrCSVNoTable
+	case 1:
+		return tables[0], nil
+	default:
+		return nil, fmt.Errorf("%w: found %d tables", ErrCSVMultipleTables, len(tables))
+	}
+}
+
+// ReadCSVTables reads every table from CSV input in which tables are
+// separated by blank lines, as the CSV renderer writes them. The renderer
+// omits the header of a table with the same columns as the previous one, so
+// a later block whose first row has the previous table's width and fits its
+// column types is read as data under those columns. When the previous table
+// holds only string columns the first row cannot be told apart from a
+// header and is read as one. Types are inferred per table.
+func ReadCSVTables(r io.Reader, opts ...CSVReadOption) ([]*TableContent, error) {
+	cfg := newCSVDialect(opts...)
+	for column, fieldType := range cfg.columnTypes {
+		switch fieldType {
+		case fieldTypeString, fieldTypeInt, fieldTypeFloat, fieldTypeBool, fieldTypeTime:
+		default:
+			return nil, fmt.Errorf("csv: unsupported type %q for column %q", fieldType, column)
+		}
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("csv: reading input: %w", err)
+	}
+	lines := splitDrawIOLines(strings.TrimPrefix(string(data), drawioUTF8BOM))
+
+	var (
+		tables   []*TableContent
+		previous []Field
+	)
+	for _, block := range splitCSVBlocks(lines) {
+		rows, err := cfg.readBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			continue // comments only
+		}
+
+		var columns []string
+		switch {
+		case cfg.noHeader:
+			columns = cfg.columnNames(len(rows[0]))
+		case cfg.continuesTable(rows[0], previous):
+			for _, field := range previous {
+				columns = append(columns, field.Name)
+			}
+		default:
+			columns, rows = rows[0], rows[1:]
+			if err := checkCSVColumns(columns, block.start); err != nil {
+				return nil, err
+			}
+		}
+
+		table, err := cfg.buildTable(columns, rows)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+		previous = table.schema.Fields
+	}
+	return tables, nil
+}
+
+// continuesTable reports whether row is data for a table with the previous
+// table's fields rather than a header: it must have the same width and
+// every value must convert to the type of a typed previous column.
+func (c *csvDialect) continuesTable(row []string, previous []Field) bool {
+	if len(row) != len(previous) {
+		return false
+	}
+	typed := false
+	for j, field := range previous {
+		if field.Type == fieldTypeString {
+			continue
+		}
+		typed = true
+		if _, err := c.convert(row[j], field.Type); err != nil {
+			return false
+		}
+	}
+	return typed
+}
+
+// csvBlock is a run of non-blank lines and the 0-based line it starts on
+type csvBlock struct {
+	start int
+	lines []string
+}
+
+// splitCSVBlocks splits lines into blocks separated by blank lines. Blank
+// lines inside a quoted field do not separate blocks.
+func splitCSVBlocks(lines []string) []csvBlock {
+	var (
+		blocks  []csvBlock
+		current *csvBlock
+		inQuote bool
+	)
+	for i, line := range lines {
+		if line == "" && !inQuote {
+			current = nil
+			continue
+		}
+		if current == nil {
+			blocks = append(blocks, csvBlock{start: i})
+			current = &blocks[len(blocks)-1]
+		}
+		current.lines = append(current.lines, line)
+		if strings.Count(line, `"`)%2 == 1 {
+			inQuote = !inQuote
+		}
+	}
+	return blocks
+}
+
+// readBlock parses the rows of one block
+func (c *csvDialect) readBlock(block csvBlock) ([][]string, error) {
+	reader := csv.NewReader(strings.NewReader(strings.Join(block.lines, "\n")))
+	reader.Comma = c.delimiterRune()
+	reader.Comment = c.comment
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		// Report line numbers for the whole input rather than the block
+		var pe *csv.ParseError
+		if errors.As(err, &pe) {
+			pe.Line += block.start
+			pe.StartLine += block.start
+		}
+		return nil, fmt.Errorf("csv: %w", err)
+	}
+	return rows, nil
+}
+
+// columnNames returns the column names for header-less input of width n
+func (c *csvDialect) columnNames(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		if i < len(c.columns) {
+			names[i] = c.columns[i]
+		} else {
+			names[i] = "Column" + strconv.Itoa(i+1)
+		}
+	}
+	return names
+}
+
+// checkCSVColumns rejects duplicate names in a header row
+func checkCSVColumns(columns []string, start int) error {
+	for i, column := range columns {
+		if slices.Contains(columns[:i], column) {
+			return fmt.Errorf("%w: line %d: %q", ErrCSVDuplicateColumn, start+1, column)
+		}
+	}
+	return nil
+}
+
+// buildTable infers or applies column types and converts the rows
+func (c *csvDialect) buildTable(columns []string, rows [][]string) (*TableContent, error) {
+	fields := make([]Field, len(columns))
+	for j, column := range columns {
+		fieldType, ok := c.columnTypes[column]
+		if !ok {
+			fieldType = c.inferType(rows, j)
+		}
+		fields[j] = Field{Name: column, Type: fieldType}
+	}
+
+	records := make([]Record, len(rows))
+	for i, row := range rows {
+		record := make(Record, len(columns))
+		for j, field := range fields {
+			val, err := c.convert(row[j], field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%w: row %d, column %q: %q is not a valid %s", ErrCSVValue, i+1, field.Name, row[j], field.Type)
+			}
+			record[field.Name] = val
+		}
+		records[i] = record
+	}
+
+	return &TableContent{
+		id:      GenerateID(),
+		schema:  &Schema{Fields: fields, keyOrder: slices.Clone(columns)},
+		records: records,
+	}, nil
+}
+
+// inferType returns the narrowest type that every non-empty value in column
+// j converts to, or string.
+func (c *csvDialect) inferType(rows [][]string, j int) string {
+	isInt, isFloat, isBool, isTime := true, true, true, true
+	seen := false
+	for _, row := range rows {
+		val := row[j]
+		if c.isNull(val) {
+			continue
+		}
+		seen = true
+		if hasCSVLeadingZero(val) {
+			isInt, isFloat = false, false
+		}
+		if isInt {
+			_, err := strconv.Atoi(val)
+			isInt = err == nil
+		}
+		isFloat = isFloat && isCSVDecimal(val)
+		if isBool {
+			_, isBool = parseCSVBool(val)
+		}
+		if isTime {
+			_, isTime = c.parseTime(val)
+		}
+		if !isInt && !isFloat && !isBool && !isTime {
+			return fieldTypeString
+		}
+	}
+
+	switch {
+	case !seen:
+		return fieldTypeString
+	case isInt:
+		return fieldTypeInt
+	case isFloat:
+		return fieldTypeFloat
+	case isBool:
+		return fieldTypeBool
+	case isTime:
+		return fieldTypeTime
+	default:
+		return fieldTypeString
+	}
+}
+
+// isCSVDecimal reports whether val is a plain decimal number. It excludes
+// forms strconv.ParseFloat also accepts, such as "NaN", "Inf" and hex.
+func isCSVDecimal(val string) bool {
+	if strings.Trim(val, "0123456789+-.eE") != "" || strings.Trim(val, "+-.eE") == "" {
+		return false
+	}
+	_, err := strconv.ParseFloat(val, 64)
+	return err == nil
+}
+
+// hasCSVLeadingZero reports whether val is a number written with a leading
+// zero, such as "007", which inference keeps as text so the zero survives
+func hasCSVLeadingZero(val string) bool {
+	digits := strings.TrimLeft(val, "+-")
+	return len(digits) > 1 && digits[0] == '0' && digits[1] >= '0' && digits[1] <= '9'
+}
+
+// parseCSVBool parses "true" or "false" in any letter case
+func parseCSVBool(val string) (bool, bool) {
+	switch {
+	case strings.EqualFold(val, "true"):
+		return true, true
+	case strings.EqualFold(val, "false"):
+		return false, true
+	}
+	return false, false
+}
+
+// isNull reports whether val is an empty cell or the configured null value
+func (c *csvDialect) isNull(val string) bool {
+	return val == "" || (c.null != "" && val == c.null)
+}
+
+// convert converts val to fieldType. Empty values are nil unless the column
+// holds strings; the configured null value is always nil.
+func (c *csvDialect) convert(val, fieldType string) (any, error) {
+	if c.null != "" && val == c.null {
+		return nil, nil
+	}
+	if fieldType == fieldTypeString {
+		return val, nil
+	}
+	if val == "" {
+		return nil, nil
+	}
+
+	switch fieldType {
+	case fieldTypeInt:
+		return strconv.Atoi(val)
+	case fieldTypeFloat:
+		return strconv.ParseFloat(val, 64)
+	case fieldTypeBool:
+		if b, ok := parseCSVBool(val); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("not true or false")
+	case fieldTypeTime:
+		if t, ok := c.parseTime(val); ok {
+			return t, nil
+		}
+		return nil, fmt.Errorf("no matching time layout")
+	}
+	return val, nil
+}
+
+// parseTime tries each configured layout in turn
+func (c *csvDialect) parseTime(val string) (time.Time, bool) {
+	for _, layout := range c.timeLayouts {
+		if t, err := time.Parse(layout, val); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,218 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadCSVTable_InfersTypes(t *testing.T) {
+	input := "\xef\xbb\xbfName,Count,Cost,Active,Launched,Zip\n" +
+		"web,3,1.5,true,2024-03-01,0800\n" +
+		"db,,2,FaLsE,2024-04-01T10:00:00Z,1000\n"
+
+	table, err := ReadCSVTable(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCSVTable() error = %v", err)
+	}
+
+	if got := table.Schema().GetKeyOrder(); !slices.Equal(got, []string{"Name", "Count", "Cost", "Active", "Launched", "Zip"}) {
+		t.Errorf("key order = %v", got)
+	}
+	wantTypes := map[string]string{
+		"Name":     fieldTypeString,
+		"Count":    fieldTypeInt,
+		"Cost":     fieldTypeFloat,
+		"Active":   fieldTypeBool,
+		"Launched": fieldTypeTime,
+		"Zip":      fieldTypeString,
+	}
+	for name, want := range wantTypes {
+		if got := table.Schema().FindField(name).Type; got != want {
+			t.Errorf("field %s type = %q, want %q", name, got, want)
+		}
+	}
+
+	records := table.Records()
+	if records[0]["Count"] != 3 || records[1]["Count"] != nil {
+		t.Errorf("Count = %v, %v; want 3, nil", records[0]["Count"], records[1]["Count"])
+	}
+	if records[1]["Cost"] != 2.0 || records[1]["Active"] != false {
+		t.Errorf("record = %v, want float Cost and bool Active", records[1])
+	}
+	if records[0]["Zip"] != "0800" {
+		t.Errorf("Zip = %#v, want leading zero kept", records[0]["Zip"])
+	}
+	if launched, ok := records[0]["Launched"].(time.Time); !ok || !launched.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Launched = %#v, want 2024-03-01", records[0]["Launched"])
+	}
+}
+
+func TestReadCSVTable_Options(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		opts     []CSVReadOption
+		wantKeys []string
+		wantRow  Record
+	}{
+		"tsv": {
+			input:    "Name\tCount\nweb\t3\n",
+			opts:     []CSVReadOption{WithCSVDelimiter('\t')},
+			wantKeys: []string{"Name", "Count"},
+			wantRow:  Record{"Name": "web", "Count": 3},
+		},
+		"comments": {
+			input:    "# exported report\nName,Count\n# skipped\nweb,3\n",
+			opts:     []CSVReadOption{WithCSVComment('#')},
+			wantKeys: []string{"Name", "Count"},
+			wantRow:  Record{"Name": "web", "Count": 3},
+		},
+		"no header with names": {
+			input:    "web,3,x\n",
+			opts:     []CSVReadOption{WithCSVNoHeader("Name")},
+			wantKeys: []string{"Name", "Column2", "Column3"},
+			wantRow:  Record{"Name": "web", "Column2": 3, "Column3": "x"},
+		},
+		"type override": {
+			input:    "Zip,Count\n0800,3\n",
+			opts:     []CSVReadOption{WithCSVColumnType("Zip", "string"), WithCSVColumnType("Count", "float")},
+			wantKeys: []string{"Zip", "Count"},
+			wantRow:  Record{"Zip": "0800", "Count": 3.0},
+		},
+		"time layouts": {
+			input:    "When\n01/02/2024\n",
+			opts:     []CSVReadOption{WithCSVTimeLayouts("01/02/2006")},
+			wantKeys: []string{"When"},
+			wantRow:  Record{"When": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		"leading zero override": {
+			input:    "ID,Ratio\n007,0.5\n",
+			opts:     []CSVReadOption{WithCSVColumnType("ID", "int")},
+			wantKeys: []string{"ID", "Ratio"},
+			wantRow:  Record{"ID": 7, "Ratio": 0.5},
+		},
+		"mixed case bool": {
+			input:    "x\ntRuE\n",
+			wantKeys: []string{"x"},
+			wantRow:  Record{"x": true},
+		},
+		"nil option ignored": {
+			input:    "Name\nweb\n",
+			opts:     []CSVReadOption{nil},
+			wantKeys: []string{"Name"},
+			wantRow:  Record{"Name": "web"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			table, err := ReadCSVTable(strings.NewReader(tc.input), tc.opts...)
+			if err != nil {
+				t.Fatalf("ReadCSVTable() error = %v", err)
+			}
+			if got := table.Schema().GetKeyOrder(); !slices.Equal(got, tc.wantKeys) {
+				t.Errorf("key order = %v, want %v", got, tc.wantKeys)
+			}
+			got := table.Records()[0]
+			for key, want := range tc.wantRow {
+				if got[key] != want {
+					t.Errorf("record[%q] = %#v, want %#v", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadCSVTables_RendererOutput(t *testing.T) {
+	first, _ := NewTableContent("", []Record{{"Name": "web", "Count": 3}}, WithKeys("Name", "Count"))
+	second, _ := NewTableContent("", []Record{{"Name": "db", "Count": 1}}, WithKeys("Name", "Count"))
+	third, _ := NewTableContent("", []Record{{"Region": "eu", "Cost": 1.5}}, WithKeys("Region", "Cost"))
+
+	out, err := CSV().Renderer.Render(context.Background(), New().AddContent(first).AddContent(second).AddContent(third).Build())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	tables, err := ReadCSVTables(strings.NewReader(string(out)))
+	if err != nil {
+		t.Fatalf("ReadCSVTables() error = %v", err)
+	}
+	if len(tables) != 3 {
+		t.Fatalf("read %d tables, want 3\n%s", len(tables), out)
+	}
+	// The second table has no header of its own and continues the columns
+	if got := tables[1].Schema().GetKeyOrder(); !slices.Equal(got, []string{"Name", "Count"}) {
+		t.Errorf("second table keys = %v", got)
+	}
+	if got := tables[1].Records()[0]; got["Name"] != "db" || got["Count"] != 1 {
+		t.Errorf("second table record = %v", got)
+	}
+	if got := tables[2].Records()[0]; got["Region"] != "eu" || got["Cost"] != 1.5 {
+		t.Errorf("third table record = %v", got)
+	}
+
+	if _, err := ReadCSVTable(strings.NewReader(string(out))); !errors.Is(err, ErrCSVMultipleTables) {
+		t.Errorf("ReadCSVTable() error = %v, want ErrCSVMultipleTables", err)
+	}
+}
+
+func TestReadCSVTable_QuotedBlankLine(t *testing.T) {
+	table, err := ReadCSVTable(strings.NewReader("Name,Note\nweb,\"line one\n\nline three\"\n"))
+	if err != nil {
+		t.Fatalf("ReadCSVTable() error = %v", err)
+	}
+	if got := table.Records()[0]["Note"]; got != "line one\n\nline three" {
+		t.Errorf("Note = %q", got)
+	}
+}
+
+func TestReadCSVTable_Errors(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		opts    []CSVReadOption
+		wantErr error
+		wantMsg string
+	}{
+		"empty input": {
+			input:   "\n\n",
+			wantErr: ErrCSVNoTable,
+		},
+		"duplicate column": {
+			input:   "Name,Name\na,b\n",
+			wantErr: ErrCSVDuplicateColumn,
+		},
+		"invalid override value": {
+			input:   "Count\nmany\n",
+			opts:    []CSVReadOption{WithCSVColumnType("Count", "int")},
+			wantErr: ErrCSVValue,
+			wantMsg: `row 1, column "Count"`,
+		},
+		"unsupported override type": {
+			input:   "Count\n1\n",
+			opts:    []CSVReadOption{WithCSVColumnType("Count", "decimal")},
+			wantMsg: `unsupported type "decimal"`,
+		},
+		"field count mismatch reports input line": {
+			input:   "A,B\n1,2\n\nC\n3\n4,5\n",
+			wantMsg: "line 6",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ReadCSVTable(strings.NewReader(tc.input), tc.opts...)
+			if err == nil {
+				t.Fatal("ReadCSVTable() error = nil, want error")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("error = %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantMsg != "" && !strings.Contains(err.Error(), tc.wantMsg) {
+				t.Errorf("error = %v, want message containing %q", err, tc.wantMsg)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package output
 import (
 	"bytes"
 	"context"
-	"encoding/csv"
 	"fmt"
 	"io"
 	"strconv"
@@ -15,6 +14,7 @@ import (
 type csvRenderer struct {
 	// No base renderer needed for CSV
 	collapsibleConfig RendererConfig
+	dialect           csvDialect
 }
 
 func (c *csvRenderer) Format() string {
@@ -57,15 +57,12 @@ func (c *csvRenderer) renderDocumentCSVTo(ctx context.Context, doc *Document, w
 	}
 
 	contents := doc.GetContents()
-	csvWriter := csv.NewWriter(w)
+	csvWriter := newCSVRowWriter(w, &c.dialect)
 
 	// flushCSV flushes any buffered rows and reports a write error surfaced by
-	// the underlying io.Writer. csv.Writer.Write buffers data, so an underlying
-	// failure may only be reported here via csvWriter.Error() (T-1186).
-	flushCSV := func() error {
-		csvWriter.Flush()
-		return csvWriter.Error()
-	}
+	// the underlying io.Writer. Rows are buffered, so an underlying failure
+	// may only be reported here (T-1186).
+	flushCSV := csvWriter.Flush
 
 	// Track the last written header schema to detect schema changes
 	var lastKeyOrder []string
@@ -99,7 +96,7 @@ func (c *csvRenderer) renderDocumentCSVTo(ctx context.Context, doc *Document, w
 		case *TableContent:
 			// Add a blank line between tables (except for the first table)
 			if i > 0 && lastKeyOrder != nil {
-				if err := csvWriter.Write([]string{}); err != nil {
+				if err := csvWriter.WriteSeparator(); err != nil {
 					return fmt.Errorf("failed to write separator row: %w", err)
 				}
 			}
@@ -115,6 +112,25 @@ func (c *csvRenderer) renderDocumentCSVTo(ctx context.Context, doc *Document, w
 
 			lastKeyOrder = content.getSchema().GetKeyOrder()
 
+		case *StreamingTableContent:
+			if i > 0 && lastKeyOrder != nil {
+				if err := csvWriter.WriteSeparator(); err != nil {
+					return fmt.Errorf("failed to write separator row: %w", err)
+				}
+			}
+
+			writeHeaders := !keyOrdersEqual(lastKeyOrder, content.getSchema().GetKeyOrder())
+			if err := c.renderStreamingTableCSV(ctx, content, csvWriter, writeHeaders); err != nil {
+				// Flush the rows written so far; prefer a write error so a
+				// failed destination is not masked.
+				if flushErr := flushCSV(); flushErr != nil {
+					return flushErr
+				}
+				return fmt.Errorf("failed to render table %s: %w", content.ID(), err)
+			}
+
+			lastKeyOrder = content.getSchema().GetKeyOrder()
+
 		case *SectionContent:
 			// Extract and render tables from sections. CSV is a flat format,
 			// so we recursively flatten the section hierarchy to any depth
@@ -141,7 +157,7 @@ func (c *csvRenderer) renderDocumentCSVTo(ctx context.Context, doc *Document, w
 				contentText, err := content.AppendText(nil)
 				if err == nil && len(contentText) > 0 {
 					// Write simple header and content as CSV
-					if err := csvWriter.Write([]string{keyContent}); err != nil {
+					if err := csvWriter.WriteHeader([]string{keyContent}); err != nil {
 						return fmt.Errorf("failed to write content header: %w", err)
 					}
 					if err := csvWriter.Write([]string{c.formatValueForCSV(string(contentText))}); err != nil {
@@ -169,7 +185,7 @@ func (c *csvRenderer) renderDocumentCSVTo(ctx context.Context, doc *Document, w
 // re-writes behave consistently with top-level tables. flushCSV is the
 // document-level flush used to surface deferred writer errors before returning
 // a transformation error (T-1186).
-func (c *csvRenderer) renderSectionTablesCSV(ctx context.Context, section *SectionContent, csvWriter *csv.Writer, lastKeyOrder *[]string, flushCSV func() error) error {
+func (c *csvRenderer) renderSectionTablesCSV(ctx context.Context, section *SectionContent, csvWriter *csvRowWriter, lastKeyOrder *[]string, flushCSV func() error) error {
 	for _, nestedContent := range section.Contents() {
 		// Apply transformations to nested content at this level.
 		transformed, err := applyContentTransformations(ctx, nestedContent)
@@ -186,7 +202,7 @@ func (c *csvRenderer) renderSectionTablesCSV(ctx context.Context, section *Secti
 		case *TableContent:
 			// Add separator between tables (matches top-level behaviour).
 			if *lastKeyOrder != nil {
-				if err := csvWriter.Write([]string{}); err != nil {
+				if err := csvWriter.WriteSeparator(); err != nil {
 					return fmt.Errorf("failed to write separator row: %w", err)
 				}
 			}
@@ -197,6 +213,19 @@ func (c *csvRenderer) renderSectionTablesCSV(ctx context.Context, section *Secti
 			}
 			*lastKeyOrder = nested.getSchema().GetKeyOrder()
 
+		case *StreamingTableContent:
+			if *lastKeyOrder != nil {
+				if err := csvWriter.WriteSeparator(); err != nil {
+					return fmt.Errorf("failed to write separator row: %w", err)
+				}
+			}
+
+			writeHeaders := !keyOrdersEqual(*lastKeyOrder, nested.getSchema().GetKeyOrder())
+			if err := c.renderStreamingTableCSV(ctx, nested, csvWriter, writeHeaders); err != nil {
+				return fmt.Errorf("failed to render table %s: %w", nested.ID(), err)
+			}
+			*lastKeyOrder = nested.getSchema().GetKeyOrder()
+
 		case *SectionContent:
 			// Recurse into deeper sections to any depth.
 			if err := c.renderSectionTablesCSV(ctx, nested, csvWriter, lastKeyOrder, flushCSV); err != nil {
@@ -209,7 +238,7 @@ func (c *csvRenderer) renderSectionTablesCSV(ctx context.Context, section *Secti
 }
 
 // renderTableContentCSV renders table content to CSV with key order preservation
-func (c *csvRenderer) renderTableContentCSV(table *TableContent, csvWriter *csv.Writer, writeHeaders bool) error {
+func (c *csvRenderer) renderTableContentCSV(table *TableContent, csvWriter *csvRowWriter, writeHeaders bool) error {
 	// Handle collapsible fields by creating extended schema and records (Requirement 8.1)
 	enhancedTable, err := c.handleCollapsibleFields(table)
 	if err != nil {
@@ -221,23 +250,72 @@ func (c *csvRenderer) renderTableContentCSV(table *TableContent, csvWriter *csv.
 		return nil // No columns to write
 	}
 
-	// Write headers if requested
+	// Write headers if requested; grouped columns get "Group / Column"
 	if writeHeaders {
-		if err := csvWriter.Write(keyOrder); err != nil {
+		if err := csvWriter.WriteHeader(enhancedTable.getSchema().flatColumnHeaders(keyOrder)); err != nil {
 			return fmt.Errorf("failed to write CSV headers: %w", err)
 		}
 	}
 
 	// Write data rows in key order
+	schema := enhancedTable.getSchema()
 	for _, record := range enhancedTable.Records() {
 		row := make([]string, len(keyOrder))
 		for i, key := range keyOrder {
-			if val, exists := record[key]; exists {
+			// Missing values are written like nil
+			row[i] = c.formatValueForCSV(csvFieldValue(record[key], schema.FindField(key)))
+		}
+
+		if err := csvWriter.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	// Write the footer as a trailing row; "_details" columns stay empty
+	if footer := table.Footer(); footer != nil && c.dialect.footer {
+		row := make([]string, len(keyOrder))
+		for i, key := range keyOrder {
+			if val, exists := footer[key]; exists {
 				row[i] = c.formatValueForCSV(val)
 			}
-			// Empty string for missing values (row[i] is already "")
 		}
+		if err := csvWriter.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV footer: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renderStreamingTableCSV writes a streaming table row by row as records are
+// pulled from its sequence. csv.Writer buffers internally and writes through
+// to the destination as its buffer fills, so memory stays bounded. Values are
+// written raw, as for TableContent; "_details" columns for collapsible
+// formatters need the full record set to detect and are not added.
+func (c *csvRenderer) renderStreamingTableCSV(ctx context.Context, table *StreamingTableContent, csvWriter *csvRowWriter, writeHeaders bool) error {
+	keyOrder := table.getSchema().GetKeyOrder()
+	if len(keyOrder) == 0 {
+		return nil // No columns to write
+	}
+
+	if writeHeaders {
+		if err := csvWriter.WriteHeader(table.getSchema().flatColumnHeaders(keyOrder)); err != nil {
+			return fmt.Errorf("failed to write CSV headers: %w", err)
+		}
+	}
 
+	row := make([]string, len(keyOrder))
+	for record, err := range table.All() {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		for i, key := range keyOrder {
+			row[i] = c.formatValueForCSV(csvFieldValue(record[key], table.getSchema().FindField(key)))
+		}
 		if err := csvWriter.Write(row); err != nil {
 			return fmt.Errorf("failed to write CSV row: %w", err)
 		}
@@ -246,12 +324,34 @@ func (c *csvRenderer) renderTableContentCSV(table *TableContent, csvWriter *csv.
 	return nil
 }
 
-// formatValueForCSV converts any value to its CSV string representation
+// csvFieldValue returns the value written for a cell. Field formatters are
+// not applied in CSV output, except that a formatter returning a
+// FormattedValue contributes its text when the value is displayed in CSV.
+func csvFieldValue(val any, field *Field) any {
+	if val == nil || field == nil || field.Formatter == nil {
+		return val
+	}
+	if fv, ok := field.Formatter(val).(FormattedValue); ok {
+		return fv.ValueFor(FormatCSV)
+	}
+	return val
+}
+
+// formatValueForCSV converts any value to its CSV string representation,
+// escaping formulas when the dialect asks for it
 func (c *csvRenderer) formatValueForCSV(val any) string {
 	if val == nil {
-		return ""
+		return c.dialect.null
+	}
+	str := csvValueString(val)
+	if c.dialect.formulaEscaping {
+		return escapeCSVFormula(str)
 	}
+	return str
+}
 
+// csvValueString converts a non-nil value to its CSV string representation
+func csvValueString(val any) string {
 	switch v := val.(type) {
 	case string:
 		// Handle newlines and tabs in strings
@@ -333,6 +433,7 @@ func (c *csvRenderer) handleCollapsibleFields(table *TableContent) (*TableConten
 	// Create new schema with enhanced fields
 	newSchema := &Schema{
 		Fields:   newFields,
+		Groups:   table.getSchema().Groups,
 		keyOrder: newKeyOrder,
 	}
 
@@ -355,7 +456,7 @@ func (c *csvRenderer) handleCollapsibleFields(table *TableContent) (*TableConten
 					newRecord[key] = cv.Summary()                              // Summary in original column
 					newRecord[key+"_details"] = c.flattenDetails(cv.Details()) // Details in new column
 				} else {
-					newRecord[key] = processed
+					newRecord[key] = formattedValueFor(processed, FormatCSV)
 					// Leave detail column empty for non-collapsible (Requirement 8.3)
 					if collapsibleFields[key] {
 						newRecord[key+"_details"] = ""
@@ -461,7 +562,7 @@ func (c *csvRenderer) flattenDetails(details any) string {
 // mirroring the normal CSV path and renderSectionTablesCSV (T-1315). Tracking a
 // single boolean previously suppressed headers for every table after the first,
 // producing malformed CSV when a section held tables with differing schemas.
-func (c *csvRenderer) renderCollapsibleSectionCSV(section *DefaultCollapsibleSection, csvWriter *csv.Writer, lastKeyOrder *[]string) error {
+func (c *csvRenderer) renderCollapsibleSectionCSV(section *DefaultCollapsibleSection, csvWriter *csvRowWriter, lastKeyOrder *[]string) error {
 	// Add section metadata as CSV comments or special rows (Requirement 15.8)
 
 	// Since CSV doesn't support comments officially, we'll use a special metadata row format
@@ -491,7 +592,7 @@ func (c *csvRenderer) renderCollapsibleSectionCSV(section *DefaultCollapsibleSec
 			// Add a blank separator row between tables (matches top-level
 			// behaviour) when a previous table has already been written.
 			if *lastKeyOrder != nil {
-				if err := csvWriter.Write([]string{}); err != nil {
+				if err := csvWriter.WriteSeparator(); err != nil {
 					return fmt.Errorf("failed to write separator row: %w", err)
 				}
 			}
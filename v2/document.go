@@ -2,8 +2,10 @@ package output
 
 import (
 	"fmt"
+	"iter"
 	"maps"
 	"sync"
+	"time"
 )
 
 // Document represents a collection of content to be output
@@ -250,6 +252,20 @@ func (b *Builder) Table(title string, data any, opts ...TableOption) *Builder {
 	return b.AddContent(table)
 }
 
+// StreamingTable adds a table whose records are pulled from rows at render
+// time. See NewStreamingTableContent for the schema requirement; a
+// construction error is recorded on the builder like Table.
+func (b *Builder) StreamingTable(title string, rows iter.Seq[Record], opts ...TableOption) *Builder {
+	table, err := NewStreamingTableContent(title, rows, opts...)
+	if err != nil {
+		b.mu.Lock()
+		b.addError(fmt.Errorf("failed to create streaming table %q: %w", title, err))
+		b.mu.Unlock()
+		return b
+	}
+	return b.AddContent(table)
+}
+
 // Text adds text content with optional styling
 func (b *Builder) Text(text string, opts ...TextOption) *Builder {
 	textContent := NewTextContent(text, opts...)
@@ -350,6 +366,36 @@ func (b *Builder) PieChart(title string, slices []PieSlice, showData bool) *Buil
 	return b.AddContent(chartContent)
 }
 
+// BarChart adds a bar chart with one bar per series in each category
+func (b *Builder) BarChart(title string, categories []string, series []ChartSeries) *Builder {
+	chartContent := NewBarChart(title, categories, series)
+	return b.AddContent(chartContent)
+}
+
+// StackedBarChart adds a bar chart with the series of each category stacked
+func (b *Builder) StackedBarChart(title string, categories []string, series []ChartSeries) *Builder {
+	chartContent := NewStackedBarChart(title, categories, series)
+	return b.AddContent(chartContent)
+}
+
+// LineChart adds a line chart with one point per category in each series
+func (b *Builder) LineChart(title string, categories []string, series []ChartSeries) *Builder {
+	chartContent := NewLineChart(title, categories, series)
+	return b.AddContent(chartContent)
+}
+
+// TimeSeriesChart adds a line chart over time
+func (b *Builder) TimeSeriesChart(title string, times []time.Time, series []ChartSeries) *Builder {
+	chartContent := NewTimeSeriesChart(title, times, series)
+	return b.AddContent(chartContent)
+}
+
+// ScatterChart adds an XY scatter chart
+func (b *Builder) ScatterChart(title string, series []ScatterSeries) *Builder {
+	chartContent := NewScatterChart(title, series)
+	return b.AddContent(chartContent)
+}
+
 // DrawIO adds Draw.io diagram content with CSV configuration
 func (b *Builder) DrawIO(title string, records []Record, header DrawIOHeader, opts ...DrawIOOption) *Builder {
 	drawioContent := NewDrawIOContent(title, records, header, opts...)
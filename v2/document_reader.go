@@ -0,0 +1,556 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidDocument is returned by ParseJSONDocument and ParseYAMLDocument
+// when the input does not follow the structure written by the JSON and YAML
+// renderers. It is wrapped with the path of the offending content, such as
+// "content[1].contents[0]", so callers can discriminate with errors.Is.
+var ErrInvalidDocument = errors.New("invalid document")
+
+// ParseJSONDocument reads a document written by the JSON renderer and rebuilds
+// its content, so that output from one tool can be re-rendered in another
+// format by a second tool.
+//
+// Tables keep their title, key order and field definitions (formatters are
+// not serialized and cannot be restored). Text keeps its TextStyle, and raw
+// content, sections, collapsible sections, collapsible cell values, graphs,
+// charts and draw.io content are rebuilt with their settings. Numbers become
+// int when whole and float64 otherwise, unless the field type says
+// otherwise; "time" fields holding a timestamp string become time.Time.
+func ParseJSONDocument(r io.Reader) (*Document, error) {
+	if r == nil {
+		return nil, fmt.Errorf("reader cannot be nil")
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var data any
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON document: %w", err)
+	}
+
+	parser := documentParser{marshal: json.Marshal, unmarshal: json.Unmarshal}
+	return parser.parse(normalizeJSONNumbers(data))
+}
+
+// ParseYAMLDocument reads a document written by the YAML renderer and
+// rebuilds its content. It restores the same content as ParseJSONDocument.
+func ParseYAMLDocument(r io.Reader) (*Document, error) {
+	if r == nil {
+		return nil, fmt.Errorf("reader cannot be nil")
+	}
+
+	var data any
+	if err := yaml.NewDecoder(r).Decode(&data); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to decode YAML document: %w", err)
+	}
+
+	parser := documentParser{marshal: yaml.Marshal, unmarshal: yaml.Unmarshal}
+	return parser.parse(data)
+}
+
+// normalizeJSONNumbers replaces json.Number values with int where the number
+// is whole and fits, and float64 otherwise.
+func normalizeJSONNumbers(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil && i >= math.MinInt && i <= math.MaxInt {
+			return int(i)
+		}
+		f, _ := val.Float64()
+		return f
+	case map[string]any:
+		for key, item := range val {
+			val[key] = normalizeJSONNumbers(item)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = normalizeJSONNumbers(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// documentParser rebuilds content from decoded JSON or YAML. Typed payloads
+// such as chart data are re-encoded with the source codec and decoded into
+// their Go types, so each format's field naming is honoured.
+type documentParser struct {
+	marshal   func(any) ([]byte, error)
+	unmarshal func([]byte, any) error
+}
+
+// parse builds a document from the top-level value: null for an empty
+// document, a single content object, or an array of content.
+func (p documentParser) parse(data any) (*Document, error) {
+	var items []any
+	switch d := data.(type) {
+	case nil:
+	case []any:
+		items = d
+	default:
+		items = []any{d}
+	}
+
+	builder := New()
+	for i, item := range items {
+		content, err := p.parseContent(item, fmt.Sprintf("content[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		builder.AddContent(content)
+	}
+	return builder.Build(), nil
+}
+
+// invalid returns an ErrInvalidDocument error for the content at path
+func (p documentParser) invalid(path, format string, args ...any) error {
+	return fmt.Errorf("%w: %s: %s", ErrInvalidDocument, path, fmt.Sprintf(format, args...))
+}
+
+// parseContent identifies the content type of item and rebuilds it. Tables,
+// charts, graphs and draw.io content are recognised by their members, since
+// the renderers do not write a type name for them.
+func (p documentParser) parseContent(item any, path string) (Content, error) {
+	obj, ok := item.(map[string]any)
+	if !ok {
+		// Content without a structured representation is written as a string
+		if text, isText := item.(string); isText {
+			return NewTextContent(text), nil
+		}
+		return nil, p.invalid(path, "expected an object, found %T", item)
+	}
+
+	typeName, _ := obj[keyType].(string)
+	switch {
+	case typeName == FormatText:
+		return p.parseText(obj), nil
+	case typeName == contentTypeNameRaw:
+		return p.parseRaw(obj, path)
+	case typeName == contentTypeNameSection:
+		return p.parseSection(obj, path)
+	case typeName == contentTypeNameCollapsibleSection:
+		return p.parseCollapsibleSection(obj, path)
+	case hasMember(obj, keySchema):
+		return p.parseTable(obj, path)
+	case hasMember(obj, keyChartType):
+		return p.parseChart(obj, path)
+	case hasMember(obj, keyEdges):
+		return p.parseGraph(obj, path)
+	case hasMember(obj, keyRecords) && hasMember(obj, keyHeader):
+		return p.parseDrawIO(obj, path)
+	default:
+		return nil, p.invalid(path, "unrecognised content")
+	}
+}
+
+// parseText rebuilds text content and its style
+func (p documentParser) parseText(obj map[string]any) Content {
+	var style TextStyle
+	if styleObj, ok := obj[keyStyle].(map[string]any); ok {
+		style = TextStyle{
+			Bold:   boolMember(styleObj, keyBold),
+			Italic: boolMember(styleObj, keyItalic),
+			Color:  stringMember(styleObj, keyColor),
+			Size:   intMember(styleObj, keySize),
+			Header: boolMember(styleObj, keyHeader),
+		}
+	}
+	return NewTextContent(stringMember(obj, keyContent), WithTextStyle(style))
+}
+
+// parseRaw rebuilds raw content
+func (p documentParser) parseRaw(obj map[string]any, path string) (Content, error) {
+	raw, err := NewRawContent(stringMember(obj, keyFormat), []byte(stringMember(obj, keyData)))
+	if err != nil {
+		return nil, p.invalid(path, "%v", err)
+	}
+	return raw, nil
+}
+
+// parseSection rebuilds a section and its nested content
+func (p documentParser) parseSection(obj map[string]any, path string) (Content, error) {
+	contents, err := p.parseContents(obj, keyContents, path)
+	if err != nil {
+		return nil, err
+	}
+	section := NewSectionContent(stringMember(obj, keyTitle), WithLevel(intMember(obj, keyLevel)))
+	for _, content := range contents {
+		section.AddContent(content)
+	}
+	return section, nil
+}
+
+// parseCollapsibleSection rebuilds a collapsible section and its nested content
+func (p documentParser) parseCollapsibleSection(obj map[string]any, path string) (Content, error) {
+	contents, err := p.parseContents(obj, keyContent, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewCollapsibleSection(stringMember(obj, keyTitle), contents,
+		WithSectionLevel(intMember(obj, keyLevel)),
+		WithSectionExpanded(boolMember(obj, keyExpanded)),
+	), nil
+}
+
+// parseContents rebuilds the nested content array stored under key
+func (p documentParser) parseContents(obj map[string]any, key, path string) ([]Content, error) {
+	items, ok := obj[key].([]any)
+	if !ok && obj[key] != nil {
+		return nil, p.invalid(path, "%s must be an array", key)
+	}
+
+	contents := make([]Content, 0, len(items))
+	for i, item := range items {
+		content, err := p.parseContent(item, fmt.Sprintf("%s.%s[%d]", path, key, i))
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, content)
+	}
+	return contents, nil
+}
+
+// parseTable rebuilds a table with its schema. The records only hold the
+// visible columns, since hidden fields are not rendered. A footer is restored
+// as aggregates that return the rendered values.
+func (p documentParser) parseTable(obj map[string]any, path string) (Content, error) {
+	schemaObj, ok := obj[keySchema].(map[string]any)
+	if !ok {
+		return nil, p.invalid(path, "schema must be an object")
+	}
+
+	keys, err := p.stringList(schemaObj[keyKeys], path+".schema.keys")
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []Field
+	fieldItems, ok := schemaObj[keyFields].([]any)
+	if !ok && schemaObj[keyFields] != nil {
+		return nil, p.invalid(path, "schema.fields must be an array")
+	}
+	for i, item := range fieldItems {
+		fieldObj, ok := item.(map[string]any)
+		if !ok || stringMember(fieldObj, keyName) == "" {
+			return nil, p.invalid(path, "schema.fields[%d] must be an object with a name", i)
+		}
+		fields = append(fields, Field{
+			Name:   stringMember(fieldObj, keyName),
+			Type:   stringMember(fieldObj, keyType),
+			Hidden: boolMember(fieldObj, keyHidden),
+		})
+	}
+	if len(fields) == 0 {
+		for _, key := range keys {
+			fields = append(fields, Field{Name: key})
+		}
+	}
+	groups, err := p.parseColumnGroups(schemaObj[keyGroups], path+".schema.groups")
+	if err != nil {
+		return nil, err
+	}
+	schema := &Schema{Fields: fields, Groups: groups, keyOrder: keys}
+
+	rows, ok := obj[keyData].([]any)
+	if !ok && obj[keyData] != nil {
+		return nil, p.invalid(path, "data must be an array")
+	}
+	records := make([]Record, len(rows))
+	for i, row := range rows {
+		rowObj, ok := row.(map[string]any)
+		if !ok {
+			return nil, p.invalid(path, "data[%d] must be an object", i)
+		}
+		record := make(Record, len(rowObj))
+		for key, val := range unnestColumnGroups(rowObj, schema) {
+			record[key] = p.tableValue(val, schema.FindField(key))
+		}
+		records[i] = record
+	}
+
+	var footer map[string]AggregateFunc
+	if obj[keyFooter] != nil {
+		footerObj, ok := obj[keyFooter].(map[string]any)
+		if !ok {
+			return nil, p.invalid(path, "footer must be an object")
+		}
+		footer = make(map[string]AggregateFunc, len(footerObj))
+		for key, val := range unnestColumnGroups(footerObj, schema) {
+			value := p.tableValue(val, schema.FindField(key))
+			footer[key] = func([]Record, string) any { return value }
+		}
+	}
+
+	return &TableContent{
+		id:      GenerateID(),
+		title:   stringMember(obj, keyTitle),
+		schema:  schema,
+		records: records,
+		footer:  footer,
+	}, nil
+}
+
+// parseColumnGroups rebuilds the column groups of a table schema
+func (p documentParser) parseColumnGroups(v any, path string) ([]ColumnGroup, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]any)
+	if !ok {
+		return nil, p.invalid(path, "expected an array, found %T", v)
+	}
+	groups := make([]ColumnGroup, len(items))
+	for i, item := range items {
+		groupObj, ok := item.(map[string]any)
+		if !ok || stringMember(groupObj, keyName) == "" {
+			return nil, p.invalid(path, "groups[%d] must be an object with a name", i)
+		}
+		columns, err := p.stringList(groupObj[keyColumns], fmt.Sprintf("%s[%d].columns", path, i))
+		if err != nil {
+			return nil, err
+		}
+		var labels map[string]string
+		if labelsObj, ok := groupObj[keyLabels].(map[string]any); ok {
+			labels = make(map[string]string, len(labelsObj))
+			for column := range labelsObj {
+				labels[column] = stringMember(labelsObj, column)
+			}
+		}
+		groups[i] = ColumnGroup{
+			Name:    stringMember(groupObj, keyName),
+			Columns: columns,
+			Labels:  labels,
+			Nest:    boolMember(groupObj, keyNest),
+		}
+	}
+	return groups, nil
+}
+
+// unnestColumnGroups moves the values of nested column groups back to the
+// top level of a rendered record, keyed by column name
+func unnestColumnGroups(obj map[string]any, schema *Schema) map[string]any {
+	for _, group := range schema.Groups {
+		nested, ok := obj[group.Name].(map[string]any)
+		if !group.Nest || !ok {
+			continue
+		}
+		delete(obj, group.Name)
+		for _, column := range group.Columns {
+			if val, ok := nested[group.label(column)]; ok {
+				obj[column] = val
+			}
+		}
+	}
+	return obj
+}
+
+// documentTimeLayouts are the layouts time values are written in: RFC 3339
+// by JSON and time.Time.String by YAML. String appends the monotonic clock
+// reading of times from time.Now as " m=±<seconds>", which tableValue strips
+// before parsing.
+var documentTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999 -0700 MST"}
+
+// tableValue restores a cell value, using the field type to undo the
+// widening done by the encoding: whole floats come back as int and YAML
+// reads unquoted numeric strings as numbers.
+func (p documentParser) tableValue(val any, field *Field) any {
+	if cv := collapsibleFromMap(val); cv != nil {
+		return cv
+	}
+	if field == nil || val == nil {
+		return val
+	}
+
+	switch field.Type {
+	case fieldTypeString:
+		switch val.(type) {
+		case int, float64, bool:
+			return fmt.Sprint(val)
+		}
+	case fieldTypeFloat:
+		if i, ok := val.(int); ok {
+			return float64(i)
+		}
+	case fieldTypeTime:
+		if s, ok := val.(string); ok {
+			if i := strings.LastIndex(s, " m="); i >= 0 {
+				s = s[:i]
+			}
+			for _, layout := range documentTimeLayouts {
+				if t, err := time.Parse(layout, s); err == nil {
+					return t
+				}
+			}
+		}
+	}
+	return val
+}
+
+// collapsibleFromMap rebuilds a CollapsibleValue from its rendered map, or
+// returns nil if val is not one. JSON marks these maps with a type; YAML
+// writes summary, details and expanded only.
+func collapsibleFromMap(val any) CollapsibleValue {
+	obj, ok := val.(map[string]any)
+	if !ok {
+		return nil
+	}
+	summary, hasSummary := obj[keySummary].(string)
+	expanded, hasExpanded := obj[keyExpanded].(bool)
+	if !hasSummary || !hasExpanded || !hasMember(obj, keyDetails) {
+		return nil
+	}
+	if typeName, hasType := obj[keyType]; hasType && typeName != valueTypeNameCollapsible {
+		return nil
+	}
+	return NewCollapsibleValue(summary, obj[keyDetails], WithExpanded(expanded))
+}
+
+// parseChart rebuilds chart content, decoding Gantt, pie, bar, line and
+// scatter data into their types. Data of other chart types is kept as decoded.
+func (p documentParser) parseChart(obj map[string]any, path string) (Content, error) {
+	chartType := stringMember(obj, keyChartType)
+	data := obj[keyData]
+
+	switch chartType {
+	case ChartTypeGantt:
+		var gantt GanttData
+		if err := p.decode(data, &gantt); err != nil {
+			return nil, p.invalid(path, "invalid gantt data: %v", err)
+		}
+		data = &gantt
+	case ChartTypePie:
+		var pie PieData
+		if err := p.decode(data, &pie); err != nil {
+			return nil, p.invalid(path, "invalid pie data: %v", err)
+		}
+		data = &pie
+	case ChartTypeBar:
+		var bar BarData
+		if err := p.decode(data, &bar); err != nil {
+			return nil, p.invalid(path, "invalid bar data: %v", err)
+		}
+		data = &bar
+	case ChartTypeLine:
+		var line LineData
+		if err := p.decode(data, &line); err != nil {
+			return nil, p.invalid(path, "invalid line data: %v", err)
+		}
+		data = &line
+	case ChartTypeScatter:
+		var scatter ScatterData
+		if err := p.decode(data, &scatter); err != nil {
+			return nil, p.invalid(path, "invalid scatter data: %v", err)
+		}
+		data = &scatter
+	}
+	return NewChartContent(stringMember(obj, keyTitle), chartType, data), nil
+}
+
+// parseGraph rebuilds graph content from its edges; the node list is derived
+func (p documentParser) parseGraph(obj map[string]any, path string) (Content, error) {
+	var edges []Edge
+	if err := p.decode(obj[keyEdges], &edges); err != nil {
+		return nil, p.invalid(path, "invalid edges: %v", err)
+	}
+	return NewGraphContent(stringMember(obj, keyTitle), edges), nil
+}
+
+// parseDrawIO rebuilds draw.io content with its header
+func (p documentParser) parseDrawIO(obj map[string]any, path string) (Content, error) {
+	var header DrawIOHeader
+	if err := p.decode(obj[keyHeader], &header); err != nil {
+		return nil, p.invalid(path, "invalid header: %v", err)
+	}
+	rows, ok := obj[keyRecords].([]any)
+	if !ok && obj[keyRecords] != nil {
+		return nil, p.invalid(path, "records must be an array")
+	}
+	records := make([]Record, len(rows))
+	for i, row := range rows {
+		rowObj, ok := row.(map[string]any)
+		if !ok {
+			return nil, p.invalid(path, "records[%d] must be an object", i)
+		}
+		records[i] = Record(rowObj)
+	}
+	return NewDrawIOContent(stringMember(obj, keyTitle), records, header), nil
+}
+
+// decode re-encodes v with the source codec and decodes it into target
+func (p documentParser) decode(v, target any) error {
+	if v == nil {
+		return nil
+	}
+	data, err := p.marshal(v)
+	if err != nil {
+		return err
+	}
+	return p.unmarshal(data, target)
+}
+
+// stringList converts a decoded array of names
+func (p documentParser) stringList(v any, path string) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]any)
+	if !ok {
+		return nil, p.invalid(path, "expected an array, found %T", v)
+	}
+	list := make([]string, len(items))
+	for i, item := range items {
+		list[i] = fmt.Sprint(item)
+	}
+	return list, nil
+}
+
+// hasMember reports whether obj has key, even with a null value
+func hasMember(obj map[string]any, key string) bool {
+	_, ok := obj[key]
+	return ok
+}
+
+// stringMember returns obj[key] as a string. YAML reads unquoted scalars
+// such as a numeric title as numbers, so those are formatted back.
+func stringMember(obj map[string]any, key string) string {
+	switch val := obj[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// intMember returns obj[key] as an int, or 0
+func intMember(obj map[string]any, key string) int {
+	switch val := obj[key].(type) {
+	case int:
+		return val
+	case float64:
+		return int(val)
+	default:
+		return 0
+	}
+}
+
+// boolMember returns obj[key] as a bool, or false
+func boolMember(obj map[string]any, key string) bool {
+	val, _ := obj[key].(bool)
+	return val
+}
@@ -0,0 +1,274 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDocument_RoundTrip(t *testing.T) {
+	section := NewSectionContent("Details", WithLevel(2))
+	section.AddContent(NewTextContent("Nested text"))
+	section.AddContent(NewGraphContent("Dependencies", []Edge{{From: "web", To: "db", Label: "sql"}}))
+
+	raw, err := NewRawContent(FormatHTML, []byte("<p>raw</p>"))
+	if err != nil {
+		t.Fatalf("NewRawContent() error = %v", err)
+	}
+
+	source := New().
+		AddContent(NewTextContent("Report", WithTextStyle(TextStyle{Bold: true, Color: "red", Size: 2, Header: true}))).
+		Table("Instances", []Record{
+			{"Name": "web", "Count": 3, "Cost": 1.5, "Launched": time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+				"Tags": NewCollapsibleValue("2 tags", []string{"env=prod", "team=ops"}, WithExpanded(true))},
+			{"Name": "007", "Count": 1, "Cost": 2.0, "Launched": time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), "Tags": "none"},
+		}, WithSchema(
+			Field{Name: "Name", Type: fieldTypeString},
+			Field{Name: "Count", Type: fieldTypeInt},
+			Field{Name: "Cost", Type: fieldTypeFloat},
+			Field{Name: "Launched", Type: fieldTypeTime},
+			Field{Name: "Tags", Type: fieldTypeInterface},
+			Field{Name: "Internal", Type: fieldTypeString, Hidden: true},
+		)).
+		AddContent(raw).
+		AddContent(section).
+		AddContent(NewCollapsibleSection("More", []Content{NewTextContent("Hidden by default")},
+			WithSectionLevel(1), WithSectionExpanded(true))).
+		AddContent(NewPieChart("Spend", []PieSlice{{Label: "EC2", Value: 12.5}, {Label: "S3", Value: 3}}, true)).
+		AddContent(NewGanttChart("Plan", []GanttTask{
+			{ID: "a", Title: "Build", StartDate: "2024-01-01", Duration: "3d", Status: "active", Section: "Dev"},
+			{ID: "b", Title: "Ship", StartDate: "2024-01-04", Duration: "1d", Dependencies: []string{"a"}},
+		})).
+		AddContent(NewDrawIOContent("Diagram", []Record{{"Name": "web"}}, DrawIOHeader{Label: "%Name%", Layout: "auto"})).
+		Build()
+
+	tests := map[string]struct {
+		format Format
+		parse  func(*bytes.Reader) (*Document, error)
+	}{
+		"json": {format: JSON(), parse: func(r *bytes.Reader) (*Document, error) { return ParseJSONDocument(r) }},
+		"yaml": {format: YAML(), parse: func(r *bytes.Reader) (*Document, error) { return ParseYAMLDocument(r) }},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			original, err := tc.format.Renderer.Render(ctx, source)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			doc, err := tc.parse(bytes.NewReader(original))
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			if got := len(doc.GetContents()); got != 8 {
+				t.Fatalf("parsed %d contents, want 8", got)
+			}
+
+			again, err := tc.format.Renderer.Render(ctx, doc)
+			if err != nil {
+				t.Fatalf("Render() of parsed document error = %v", err)
+			}
+			if !bytes.Equal(original, again) {
+				t.Errorf("round trip changed output\noriginal:\n%s\nagain:\n%s", original, again)
+			}
+
+			// The rebuilt table keeps its schema and typed values
+			table, ok := doc.GetContents()[1].(*TableContent)
+			if !ok {
+				t.Fatalf("content[1] = %T, want *TableContent", doc.GetContents()[1])
+			}
+			if got := strings.Join(table.Schema().GetKeyOrder(), ","); got != "Name,Count,Cost,Launched,Tags" {
+				t.Errorf("key order = %s", got)
+			}
+			if field := table.Schema().FindField("Internal"); field == nil || !field.Hidden {
+				t.Errorf("hidden field = %+v, want hidden Internal field", field)
+			}
+			second := table.Records()[1]
+			if second["Name"] != "007" || second["Count"] != 1 || second["Cost"] != 2.0 {
+				t.Errorf("record = %v, want typed values", second)
+			}
+			if launched, ok := second["Launched"].(time.Time); !ok || !launched.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("Launched = %#v, want time.Time", second["Launched"])
+			}
+			if _, ok := table.Records()[0]["Tags"].(CollapsibleValue); !ok {
+				t.Errorf("Tags = %#v, want CollapsibleValue", table.Records()[0]["Tags"])
+			}
+
+			text := doc.GetContents()[0].(*TextContent)
+			if want := (TextStyle{Bold: true, Color: "red", Size: 2, Header: true}); text.Style() != want {
+				t.Errorf("text style = %+v, want %+v", text.Style(), want)
+			}
+
+			// The rebuilt document renders in other formats
+			if _, err := Markdown().Renderer.Render(ctx, doc); err != nil {
+				t.Errorf("Markdown Render() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseDocument_CurrentTime(t *testing.T) {
+	// time.Now carries a monotonic clock reading, which YAML writes after
+	// the time as " m=+<seconds>"
+	now := time.Now()
+	table, err := NewTableContent("Events", []Record{{"At": now}}, WithSchema(Field{Name: "At", Type: fieldTypeTime}))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+
+	tests := map[string]struct {
+		format Format
+		parse  func(*bytes.Reader) (*Document, error)
+	}{
+		"json": {format: JSON(), parse: func(r *bytes.Reader) (*Document, error) { return ParseJSONDocument(r) }},
+		"yaml": {format: YAML(), parse: func(r *bytes.Reader) (*Document, error) { return ParseYAMLDocument(r) }},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.format.Renderer.Render(context.Background(), New().AddContent(table).Build())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			doc, err := tc.parse(bytes.NewReader(out))
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			got := doc.GetContents()[0].(*TableContent).Records()[0]["At"]
+			if at, ok := got.(time.Time); !ok || !at.Equal(now) {
+				t.Errorf("At = %#v, want %v", got, now)
+			}
+		})
+	}
+}
+
+func TestParseDocument_SeriesCharts(t *testing.T) {
+	tests := map[string]struct {
+		format Format
+		parse  func(*bytes.Reader) (*Document, error)
+	}{
+		"json": {format: JSON(), parse: func(r *bytes.Reader) (*Document, error) { return ParseJSONDocument(r) }},
+		"yaml": {format: YAML(), parse: func(r *bytes.Reader) (*Document, error) { return ParseYAMLDocument(r) }},
+	}
+	series := []ChartSeries{{Name: "EU", Values: []float64{10, 12.5}}, {Name: "US", Values: []float64{8, 9}}}
+	times := []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+	doc := New().
+		StackedBarChart("Sales", []string{"Q1", "Q2"}, series).
+		TimeSeriesChart("Requests", times, series).
+		ScatterChart("Latency", []ScatterSeries{{Name: "api", Points: []XYPoint{{X: 1, Y: 120}, {X: 2, Y: 95}}}}).
+		Build()
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			original, err := tc.format.Renderer.Render(ctx, doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			parsed, err := tc.parse(bytes.NewReader(original))
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			again, err := tc.format.Renderer.Render(ctx, parsed)
+			if err != nil {
+				t.Fatalf("Render() of parsed document error = %v", err)
+			}
+			if !bytes.Equal(original, again) {
+				t.Errorf("round trip changed output\noriginal:\n%s\nagain:\n%s", original, again)
+			}
+
+			contents := parsed.GetContents()
+			if len(contents) != 3 {
+				t.Fatalf("parsed %d contents, want 3", len(contents))
+			}
+			bar, ok := contents[0].(*ChartContent).GetData().(*BarData)
+			if !ok || !bar.Stacked || bar.Series[0].Values[1] != 12.5 {
+				t.Errorf("bar data = %#v", contents[0].(*ChartContent).GetData())
+			}
+			line, ok := contents[1].(*ChartContent).GetData().(*LineData)
+			if !ok || len(line.Times) != 2 || !line.Times[1].Equal(times[1]) {
+				t.Errorf("line data = %#v", contents[1].(*ChartContent).GetData())
+			}
+			scatter, ok := contents[2].(*ChartContent).GetData().(*ScatterData)
+			if !ok || scatter.Series[0].Points[1] != (XYPoint{X: 2, Y: 95}) {
+				t.Errorf("scatter data = %#v", contents[2].(*ChartContent).GetData())
+			}
+		})
+	}
+}
+
+func TestParseDocument_SingleAndEmpty(t *testing.T) {
+	ctx := context.Background()
+	table, err := NewTableContent("", []Record{{"A": 1, "B": "x"}}, WithKeys("B", "A"))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+
+	for _, doc := range []*Document{New().AddContent(table).Build(), New().Build()} {
+		out, err := JSON().Renderer.Render(ctx, doc)
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		parsed, err := ParseJSONDocument(bytes.NewReader(out))
+		if err != nil {
+			t.Fatalf("ParseJSONDocument() error = %v", err)
+		}
+		if got, want := len(parsed.GetContents()), len(doc.GetContents()); got != want {
+			t.Errorf("parsed %d contents, want %d", got, want)
+		}
+	}
+
+	parsed, err := ParseYAMLDocument(strings.NewReader(""))
+	if err != nil || len(parsed.GetContents()) != 0 {
+		t.Errorf("ParseYAMLDocument(empty) = %v, %v; want empty document", parsed, err)
+	}
+}
+
+func TestParseDocument_Errors(t *testing.T) {
+	tests := map[string]struct {
+		input       string
+		wantInvalid bool
+		wantErrText string
+	}{
+		"malformed json": {
+			input:       `{"schema":`,
+			wantErrText: "failed to decode JSON document",
+		},
+		"unrecognised content": {
+			input:       `[{"type":"text","content":"ok"},{"unknown":true}]`,
+			wantInvalid: true,
+			wantErrText: "content[1]: unrecognised content",
+		},
+		"nested error path": {
+			input:       `{"type":"section","title":"S","level":0,"contents":[42]}`,
+			wantInvalid: true,
+			wantErrText: "content[0].contents[0]",
+		},
+		"schema not an object": {
+			input:       `{"schema":[],"data":[]}`,
+			wantInvalid: true,
+			wantErrText: "schema must be an object",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseJSONDocument(strings.NewReader(tc.input))
+			if err == nil || !strings.Contains(err.Error(), tc.wantErrText) {
+				t.Fatalf("ParseJSONDocument() error = %v, want error containing %q", err, tc.wantErrText)
+			}
+			if got := errors.Is(err, ErrInvalidDocument); got != tc.wantInvalid {
+				t.Errorf("errors.Is(err, ErrInvalidDocument) = %v, want %v", got, tc.wantInvalid)
+			}
+		})
+	}
+
+	if _, err := ParseJSONDocument(nil); err == nil {
+		t.Error("ParseJSONDocument(nil) error = nil, want error")
+	}
+}
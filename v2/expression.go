@@ -0,0 +1,1155 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxExpressionDepth bounds nesting so hostile input cannot exhaust the stack
+const maxExpressionDepth = 200
+
+// ExpressionError reports a problem with an expression, either while parsing
+// it or while evaluating it against a record. Column is the 1-based character
+// position in Expression where the problem was found.
+type ExpressionError struct {
+	Expression string
+	Column     int
+	Message    string
+}
+
+// Error returns the error message
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("expression %q: column %d: %s", e.Expression, e.Column, e.Message)
+}
+
+// Expression is a parsed expression over the columns of a record, as used by
+// NewFilterOpFromExpression, NewAddColumnOpFromExpression and
+// NewSortOpFromExpression. Expressions have no side effects and always
+// terminate, so they are safe to accept from command-line users.
+//
+// The language supports:
+//
+//   - literals: 42, 3.5, "text" or 'text', true, false, null
+//   - column references: Status, or `Instance Id` for names that are not
+//     identifiers; a column missing from a record is null
+//   - arithmetic: + - * / % (+ concatenates when either side is a string)
+//   - comparisons: == != < <= > >=
+//   - boolean logic: && || ! (or and, or, not)
+//   - list membership: Status in ("failed", "error"), Status not in (...)
+//   - regular expressions: Name =~ "^web-" and Name !~ "test" (RE2 syntax)
+//   - string functions: lower, upper, trim, len, contains, startsWith,
+//     endsWith, replace, substr
+//
+// A numeric string compared with or used in arithmetic with a number is
+// treated as a number. Arithmetic on null yields null, and ordering
+// comparisons involving null are false. +, -, * and % on two integers are
+// exact, and a result outside the int64 range is an evaluation error.
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+// ParseExpression parses an expression. Syntax errors are returned as
+// *ExpressionError.
+func ParseExpression(source string) (*Expression, error) {
+	p, err := newExprParser(source)
+	if err != nil {
+		return nil, err
+	}
+	root, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectEnd(); err != nil {
+		return nil, err
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// String returns the expression source
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Fields returns the column names the expression references, sorted and
+// without duplicates.
+func (e *Expression) Fields() []string {
+	var names []string
+	for _, field := range exprFieldNodes(e.root) {
+		if !slices.Contains(names, field.name) {
+			names = append(names, field.name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ValidateFields checks that every column the expression references exists
+// in schema. The error is an *ExpressionError pointing at the first unknown
+// column.
+func (e *Expression) ValidateFields(schema *Schema) error {
+	columns := joinSchemaColumns(schema)
+	for _, field := range exprFieldNodes(e.root) {
+		if !slices.Contains(columns, field.name) {
+			return e.errorAt(field.at, fmt.Sprintf("unknown column %q; available columns: %s",
+				field.name, strings.Join(columns, ", ")))
+		}
+	}
+	return nil
+}
+
+// Eval evaluates the expression against record. Type errors, such as
+// comparing a number with a non-numeric string, and division by zero are
+// returned as *ExpressionError.
+func (e *Expression) Eval(record Record) (any, error) {
+	val, err := e.root.eval(record)
+	if err != nil {
+		if evalErr, ok := err.(*exprEvalError); ok {
+			return nil, e.errorAt(evalErr.at, evalErr.message)
+		}
+		return nil, err
+	}
+	return val, nil
+}
+
+// EvalBool evaluates the expression as a condition. Null counts as false; any
+// other non-boolean result is an error.
+func (e *Expression) EvalBool(record Record) (bool, error) {
+	val, err := e.Eval(record)
+	if err != nil {
+		return false, err
+	}
+	b, ok := exprTruth(val)
+	if !ok {
+		return false, e.errorAt(e.root.position(), fmt.Sprintf("expression must be a condition, got %s", exprTypeName(val)))
+	}
+	return b, nil
+}
+
+func (e *Expression) errorAt(column int, message string) *ExpressionError {
+	return &ExpressionError{Expression: e.source, Column: column, Message: message}
+}
+
+// exprEvalError is an evaluation failure at a node; Expression.Eval turns it
+// into an ExpressionError carrying the source.
+type exprEvalError struct {
+	at      int
+	message string
+}
+
+func (e *exprEvalError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.at, e.message)
+}
+
+func evalErrorf(at int, format string, args ...any) error {
+	return &exprEvalError{at: at, message: fmt.Sprintf(format, args...)}
+}
+
+// Lexer
+
+type exprTokenKind int
+
+const (
+	tokEOF exprTokenKind = iota
+	tokIdent
+	tokField // backtick-quoted column name
+	tokNumber
+	tokString
+	tokOperator
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string // identifier, operator or literal text (strings unescaped)
+	at   int    // 1-based column
+}
+
+func (t exprToken) describe() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// exprOperators lists operator tokens, longest first so that "==" is not
+// lexed as "=" "=".
+var exprOperators = []string{
+	"==", "!=", "<=", ">=", "=~", "!~", "&&", "||",
+	"<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ",", "=",
+}
+
+func tokenizeExpression(source string) ([]exprToken, error) {
+	runes := []rune(source)
+	var tokens []exprToken
+	errAt := func(i int, format string, args ...any) error {
+		return &ExpressionError{Expression: source, Column: i + 1, Message: fmt.Sprintf(format, args...)}
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || runes[i] == '.' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokIdent, text: string(runes[start:i]), at: start + 1})
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			// Exponent, e.g. 1e6 or 2.5E-3
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+					j++
+				}
+				if j < len(runes) && unicode.IsDigit(runes[j]) {
+					i = j
+					for i < len(runes) && unicode.IsDigit(runes[i]) {
+						i++
+					}
+				}
+			}
+			tokens = append(tokens, exprToken{kind: tokNumber, text: string(runes[start:i]), at: start + 1})
+
+		case r == '"' || r == '\'' || r == '`':
+			start := i
+			text, next, err := scanQuoted(runes, i)
+			if err != nil {
+				return nil, errAt(start, "%s", err.Error())
+			}
+			kind := tokString
+			if r == '`' {
+				kind = tokField
+				if text == "" {
+					return nil, errAt(start, "empty column name")
+				}
+			}
+			tokens = append(tokens, exprToken{kind: kind, text: text, at: start + 1})
+			i = next
+
+		default:
+			matched := false
+			for _, op := range exprOperators {
+				if strings.HasPrefix(string(runes[i:min(i+len(op), len(runes))]), op) {
+					tokens = append(tokens, exprToken{kind: tokOperator, text: op, at: i + 1})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, errAt(i, "unexpected character %q", r)
+			}
+		}
+	}
+
+	return append(tokens, exprToken{kind: tokEOF, at: len(runes) + 1}), nil
+}
+
+// scanQuoted reads a quoted literal starting at runes[start] and returns its
+// unescaped text and the index after the closing quote. Backslash escapes
+// \n, \t, \\ and the quote character are recognised.
+func scanQuoted(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	var b strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == quote:
+			return b.String(), i + 1, nil
+		case r == '\\' && i+1 < len(runes):
+			i++
+			switch runes[i] {
+			case 'n':
+				b.WriteRune('\n')
+			case 't':
+				b.WriteRune('\t')
+			default:
+				b.WriteRune(runes[i])
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated %c quote", quote)
+}
+
+// Parser
+
+type exprParser struct {
+	source string
+	tokens []exprToken
+	pos    int
+	depth  int
+}
+
+func newExprParser(source string) (*exprParser, error) {
+	tokens, err := tokenizeExpression(source)
+	if err != nil {
+		return nil, err
+	}
+	return &exprParser{source: source, tokens: tokens}, nil
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *exprParser) errorf(tok exprToken, format string, args ...any) error {
+	return &ExpressionError{Expression: p.source, Column: tok.at, Message: fmt.Sprintf(format, args...)}
+}
+
+// isOperator reports whether tok is one of the given operators.
+func (tok exprToken) isOperator(ops ...string) bool {
+	return tok.kind == tokOperator && slices.Contains(ops, tok.text)
+}
+
+// isKeyword reports whether tok is the given keyword, case-insensitively.
+func (tok exprToken) isKeyword(word string) bool {
+	return tok.kind == tokIdent && strings.EqualFold(tok.text, word)
+}
+
+func (p *exprParser) expectOperator(op string) (exprToken, error) {
+	tok := p.next()
+	if !tok.isOperator(op) {
+		return tok, p.errorf(tok, "expected %q, found %s", op, tok.describe())
+	}
+	return tok, nil
+}
+
+func (p *exprParser) expectEnd() error {
+	if tok := p.peek(); tok.kind != tokEOF {
+		return p.errorf(tok, "unexpected %s", tok.describe())
+	}
+	return nil
+}
+
+func (p *exprParser) parseExpression() (exprNode, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxExpressionDepth {
+		return nil, p.errorf(p.peek(), "expression nested too deeply")
+	}
+	return p.parseOr()
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok.isOperator("||") || tok.isKeyword("or"); tok = p.peek() {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{or: true, left: left, right: right, at: tok.at}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok.isOperator("&&") || tok.isKeyword("and"); tok = p.peek() {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{left: left, right: right, at: tok.at}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	tok := p.peek()
+	if tok.isOperator("!") || tok.isKeyword("not") {
+		p.next()
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxExpressionDepth {
+			return nil, p.errorf(tok, "expression nested too deeply")
+		}
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand, at: tok.at}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	switch {
+	case tok.isOperator("==", "!=", "<", "<=", ">", ">="):
+		p.next()
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &compareNode{op: tok.text, left: left, right: right, at: tok.at}, nil
+
+	case tok.isOperator("=~", "!~"):
+		p.next()
+		patternTok := p.next()
+		if patternTok.kind != tokString {
+			return nil, p.errorf(patternTok, "regular expression must be a string literal, found %s", patternTok.describe())
+		}
+		re, err := regexp.Compile(patternTok.text)
+		if err != nil {
+			return nil, p.errorf(patternTok, "invalid regular expression: %v", err)
+		}
+		return &matchNode{value: left, re: re, negate: tok.text == "!~", at: tok.at}, nil
+
+	case tok.isKeyword("in"):
+		p.next()
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &inNode{value: left, list: list, at: tok.at}, nil
+
+	case tok.isKeyword("not") && p.tokens[p.pos+1].isKeyword("in"):
+		p.next()
+		p.next()
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &inNode{value: left, list: list, negate: true, at: tok.at}, nil
+	}
+
+	return left, nil
+}
+
+// parseList parses a parenthesised or bracketed list of expressions.
+func (p *exprParser) parseList() ([]exprNode, error) {
+	open := p.next()
+	closing := ")"
+	switch {
+	case open.isOperator("("):
+	case open.isOperator("["):
+		closing = "]"
+	default:
+		return nil, p.errorf(open, "expected list after in, found %s", open.describe())
+	}
+
+	var list []exprNode
+	if p.peek().isOperator(closing) {
+		p.next()
+		return list, nil
+	}
+	for {
+		item, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+
+		tok := p.next()
+		if tok.isOperator(closing) {
+			return list, nil
+		}
+		if !tok.isOperator(",") {
+			return nil, p.errorf(tok, "expected \",\" or %q in list, found %s", closing, tok.describe())
+		}
+	}
+}
+
+func (p *exprParser) parseAdditive() (exprNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok.isOperator("+", "-"); tok = p.peek() {
+		p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &arithmeticNode{op: tok.text, left: left, right: right, at: tok.at}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok.isOperator("*", "/", "%"); tok = p.peek() {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &arithmeticNode{op: tok.text, left: left, right: right, at: tok.at}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	tok := p.peek()
+	if tok.isOperator("-") {
+		p.next()
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxExpressionDepth {
+			return nil, p.errorf(tok, "expression nested too deeply")
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &negateNode{operand: operand, at: tok.at}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		val, err := parseExprNumber(tok.text)
+		if err != nil {
+			return nil, p.errorf(tok, "invalid number %q", tok.text)
+		}
+		return &literalNode{value: val, at: tok.at}, nil
+
+	case tokString:
+		return &literalNode{value: tok.text, at: tok.at}, nil
+
+	case tokField:
+		return &fieldNode{name: tok.text, at: tok.at}, nil
+
+	case tokIdent:
+		switch {
+		case tok.isKeyword("true"):
+			return &literalNode{value: true, at: tok.at}, nil
+		case tok.isKeyword("false"):
+			return &literalNode{value: false, at: tok.at}, nil
+		case tok.isKeyword("null"), tok.isKeyword("nil"):
+			return &literalNode{value: nil, at: tok.at}, nil
+		case tok.isKeyword("and"), tok.isKeyword("or"), tok.isKeyword("not"), tok.isKeyword("in"):
+			return nil, p.errorf(tok, "unexpected keyword %q; quote column names that are keywords with backticks", tok.text)
+		}
+		if p.peek().isOperator("(") {
+			return p.parseCall(tok)
+		}
+		return &fieldNode{name: tok.text, at: tok.at}, nil
+
+	case tokOperator:
+		if tok.isOperator("(") {
+			inner, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expectOperator(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		}
+	}
+
+	return nil, p.errorf(tok, "expected a value, found %s", tok.describe())
+}
+
+func (p *exprParser) parseCall(name exprToken) (exprNode, error) {
+	fn, ok := expressionFunctions[strings.ToLower(name.text)]
+	if !ok {
+		return nil, p.errorf(name, "unknown function %q", name.text)
+	}
+	p.next() // (
+
+	var args []exprNode
+	if p.peek().isOperator(")") {
+		p.next()
+	} else {
+		for {
+			arg, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			tok := p.next()
+			if tok.isOperator(")") {
+				break
+			}
+			if !tok.isOperator(",") {
+				return nil, p.errorf(tok, "expected \",\" or \")\" in call to %s, found %s", name.text, tok.describe())
+			}
+		}
+	}
+
+	if len(args) < fn.minArgs || len(args) > fn.maxArgs {
+		want := strconv.Itoa(fn.minArgs)
+		if fn.maxArgs != fn.minArgs {
+			want = fmt.Sprintf("%d to %d", fn.minArgs, fn.maxArgs)
+		}
+		return nil, p.errorf(name, "function %s takes %s arguments, got %d", name.text, want, len(args))
+	}
+	return &callNode{name: name.text, fn: fn, args: args, at: name.at}, nil
+}
+
+func parseExprNumber(text string) (any, error) {
+	if i, err := strconv.ParseInt(text, 10, 0); err == nil {
+		return int(i), nil
+	}
+	return strconv.ParseFloat(text, 64)
+}
+
+// AST
+
+type exprNode interface {
+	eval(record Record) (any, error)
+	position() int
+}
+
+type literalNode struct {
+	value any
+	at    int
+}
+
+func (n *literalNode) eval(Record) (any, error) { return n.value, nil }
+func (n *literalNode) position() int            { return n.at }
+
+type fieldNode struct {
+	name string
+	at   int
+}
+
+func (n *fieldNode) eval(record Record) (any, error) { return record[n.name], nil }
+func (n *fieldNode) position() int                   { return n.at }
+
+type logicalNode struct {
+	or          bool
+	left, right exprNode
+	at          int
+}
+
+func (n *logicalNode) position() int { return n.at }
+
+func (n *logicalNode) eval(record Record) (any, error) {
+	left, err := evalCondition(n.left, record)
+	if err != nil {
+		return nil, err
+	}
+	// Short-circuit
+	if left == n.or {
+		return left, nil
+	}
+	return evalCondition(n.right, record)
+}
+
+type notNode struct {
+	operand exprNode
+	at      int
+}
+
+func (n *notNode) position() int { return n.at }
+
+func (n *notNode) eval(record Record) (any, error) {
+	val, err := evalCondition(n.operand, record)
+	if err != nil {
+		return nil, err
+	}
+	return !val, nil
+}
+
+// evalCondition evaluates node as a boolean, treating null as false.
+func evalCondition(node exprNode, record Record) (bool, error) {
+	val, err := node.eval(record)
+	if err != nil {
+		return false, err
+	}
+	b, ok := exprTruth(val)
+	if !ok {
+		return false, evalErrorf(node.position(), "expected a condition, got %s", exprTypeName(val))
+	}
+	return b, nil
+}
+
+func exprTruth(val any) (bool, bool) {
+	switch v := val.(type) {
+	case nil:
+		return false, true
+	case bool:
+		return v, true
+	}
+	return false, false
+}
+
+type compareNode struct {
+	op          string
+	left, right exprNode
+	at          int
+}
+
+func (n *compareNode) position() int { return n.at }
+
+func (n *compareNode) eval(record Record) (any, error) {
+	left, err := n.left.eval(record)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(record)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return exprEqual(left, right), nil
+	case "!=":
+		return !exprEqual(left, right), nil
+	}
+
+	if left == nil || right == nil {
+		return false, nil
+	}
+	cmp, ok := exprCompare(left, right)
+	if !ok {
+		return nil, evalErrorf(n.at, "cannot compare %s with %s", exprTypeName(left), exprTypeName(right))
+	}
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+type matchNode struct {
+	value  exprNode
+	re     *regexp.Regexp
+	negate bool
+	at     int
+}
+
+func (n *matchNode) position() int { return n.at }
+
+func (n *matchNode) eval(record Record) (any, error) {
+	val, err := n.value.eval(record)
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return false, nil
+	}
+	return n.re.MatchString(formatValue(val)) != n.negate, nil
+}
+
+type inNode struct {
+	value  exprNode
+	list   []exprNode
+	negate bool
+	at     int
+}
+
+func (n *inNode) position() int { return n.at }
+
+func (n *inNode) eval(record Record) (any, error) {
+	val, err := n.value.eval(record)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range n.list {
+		candidate, err := item.eval(record)
+		if err != nil {
+			return nil, err
+		}
+		if exprEqual(val, candidate) {
+			return !n.negate, nil
+		}
+	}
+	return n.negate, nil
+}
+
+type arithmeticNode struct {
+	op          string
+	left, right exprNode
+	at          int
+}
+
+func (n *arithmeticNode) position() int { return n.at }
+
+func (n *arithmeticNode) eval(record Record) (any, error) {
+	left, err := n.left.eval(record)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(record)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	if n.op == "+" {
+		_, leftStr := left.(string)
+		_, rightStr := right.(string)
+		if leftStr || rightStr {
+			return formatValue(left) + formatValue(right), nil
+		}
+	}
+
+	a, aInt, okA := exprNumber(left, true)
+	b, bInt, okB := exprNumber(right, true)
+	if !okA || !okB {
+		return nil, evalErrorf(n.at, "cannot apply %s to %s and %s", n.op, exprTypeName(left), exprTypeName(right))
+	}
+
+	if (n.op == "/" || n.op == "%") && b == 0 {
+		return nil, evalErrorf(n.at, "division by zero")
+	}
+
+	if n.op != "/" && aInt && bInt {
+		x, okX := exprInt(left)
+		y, okY := exprInt(right)
+		if okX && okY {
+			result, ok := exprIntArithmetic(n.op, x, y)
+			if !ok {
+				return nil, evalErrorf(n.at, "integer overflow in %d %s %d", x, n.op, y)
+			}
+			return int(result), nil
+		}
+	}
+
+	switch n.op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		return a / b, nil
+	}
+	return math.Mod(a, b), nil
+}
+
+// exprIntArithmetic applies op to two integers. It reports false when the
+// result does not fit in an int64.
+func exprIntArithmetic(op string, x, y int64) (int64, bool) {
+	switch op {
+	case "+":
+		result := x + y
+		return result, (x >= 0) != (y >= 0) || (result >= 0) == (x >= 0)
+	case "-":
+		result := x - y
+		return result, (x >= 0) == (y >= 0) || (result >= 0) == (x >= 0)
+	case "*":
+		if x == 0 || y == 0 {
+			return 0, true
+		}
+		result := x * y
+		return result, result/y == x && !(x == -1 && y == math.MinInt64) && !(y == -1 && x == math.MinInt64)
+	}
+	if y == -1 {
+		return 0, true // x % -1, which would overflow for math.MinInt64
+	}
+	return x % y, true
+}
+
+type negateNode struct {
+	operand exprNode
+	at      int
+}
+
+func (n *negateNode) position() int { return n.at }
+
+func (n *negateNode) eval(record Record) (any, error) {
+	val, err := n.operand.eval(record)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	num, isInt, ok := exprNumber(val, true)
+	if !ok {
+		return nil, evalErrorf(n.at, "cannot negate %s", exprTypeName(val))
+	}
+	if i, ok := exprInt(val); ok && isInt {
+		if i == math.MinInt64 {
+			return nil, evalErrorf(n.at, "integer overflow in -%d", i)
+		}
+		return -int(i), nil
+	}
+	return -num, nil
+}
+
+type callNode struct {
+	name string
+	fn   exprFunction
+	args []exprNode
+	at   int
+}
+
+func (n *callNode) position() int { return n.at }
+
+func (n *callNode) eval(record Record) (any, error) {
+	args := make([]any, len(n.args))
+	for i, arg := range n.args {
+		val, err := arg.eval(record)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = val
+	}
+	result, err := n.fn.call(args)
+	if err != nil {
+		return nil, evalErrorf(n.at, "%s: %v", n.name, err)
+	}
+	return result, nil
+}
+
+// exprFieldNodes returns every column reference in the tree, in source order.
+func exprFieldNodes(node exprNode) []*fieldNode {
+	var fields []*fieldNode
+	var walk func(exprNode)
+	walk = func(node exprNode) {
+		switch n := node.(type) {
+		case *fieldNode:
+			fields = append(fields, n)
+		case *logicalNode:
+			walk(n.left)
+			walk(n.right)
+		case *notNode:
+			walk(n.operand)
+		case *compareNode:
+			walk(n.left)
+			walk(n.right)
+		case *matchNode:
+			walk(n.value)
+		case *inNode:
+			walk(n.value)
+			for _, item := range n.list {
+				walk(item)
+			}
+		case *arithmeticNode:
+			walk(n.left)
+			walk(n.right)
+		case *negateNode:
+			walk(n.operand)
+		case *callNode:
+			for _, arg := range n.args {
+				walk(arg)
+			}
+		}
+	}
+	walk(node)
+	slices.SortStableFunc(fields, func(a, b *fieldNode) int { return a.at - b.at })
+	return fields
+}
+
+// Values
+
+// exprNumber converts val to float64, reporting whether it is an integer.
+// With parseStrings, numeric strings are converted too.
+func exprNumber(val any, parseStrings bool) (float64, bool, bool) {
+	if s, ok := val.(string); ok {
+		if !parseStrings {
+			return 0, false, false
+		}
+		s = strings.TrimSpace(s)
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return float64(i), true, true
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		return f, false, err == nil
+	}
+
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true, true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), false, true
+	}
+	return 0, false, false
+}
+
+// exprInt returns an integer value, or a string holding one, as an exact
+// int64. It reports false for other values and for unsigned integers above
+// math.MaxInt64.
+func exprInt(val any) (int64, bool) {
+	if s, ok := val.(string); ok {
+		i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		return i, err == nil
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		return int64(u), u <= math.MaxInt64
+	}
+	return 0, false
+}
+
+// exprEqual compares values for == and in. Two strings compare as text; a
+// number and a numeric string compare as numbers.
+func exprEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if cmp, ok := exprCompare(a, b); ok {
+		return cmp == 0
+	}
+	if ba, ok := a.(bool); ok {
+		if bb, ok := b.(bool); ok {
+			return ba == bb
+		}
+	}
+	return formatValue(a) == formatValue(b)
+}
+
+// exprCompare orders two non-nil values. It reports false for values that
+// have no common ordering.
+func exprCompare(a, b any) (int, bool) {
+	sa, aStr := a.(string)
+	sb, bStr := b.(string)
+	if aStr && bStr {
+		return strings.Compare(sa, sb), true
+	}
+
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Compare(tb), true
+		}
+		return 0, false
+	}
+
+	// Only coerce a string when the other side is a real number
+	fa, _, okA := exprNumber(a, !bStr)
+	fb, _, okB := exprNumber(b, !aStr)
+	if okA && okB {
+		switch {
+		case fa < fb:
+			return -1, true
+		case fa > fb:
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func exprTypeName(val any) string {
+	switch val.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case time.Time:
+		return "time"
+	}
+	if _, _, ok := exprNumber(val, false); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", val)
+}
+
+// exprText converts a function argument to text; null becomes "".
+func exprText(val any) string {
+	if val == nil {
+		return ""
+	}
+	return formatValue(val)
+}
+
+// exprFunction is a built-in function with a fixed arity range.
+type exprFunction struct {
+	minArgs, maxArgs int
+	call             func(args []any) (any, error)
+}
+
+func stringFunction(fn func(string) any) exprFunction {
+	return exprFunction{minArgs: 1, maxArgs: 1, call: func(args []any) (any, error) {
+		return fn(exprText(args[0])), nil
+	}}
+}
+
+func stringPredicate(fn func(s, sub string) bool) exprFunction {
+	return exprFunction{minArgs: 2, maxArgs: 2, call: func(args []any) (any, error) {
+		return fn(exprText(args[0]), exprText(args[1])), nil
+	}}
+}
+
+// expressionFunctions are the built-in functions, keyed by lower-case name.
+var expressionFunctions = map[string]exprFunction{
+	"lower":      stringFunction(func(s string) any { return strings.ToLower(s) }),
+	"upper":      stringFunction(func(s string) any { return strings.ToUpper(s) }),
+	"trim":       stringFunction(func(s string) any { return strings.TrimSpace(s) }),
+	"len":        stringFunction(func(s string) any { return len([]rune(s)) }),
+	"contains":   stringPredicate(strings.Contains),
+	"startswith": stringPredicate(strings.HasPrefix),
+	"endswith":   stringPredicate(strings.HasSuffix),
+	"replace": {minArgs: 3, maxArgs: 3, call: func(args []any) (any, error) {
+		return strings.ReplaceAll(exprText(args[0]), exprText(args[1]), exprText(args[2])), nil
+	}},
+	// substr(s, start[, length]) with a 0-based start, counted in characters
+	"substr": {minArgs: 2, maxArgs: 3, call: func(args []any) (any, error) {
+		runes := []rune(exprText(args[0]))
+		// Positions are clamped to the text as floats, since converting NaN,
+		// infinite or huge numbers to int is not defined
+		start, _, ok := exprNumber(args[1], true)
+		if !ok || start < 0 || math.IsNaN(start) || math.IsInf(start, 0) {
+			return nil, fmt.Errorf("start must be a non-negative number")
+		}
+		from := int(min(start, float64(len(runes))))
+		to := len(runes)
+		if len(args) == 3 {
+			length, _, ok := exprNumber(args[2], true)
+			if !ok || length < 0 || math.IsNaN(length) || math.IsInf(length, 0) {
+				return nil, fmt.Errorf("length must be a non-negative number")
+			}
+			to = from + int(min(length, float64(len(runes)-from)))
+		}
+		return string(runes[from:to]), nil
+	}},
+}
@@ -0,0 +1,276 @@
+package output
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// NewFilterOpFromExpression creates a filter from a condition such as
+// `Status == "failed" && Age > 3`. See Expression for the syntax. Records for
+// which the condition is null are dropped, and an evaluation error (for
+// example comparing a number with a non-numeric string) fails the operation.
+//
+// Column references are checked against the table schema by Validate when
+// the filter runs as a transformation, and by Apply.
+func NewFilterOpFromExpression(expression string) (*FilterOp, error) {
+	expr, err := ParseExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &FilterOp{
+		predicate: func(record Record) bool {
+			keep, err := expr.EvalBool(record)
+			return err == nil && keep
+		},
+		expr: expr,
+	}, nil
+}
+
+// NewAddColumnOpFromExpression creates a calculated column from a definition
+// of the form `Name = expression`, such as `Total = Price * Qty`. The name
+// may be backtick-quoted. Position works as in NewAddColumnOp.
+func NewAddColumnOpFromExpression(definition string, position *int) (*AddColumnOp, error) {
+	p, err := newExprParser(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	nameTok := p.next()
+	if nameTok.kind != tokField && (nameTok.kind != tokIdent || isExpressionKeyword(nameTok.text)) {
+		return nil, p.errorf(nameTok, "expected column name, found %s", nameTok.describe())
+	}
+	if _, err := p.expectOperator("="); err != nil {
+		return nil, err
+	}
+
+	root, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectEnd(); err != nil {
+		return nil, err
+	}
+
+	expr := &Expression{source: definition, root: root}
+	return &AddColumnOp{
+		name: nameTok.text,
+		fn: func(record Record) any {
+			val, _ := expr.Eval(record)
+			return val
+		},
+		position: position,
+		expr:     expr,
+	}, nil
+}
+
+// NewSortOpFromExpression creates a sort from a comma-separated list of keys,
+// each an expression optionally followed by asc or desc, such as
+// `Region, Price * Qty desc`. Keys that are plain column references sort
+// exactly like NewSortOp; computed keys are evaluated once per record.
+func NewSortOpFromExpression(spec string) (*SortOp, error) {
+	p, err := newExprParser(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		expressions []sortExpression
+		keys        []SortKey
+		allColumns  = true
+	)
+	for {
+		root, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		direction := Ascending
+		switch tok := p.peek(); {
+		case tok.isKeyword("asc"):
+			p.next()
+		case tok.isKeyword("desc"):
+			p.next()
+			direction = Descending
+		}
+
+		expressions = append(expressions, sortExpression{
+			expr:      &Expression{source: spec, root: root},
+			direction: direction,
+		})
+		if field, ok := root.(*fieldNode); ok {
+			keys = append(keys, SortKey{Column: field.name, Direction: direction})
+		} else {
+			allColumns = false
+		}
+
+		tok := p.next()
+		if tok.kind == tokEOF {
+			break
+		}
+		if !tok.isOperator(",") {
+			return nil, p.errorf(tok, "expected \",\", asc or desc after sort key, found %s", tok.describe())
+		}
+	}
+
+	op := &SortOp{expressions: expressions}
+	if allColumns {
+		op.keys = keys
+	}
+	return op, nil
+}
+
+// isExpressionKeyword reports whether word is reserved by the expression
+// language and therefore needs backticks as a column name.
+func isExpressionKeyword(word string) bool {
+	switch strings.ToLower(word) {
+	case "and", "or", "not", "in", "true", "false", "null", "nil":
+		return true
+	}
+	return false
+}
+
+// sortExpression is one key of an expression-based sort
+type sortExpression struct {
+	expr      *Expression
+	direction SortDirection
+}
+
+// sortByExpressions evaluates every key for every record, then sorts stably
+// on the results.
+func (o *SortOp) sortByExpressions(ctx context.Context, records []Record) ([]Record, error) {
+	type keyedRecord struct {
+		record Record
+		values []any
+	}
+
+	keyed := make([]keyedRecord, len(records))
+	for i, record := range records {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		values := make([]any, len(o.expressions))
+		for k, key := range o.expressions {
+			val, err := key.expr.Eval(record)
+			if err != nil {
+				return nil, err
+			}
+			values[k] = val
+		}
+		keyed[i] = keyedRecord{record: record, values: values}
+	}
+
+	sort.SliceStable(keyed, func(i, j int) bool {
+		for k, key := range o.expressions {
+			cmp := o.compareExpressionValues(keyed[i].values[k], keyed[j].values[k])
+			if cmp == 0 {
+				continue
+			}
+			if key.direction == Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sorted := make([]Record, len(keyed))
+	for i, k := range keyed {
+		sorted[i] = k.record
+	}
+	return sorted, nil
+}
+
+// compareExpressionValues orders computed sort values. Numbers of different
+// Go types compare numerically, which compareValues alone would not do.
+func (o *SortOp) compareExpressionValues(a, b any) int {
+	if a != nil && b != nil {
+		if cmp, ok := exprCompare(a, b); ok {
+			return cmp
+		}
+	}
+	return o.compareValues(a, b)
+}
+
+// matches reports whether record passes the filter. Expression filters
+// surface evaluation errors instead of silently dropping the record.
+func (o *FilterOp) matches(record Record) (bool, error) {
+	if o.expr != nil {
+		return o.expr.EvalBool(record)
+	}
+	return o.predicate(record), nil
+}
+
+// compute calculates the column value for record, surfacing expression
+// evaluation errors.
+func (o *AddColumnOp) compute(record Record) (any, error) {
+	if o.expr != nil {
+		return o.expr.Eval(record)
+	}
+	return o.fn(record), nil
+}
+
+// schemaBinder is implemented by operations whose Validate checks column
+// references against a schema. applyContentTransformations binds the schema
+// of the content being transformed before validating each operation.
+type schemaBinder interface {
+	bindSchema(schema *Schema) Operation
+}
+
+// bindSchema returns a copy of the filter that validates against schema
+func (o *FilterOp) bindSchema(schema *Schema) Operation {
+	if o.expr == nil {
+		return o
+	}
+	bound := *o
+	bound.schema = schema
+	return &bound
+}
+
+// bindSchema returns a copy of the sort that validates against schema
+func (o *SortOp) bindSchema(schema *Schema) Operation {
+	if len(o.expressions) == 0 {
+		return o
+	}
+	bound := *o
+	bound.schema = schema
+	return &bound
+}
+
+// bindSchema returns a copy of the operation that validates against schema
+func (o *AddColumnOp) bindSchema(schema *Schema) Operation {
+	if o.expr == nil {
+		return o
+	}
+	bound := *o
+	bound.schema = schema
+	return &bound
+}
+
+// contentSchema returns the schema of table content, or nil for other content.
+func contentSchema(content Content) *Schema {
+	switch c := content.(type) {
+	case *TableContent:
+		return c.schema
+	case *StreamingTableContent:
+		return c.schema
+	}
+	return nil
+}
+
+// validateExpressionFields checks the columns referenced by expr against
+// schema. It does nothing when either is nil.
+func validateExpressionFields(field string, schema *Schema, expr *Expression) error {
+	if expr == nil || schema == nil {
+		return nil
+	}
+	if err := expr.ValidateFields(schema); err != nil {
+		return NewValidationErrorWithCause(field, expr.String(),
+			"expression references a column that does not exist", err)
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpressionEval(t *testing.T) {
+	record := Record{
+		"Status":      "failed",
+		"Age":         5,
+		"Price":       2.5,
+		"Qty":         4,
+		"Count":       "12",
+		"Name":        "web-01",
+		"Active":      true,
+		"Instance Id": "i-1",
+		"Created":     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		"Other":       time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		"Exact":       1<<53 + 1,
+	}
+
+	tests := map[string]struct {
+		expr string
+		want any
+	}{
+		"string equality":       {`Status == "failed"`, true},
+		"single quotes":         {`Status != 'ok'`, true},
+		"integer comparison":    {`Age > 3`, true},
+		"and":                   {`Status == "failed" && Age > 3`, true},
+		"keyword or":            {`Status == "ok" or Age >= 5`, true},
+		"not":                   {`!Active`, false},
+		"keyword not":           {`not (Age < 3)`, true},
+		"arithmetic":            {`Price * Qty`, 10.0},
+		"integer arithmetic":    {`Age * 2 + 1`, 11},
+		"precedence":            {`1 + 2 * 3`, 7},
+		"parentheses":           {`(1 + 2) * 3`, 9},
+		"division":              {`Age / 2`, 2.5},
+		"modulo":                {`Age % 2`, 1},
+		"unary minus":           {`-Age`, -5},
+		"exact large integers":  {`Exact * 2`, 1<<54 + 2},
+		"exact large sum":       {`Exact + 1 - Age`, 1<<53 - 3},
+		"numeric string":        {`Count > 9`, true},
+		"string comparison":     {`Count < "9"`, true},
+		"concatenation":         {`Name + "/" + Age`, "web-01/5"},
+		"in list":               {`Status in ("failed", "error")`, true},
+		"bracket list":          {`Age in [1, 5]`, true},
+		"not in":                {`Status not in ("failed")`, false},
+		"regex":                 {`Name =~ "^web-\\d+$"`, true},
+		"negated regex":         {`Name !~ "db"`, true},
+		"backtick column":       {"`Instance Id` == \"i-1\"", true},
+		"missing column is nil": {`Missing == null`, true},
+		"nil arithmetic":        {`Missing + 1`, nil},
+		"nil ordering is false": {`Missing > 1`, false},
+		"time comparison":       {`Created > Other`, true},
+		"lower":                 {`lower("ABC")`, "abc"},
+		"upper":                 {`UPPER(Status)`, "FAILED"},
+		"trim":                  {`trim("  x ")`, "x"},
+		"len":                   {`len(Name)`, 6},
+		"contains":              {`contains(Name, "b-0")`, true},
+		"startsWith":            {`startsWith(Name, "web")`, true},
+		"endsWith":              {`endsWith(Name, "02")`, false},
+		"replace":               {`replace(Name, "-", "_")`, "web_01"},
+		"substr":                {`substr(Name, 4)`, "01"},
+		"substr with length":    {`substr(Name, 0, 3)`, "web"},
+		"substr past the end":   {`substr(Name, 1e300)`, ""},
+		"substr huge length":    {`substr(Name, 0, 1e19)`, "web-01"},
+		"float literal":         {`1.5e1`, 15.0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := ParseExpression(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q) error = %v", tt.expr, err)
+			}
+			got, err := expr.Eval(record)
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Eval(%q) = %#v, want %#v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpressionParseErrors(t *testing.T) {
+	tests := map[string]struct {
+		expr       string
+		wantColumn int
+		wantMsg    string
+	}{
+		"missing operand":       {`Status == `, 11, "expected a value, found end of expression"},
+		"unexpected token":      {`Age > 3 )`, 9, `unexpected ")"`},
+		"unterminated string":   {`Status == "failed`, 11, "unterminated"},
+		"unknown character":     {`Age # 3`, 5, "unexpected character"},
+		"unknown function":      {`Age > 1 && frob(Name)`, 12, `unknown function "frob"`},
+		"wrong arity":           {`lower(Name, Status)`, 1, "takes 1 arguments, got 2"},
+		"regex not literal":     {`Name =~ Status`, 9, "must be a string literal"},
+		"invalid regex":         {`Name =~ "("`, 9, "invalid regular expression"},
+		"unclosed list":         {`Age in (1, 2`, 13, `expected "," or ")"`},
+		"keyword as value":      {`Age > and`, 7, "unexpected keyword"},
+		"unclosed parenthesis":  {`(Age > 1`, 9, `expected ")"`},
+		"position counts runes": {`"é" == )`, 8, "expected a value"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseExpression(tt.expr)
+			var exprErr *ExpressionError
+			if !errors.As(err, &exprErr) {
+				t.Fatalf("ParseExpression(%q) error = %v, want *ExpressionError", tt.expr, err)
+			}
+			if exprErr.Column != tt.wantColumn {
+				t.Errorf("Column = %d, want %d (%v)", exprErr.Column, tt.wantColumn, err)
+			}
+			if !strings.Contains(exprErr.Message, tt.wantMsg) {
+				t.Errorf("Message = %q, want containing %q", exprErr.Message, tt.wantMsg)
+			}
+		})
+	}
+
+	t.Run("nesting limit", func(t *testing.T) {
+		deep := strings.Repeat("(", 1000) + "1" + strings.Repeat(")", 1000)
+		if _, err := ParseExpression(deep); err == nil || !strings.Contains(err.Error(), "nested too deeply") {
+			t.Errorf("ParseExpression() error = %v, want nesting error", err)
+		}
+	})
+}
+
+func TestExpressionEvalErrors(t *testing.T) {
+	tests := map[string]struct {
+		expr       string
+		wantColumn int
+		wantMsg    string
+	}{
+		"division by zero":   {`Age / 0`, 5, "division by zero"},
+		"incomparable types": {`Name > 3`, 6, "cannot compare string with number"},
+		"non-boolean logic":  {`Age && true`, 1, "expected a condition"},
+		"bad arithmetic":     {`Name * 2`, 6, "cannot apply *"},
+		"substr NaN start":   {`substr(Name, "NaN")`, 1, "start must be a non-negative number"},
+		"substr Inf length":  {`substr(Name, 0, "Inf")`, 1, "length must be a non-negative number"},
+		"integer overflow":   {`Big * 2`, 5, "integer overflow"},
+		"sum overflow":       {`Big + Big`, 5, "integer overflow"},
+		"negation overflow":  {`-Min`, 1, "integer overflow"},
+	}
+
+	record := Record{"Age": 5, "Name": "web", "Big": 1 << 62, "Min": math.MinInt64}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := ParseExpression(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseExpression() error = %v", err)
+			}
+			_, err = expr.Eval(record)
+			var exprErr *ExpressionError
+			if !errors.As(err, &exprErr) {
+				t.Fatalf("Eval() error = %v, want *ExpressionError", err)
+			}
+			if exprErr.Column != tt.wantColumn || !strings.Contains(exprErr.Message, tt.wantMsg) {
+				t.Errorf("Eval() error = %v, want column %d containing %q", err, tt.wantColumn, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestExpressionFields(t *testing.T) {
+	expr, err := ParseExpression("b > 1 && lower(a) in (c, \"x\") || `a` == b")
+	if err != nil {
+		t.Fatalf("ParseExpression() error = %v", err)
+	}
+	if got, want := expr.Fields(), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("Fields() = %v, want %v", got, want)
+	}
+
+	err = expr.ValidateFields(NewSchemaFromKeys([]string{"a", "b"}))
+	var exprErr *ExpressionError
+	if !errors.As(err, &exprErr) || exprErr.Column != 23 || !strings.Contains(exprErr.Message, `unknown column "c"`) {
+		t.Errorf("ValidateFields() error = %v, want unknown column c at column 23", err)
+	}
+}
+
+func expressionTestTable(t *testing.T, opts ...TableOption) *TableContent {
+	t.Helper()
+	opts = append([]TableOption{WithKeys("Name", "Status", "Price", "Qty")}, opts...)
+	table, err := NewTableContent("Orders", []Record{
+		{"Name": "a", "Status": "failed", "Price": 2.0, "Qty": 3},
+		{"Name": "b", "Status": "ok", "Price": 10.0, "Qty": 1},
+		{"Name": "c", "Status": "failed", "Price": 1.5, "Qty": 10},
+	}, opts...)
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	return table
+}
+
+func TestExpressionOperations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("filter", func(t *testing.T) {
+		op, err := NewFilterOpFromExpression(`Status == "failed" && Qty > 3`)
+		if err != nil {
+			t.Fatalf("NewFilterOpFromExpression() error = %v", err)
+		}
+		result, err := op.Apply(ctx, expressionTestTable(t))
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if got := joinedColumn(result.(*TableContent).Records(), "Name"); !slices.Equal(got, []string{"c"}) {
+			t.Errorf("Name = %v, want [c]", got)
+		}
+	})
+
+	t.Run("add column", func(t *testing.T) {
+		op, err := NewAddColumnOpFromExpression("`Order Total` = Price * Qty", nil)
+		if err != nil {
+			t.Fatalf("NewAddColumnOpFromExpression() error = %v", err)
+		}
+		result, err := op.Apply(ctx, expressionTestTable(t))
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if got := joinedColumn(result.(*TableContent).Records(), "Order Total"); !slices.Equal(got, []string{"6", "10", "15"}) {
+			t.Errorf("Order Total = %v", got)
+		}
+	})
+
+	t.Run("sort by columns", func(t *testing.T) {
+		op, err := NewSortOpFromExpression("Status desc, Name")
+		if err != nil {
+			t.Fatalf("NewSortOpFromExpression() error = %v", err)
+		}
+		if len(op.keys) != 2 || op.keys[0] != (SortKey{Column: "Status", Direction: Descending}) {
+			t.Errorf("keys = %v, want plain column keys", op.keys)
+		}
+		result, err := op.Apply(ctx, expressionTestTable(t))
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if got := joinedColumn(result.(*TableContent).Records(), "Name"); !slices.Equal(got, []string{"b", "a", "c"}) {
+			t.Errorf("Name = %v, want [b a c]", got)
+		}
+	})
+
+	t.Run("sort by computed key", func(t *testing.T) {
+		op, err := NewSortOpFromExpression("Price * Qty desc")
+		if err != nil {
+			t.Fatalf("NewSortOpFromExpression() error = %v", err)
+		}
+		result, err := op.Apply(ctx, expressionTestTable(t))
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if got := joinedColumn(result.(*TableContent).Records(), "Name"); !slices.Equal(got, []string{"c", "b", "a"}) {
+			t.Errorf("Name = %v, want [c b a]", got)
+		}
+	})
+
+	t.Run("definition errors", func(t *testing.T) {
+		tests := map[string]struct {
+			build      func() error
+			wantColumn int
+		}{
+			"add column without name": {
+				build:      func() error { _, err := NewAddColumnOpFromExpression("= Price", nil); return err },
+				wantColumn: 1,
+			},
+			"add column without equals": {
+				build:      func() error { _, err := NewAddColumnOpFromExpression("Total Price", nil); return err },
+				wantColumn: 7,
+			},
+			"sort with bad separator": {
+				build:      func() error { _, err := NewSortOpFromExpression("Name desc Price"); return err },
+				wantColumn: 11,
+			},
+		}
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				var exprErr *ExpressionError
+				if err := tt.build(); !errors.As(err, &exprErr) || exprErr.Column != tt.wantColumn {
+					t.Errorf("error = %v, want *ExpressionError at column %d", err, tt.wantColumn)
+				}
+			})
+		}
+	})
+}
+
+func TestExpressionOperations_SchemaValidation(t *testing.T) {
+	filter, err := NewFilterOpFromExpression(`Stat == "failed"`)
+	if err != nil {
+		t.Fatalf("NewFilterOpFromExpression() error = %v", err)
+	}
+
+	t.Run("Validate without a bound schema", func(t *testing.T) {
+		if err := filter.Validate(); err != nil {
+			t.Errorf("Validate() error = %v, want nil before a schema is known", err)
+		}
+	})
+
+	t.Run("Validate with the content schema", func(t *testing.T) {
+		err := filter.bindSchema(expressionTestTable(t).Schema()).Validate()
+		var validationErr *ValidationError
+		var exprErr *ExpressionError
+		if !errors.As(err, &validationErr) || !errors.As(err, &exprErr) || exprErr.Column != 1 {
+			t.Errorf("Validate() error = %v, want validation error wrapping column 1", err)
+		}
+	})
+
+	t.Run("pipeline reports unknown columns", func(t *testing.T) {
+		doc := New().Table("Orders", expressionTestTable(t).Records(),
+			WithKeys("Name", "Status", "Price", "Qty"),
+			WithTransformations(filter)).Build()
+		_, err := CSV().Renderer.Render(context.Background(), doc)
+		if err == nil || !strings.Contains(err.Error(), "invalid") || !strings.Contains(err.Error(), `unknown column "Stat"`) {
+			t.Errorf("Render() error = %v, want validation error for unknown column", err)
+		}
+	})
+
+	t.Run("later operations see added columns", func(t *testing.T) {
+		add, err := NewAddColumnOpFromExpression("Total = Price * Qty", nil)
+		if err != nil {
+			t.Fatalf("NewAddColumnOpFromExpression() error = %v", err)
+		}
+		onTotal, err := NewFilterOpFromExpression("Total >= 10")
+		if err != nil {
+			t.Fatalf("NewFilterOpFromExpression() error = %v", err)
+		}
+		doc := New().Table("Orders", expressionTestTable(t).Records(),
+			WithKeys("Name", "Status", "Price", "Qty"),
+			WithTransformations(add, onTotal)).Build()
+		out, err := CSV().Renderer.Render(context.Background(), doc)
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if want := "Name,Status,Price,Qty,Total\nb,ok,10,1,10\nc,failed,1.5,10,15\n"; string(out) != want {
+			t.Errorf("Render() = %q, want %q", out, want)
+		}
+	})
+
+	t.Run("evaluation errors fail the render", func(t *testing.T) {
+		bad, err := NewFilterOpFromExpression("Name > 1")
+		if err != nil {
+			t.Fatalf("NewFilterOpFromExpression() error = %v", err)
+		}
+		doc := New().Table("Orders", expressionTestTable(t).Records(),
+			WithKeys("Name", "Status", "Price", "Qty"),
+			WithTransformations(bad)).Build()
+		if _, err := CSV().Renderer.Render(context.Background(), doc); err == nil || !strings.Contains(err.Error(), "cannot compare") {
+			t.Errorf("Render() error = %v, want evaluation error", err)
+		}
+	})
+}
@@ -31,6 +31,7 @@ type FileWriter struct {
 	appendMode           bool              // Enable append mode instead of replace
 	permissions          os.FileMode       // File permissions (default 0644)
 	disallowUnsafeAppend bool              // Prevent appending to JSON/YAML
+	csvDialect           csvDialect        // Dialect of appended CSV data
 }
 
 // NewFileWriter creates a new FileWriter with the specified directory and pattern
@@ -239,6 +240,8 @@ func defaultExtensions() map[string]string {
 		FormatDOT:      FormatDOT,
 		FormatMermaid:  "mmd",
 		FormatDrawIO:   FormatCSV, // Draw.io CSV format
+		FormatXLSX:     FormatXLSX,
+		FormatSVG:      FormatSVG,
 	}
 }
 
@@ -266,6 +269,10 @@ func (fw *FileWriter) appendToFile(ctx context.Context, format string, fullPath
 		return fw.appendHTMLWithMarker(ctx, fullPath, data)
 	case FormatCSV:
 		return fw.appendCSVWithoutHeaders(ctx, fullPath, data)
+	case FormatXLSX, FormatSVG:
+		// An xlsx file is a zip archive and an SVG image has a single root
+		// element; appended bytes would corrupt either
+		return fw.wrapError(format, fmt.Errorf("append to %s files is not supported", format))
 	default:
 		return fw.appendByteLevel(ctx, fullPath, data)
 	}
@@ -402,7 +409,8 @@ func (fw *FileWriter) appendHTMLWithMarker(ctx context.Context, fullPath string,
 	return nil
 }
 
-// appendCSVWithoutHeaders appends CSV data to an existing file, stripping the header line
+// appendCSVWithoutHeaders appends CSV data to an existing file, stripping the header line.
+// The header, byte order mark and line endings are handled per the WithCSVDialect dialect.
 func (fw *FileWriter) appendCSVWithoutHeaders(ctx context.Context, fullPath string, data []byte) error {
 	// Check context cancellation
 	select {
@@ -411,18 +419,13 @@ func (fw *FileWriter) appendCSVWithoutHeaders(ctx context.Context, fullPath stri
 	default:
 	}
 
-	// Normalize line endings (handle both LF and CRLF)
-	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
-
-	// Strip first line (header) from data
-	lines := bytes.SplitN(data, []byte("\n"), 2)
-	if len(lines) < 2 {
-		// Only one line (or empty) - nothing to append after removing header
+	// Strip the header line (and byte order mark) from data
+	dataWithoutHeader := fw.csvDialect.appendRows(data)
+	if len(dataWithoutHeader) == 0 {
+		// Nothing to append after removing the header
 		return nil
 	}
 
-	dataWithoutHeader := lines[1]
-
 	// Ensure the existing file ends with a newline before appending the
 	// stripped data rows. Without this, a file that lacks a trailing newline
 	// (e.g. "a,b\n1,2") would have its last row merged with the first appended
@@ -431,7 +434,7 @@ func (fw *FileWriter) appendCSVWithoutHeaders(ctx context.Context, fullPath stri
 	if needsSeparator, err := fw.fileNeedsRowSeparator(fullPath); err != nil {
 		return fw.wrapError(FormatCSV, err)
 	} else if needsSeparator {
-		dataWithoutHeader = append([]byte("\n"), dataWithoutHeader...)
+		dataWithoutHeader = append([]byte(fw.csvDialect.lineEnding()), dataWithoutHeader...)
 	}
 
 	return fw.appendByteLevel(ctx, fullPath, dataWithoutHeader)
@@ -591,6 +594,28 @@ func WithDisallowUnsafeAppend() FileWriterOption {
 	}
 }
 
+// WithCSVDialect sets the CSV dialect of data written in append mode, so
+// appending matches output rendered with CSVWithOptions and the same options.
+// The byte order mark and header line are stripped from appended data, or
+// only the byte order mark with WithCSVNoHeader, and CRLF line endings are
+// kept with WithCSVCRLF. By default appended data is treated as CSV().
+//
+// Example:
+//
+//	dialect := []output.CSVOption{output.WithCSVDelimiter(';'), output.WithCSVBOM()}
+//	fw, err := output.NewFileWriterWithOptions(
+//	    "./reports",
+//	    "costs.{ext}",
+//	    output.WithAppendMode(),
+//	    output.WithCSVDialect(dialect...),
+//	)
+//	out := output.NewOutput(output.WithFormat(output.CSVWithOptions(dialect...)), output.WithWriter(fw))
+func WithCSVDialect(opts ...CSVOption) FileWriterOption {
+	return func(fw *FileWriter) {
+		fw.csvDialect = newCSVDialect(opts...)
+	}
+}
+
 // NewFileWriterWithOptions creates a FileWriter with options.
 // Nil options are ignored.
 func NewFileWriterWithOptions(dir, pattern string, opts ...FileWriterOption) (*FileWriter, error) {
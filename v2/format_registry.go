@@ -0,0 +1,237 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownFormat is returned (wrapped) by LookupFormat and ParseFormats for
+// a name that is neither a registered format nor an alias.
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// formatRegistry maps user-facing names, such as the value of a CLI
+// --output flag, to Format constructors. Names and aliases are stored in
+// lower case and matched case-insensitively.
+type formatRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]func() Format
+	aliases   map[string]string // alias -> registered name
+}
+
+// registeredFormats is the process-wide registry, pre-populated with the built-in
+// formats. Constructors are stored rather than Format values so every lookup
+// returns a fresh renderer (see the note on the built-in constructors).
+var registeredFormats = newBuiltinFormatRegistry()
+
+func newBuiltinFormatRegistry() *formatRegistry {
+	return &formatRegistry{
+		factories: map[string]func() Format{
+			FormatJSON:             JSON,
+			FormatYAML:             YAML,
+			FormatCSV:              CSV,
+			FormatHTML:             HTML,
+			"html-fragment":        HTMLFragment,
+			"html-interactive":     HTMLInteractive,
+			"html-offline":         HTMLOffline,
+			FormatMarkdown:         Markdown,
+			FormatTable:            Table,
+			"table-default":        TableDefault,
+			"table-bold":           TableBold,
+			"table-colored-bright": TableColoredBright,
+			"table-light":          TableLight,
+			"table-rounded":        TableRounded,
+			FormatDOT:              DOT,
+			FormatMermaid:          Mermaid,
+			FormatDrawIO:           DrawIO,
+			FormatXLSX:             XLSX,
+			FormatSVG:              SVG,
+		},
+		aliases: map[string]string{
+			extYML:     FormatYAML,
+			"md":       FormatMarkdown,
+			"graphviz": FormatDOT,
+			"excel":    FormatXLSX,
+		},
+	}
+}
+
+// normalizeFormatName trims and lower-cases a format name or alias.
+func normalizeFormatName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// RegisterFormat registers a Format constructor under name, replacing any
+// existing registration with that name (built-ins included). The factory is
+// called on every lookup, so it should return a fresh Format each time.
+//
+// Names are case-insensitive. It returns an error for an empty name, a nil
+// factory, a name containing a comma (which ParseFormats uses as separator),
+// or a name already used as an alias.
+func RegisterFormat(name string, factory func() Format) error {
+	key := normalizeFormatName(name)
+	if err := validateFormatName(key); err != nil {
+		return err
+	}
+	if factory == nil {
+		return fmt.Errorf("format %q: factory cannot be nil", key)
+	}
+
+	registeredFormats.mu.Lock()
+	defer registeredFormats.mu.Unlock()
+
+	if target, isAlias := registeredFormats.aliases[key]; isAlias {
+		return fmt.Errorf("format %q is already an alias for %q", key, target)
+	}
+	registeredFormats.factories[key] = factory
+	return nil
+}
+
+// RegisterFormatAlias makes alias resolve to the registered format name, for
+// example "yml" for "yaml". Re-registering an alias points it at the new
+// name. It returns an error when name is not registered or alias is itself a
+// registered format name.
+func RegisterFormatAlias(alias, name string) error {
+	aliasKey := normalizeFormatName(alias)
+	nameKey := normalizeFormatName(name)
+	if err := validateFormatName(aliasKey); err != nil {
+		return err
+	}
+
+	registeredFormats.mu.Lock()
+	defer registeredFormats.mu.Unlock()
+
+	if _, ok := registeredFormats.factories[nameKey]; !ok {
+		return fmt.Errorf("alias %q: %w %q", aliasKey, ErrUnknownFormat, nameKey)
+	}
+	if _, ok := registeredFormats.factories[aliasKey]; ok {
+		return fmt.Errorf("alias %q is already a registered format name", aliasKey)
+	}
+	registeredFormats.aliases[aliasKey] = nameKey
+	return nil
+}
+
+// validateFormatName rejects names that could not be looked up or parsed.
+func validateFormatName(key string) error {
+	if key == "" {
+		return fmt.Errorf("format name cannot be empty")
+	}
+	if strings.Contains(key, ",") {
+		return fmt.Errorf("format name %q cannot contain a comma", key)
+	}
+	return nil
+}
+
+// LookupFormat returns a new Format for a registered name or alias. Lookup is
+// case-insensitive and ignores surrounding whitespace. The error for an
+// unknown name wraps ErrUnknownFormat and lists the valid names.
+func LookupFormat(name string) (Format, error) {
+	factory, _, ok := resolveFormat(name)
+	if !ok {
+		return Format{}, fmt.Errorf("%w %q; valid formats: %s", ErrUnknownFormat, name, validFormatsList())
+	}
+	return factory(), nil
+}
+
+// resolveFormat resolves a name or alias to its factory and registered name.
+func resolveFormat(name string) (func() Format, string, bool) {
+	key := normalizeFormatName(name)
+
+	registeredFormats.mu.RLock()
+	defer registeredFormats.mu.RUnlock()
+
+	if target, ok := registeredFormats.aliases[key]; ok {
+		key = target
+	}
+	factory, ok := registeredFormats.factories[key]
+	return factory, key, ok
+}
+
+// FormatNames returns the registered format names in sorted order. Aliases
+// are not included; see FormatAliases.
+func FormatNames() []string {
+	registeredFormats.mu.RLock()
+	defer registeredFormats.mu.RUnlock()
+	return slices.Sorted(maps.Keys(registeredFormats.factories))
+}
+
+// FormatAliases returns a copy of the alias to format name mapping.
+func FormatAliases() map[string]string {
+	registeredFormats.mu.RLock()
+	defer registeredFormats.mu.RUnlock()
+	return maps.Clone(registeredFormats.aliases)
+}
+
+// ParseFormats parses a comma-separated list of format names or aliases, as
+// passed to a CLI flag such as --output json,table, into Formats ready for
+// WithFormats. Whitespace around names is ignored and a format named twice
+// (directly or through an alias) is returned once, at its first position.
+// Every unknown name is reported in a single error that lists the valid
+// names.
+func ParseFormats(spec string) ([]Format, error) {
+	var (
+		result  []Format
+		seen    = make(map[string]bool)
+		unknown []string
+	)
+
+	for part := range strings.SplitSeq(spec, ",") {
+		if normalizeFormatName(part) == "" {
+			continue
+		}
+
+		factory, key, ok := resolveFormat(part)
+		if !ok {
+			unknown = append(unknown, strings.TrimSpace(part))
+			continue
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, factory())
+	}
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%w %s; valid formats: %s", ErrUnknownFormat, quoteNames(unknown), validFormatsList())
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no output format specified; valid formats: %s", validFormatsList())
+	}
+	return result, nil
+}
+
+// quoteNames formats names as a comma-separated list of quoted strings.
+func quoteNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// validFormatsList renders the sorted format names for error messages, with
+// each format's aliases in parentheses: "json, markdown (md), yaml (yml)".
+func validFormatsList() string {
+	registeredFormats.mu.RLock()
+	defer registeredFormats.mu.RUnlock()
+
+	aliasesByName := make(map[string][]string)
+	for alias, name := range registeredFormats.aliases {
+		aliasesByName[name] = append(aliasesByName[name], alias)
+	}
+
+	names := slices.Sorted(maps.Keys(registeredFormats.factories))
+	entries := make([]string, len(names))
+	for i, name := range names {
+		entries[i] = name
+		if aliases := aliasesByName[name]; len(aliases) > 0 {
+			slices.Sort(aliases)
+			entries[i] += " (" + strings.Join(aliases, ", ") + ")"
+		}
+	}
+	return strings.Join(entries, ", ")
+}
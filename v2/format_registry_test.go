@@ -0,0 +1,195 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// restoreFormatRegistry replaces the process-wide registry with a fresh
+// built-in one when the test finishes, so registrations do not leak.
+func restoreFormatRegistry(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		fresh := newBuiltinFormatRegistry()
+		registeredFormats.mu.Lock()
+		registeredFormats.factories = fresh.factories
+		registeredFormats.aliases = fresh.aliases
+		registeredFormats.mu.Unlock()
+	})
+}
+
+// registryTestRenderer is a minimal custom Renderer.
+type registryTestRenderer struct{}
+
+func (registryTestRenderer) Format() string { return "custom" }
+func (registryTestRenderer) Render(context.Context, *Document) ([]byte, error) {
+	return []byte("custom"), nil
+}
+func (registryTestRenderer) RenderTo(_ context.Context, _ *Document, w io.Writer) error {
+	_, err := io.WriteString(w, "custom")
+	return err
+}
+func (registryTestRenderer) SupportsStreaming() bool { return false }
+
+func TestLookupFormat(t *testing.T) {
+	tests := map[string]struct {
+		name     string
+		wantName string
+		wantErr  bool
+	}{
+		"built-in":                 {name: "json", wantName: FormatJSON},
+		"case insensitive":         {name: " Markdown ", wantName: FormatMarkdown},
+		"alias":                    {name: "yml", wantName: FormatYAML},
+		"styled table":             {name: "table-rounded", wantName: FormatTable},
+		"html fragment":            {name: "html-fragment", wantName: FormatHTML},
+		"html offline":             {name: "html-offline", wantName: FormatHTML},
+		"unknown":                  {name: "jsonn", wantErr: true},
+		"empty name":               {name: "", wantErr: true},
+		"comma list is not a name": {name: "yaml,json", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			format, err := LookupFormat(tt.name)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownFormat) {
+					t.Fatalf("LookupFormat(%q) error = %v, want ErrUnknownFormat", tt.name, err)
+				}
+				if !strings.Contains(err.Error(), "valid formats: csv,") {
+					t.Errorf("error %q does not list the valid formats", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LookupFormat(%q) error = %v", tt.name, err)
+			}
+			if format.Name != tt.wantName || format.Renderer == nil {
+				t.Errorf("LookupFormat(%q) = %+v, want name %q with a renderer", tt.name, format, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestLookupFormat_ReturnsFreshRenderers(t *testing.T) {
+	first, err := LookupFormat("table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := LookupFormat("table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Renderer == second.Renderer {
+		t.Error("LookupFormat returned a shared renderer instance")
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	restoreFormatRegistry(t)
+
+	custom := func() Format { return Format{Name: "custom", Renderer: registryTestRenderer{}} }
+	if err := RegisterFormat("Custom", custom); err != nil {
+		t.Fatalf("RegisterFormat() error = %v", err)
+	}
+	if err := RegisterFormatAlias("c", "custom"); err != nil {
+		t.Fatalf("RegisterFormatAlias() error = %v", err)
+	}
+
+	format, err := LookupFormat("c")
+	if err != nil || format.Name != "custom" {
+		t.Fatalf("LookupFormat(c) = %+v, %v", format, err)
+	}
+	if !slices.Contains(FormatNames(), "custom") {
+		t.Errorf("FormatNames() = %v, want custom included", FormatNames())
+	}
+	if FormatAliases()["c"] != "custom" {
+		t.Errorf("FormatAliases() = %v, want c -> custom", FormatAliases())
+	}
+
+	errorCases := map[string]error{
+		"empty name":         RegisterFormat(" ", custom),
+		"nil factory":        RegisterFormat("other", nil),
+		"comma in name":      RegisterFormat("a,b", custom),
+		"name is an alias":   RegisterFormat("yml", custom),
+		"alias of unknown":   RegisterFormatAlias("x", "missing"),
+		"alias is a format":  RegisterFormatAlias("json", "yaml"),
+		"empty alias string": RegisterFormatAlias("", "json"),
+	}
+	for name, err := range errorCases {
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestRegisterFormat_ReplacesBuiltin(t *testing.T) {
+	restoreFormatRegistry(t)
+
+	if err := RegisterFormat("table", TableRounded); err != nil {
+		t.Fatalf("RegisterFormat() error = %v", err)
+	}
+	format, err := LookupFormat("table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := format.Renderer.(*tableRenderer).StyleName(); got != "Rounded" {
+		t.Errorf("style = %q, want Rounded", got)
+	}
+}
+
+func TestParseFormats(t *testing.T) {
+	tests := map[string]struct {
+		spec      string
+		wantNames []string
+		wantErr   []string
+	}{
+		"single":            {spec: "json", wantNames: []string{FormatJSON}},
+		"list with spaces":  {spec: "json, table ,md", wantNames: []string{FormatJSON, FormatTable, FormatMarkdown}},
+		"duplicates folded": {spec: "yaml,yml,YAML", wantNames: []string{FormatYAML}},
+		"empty entries":     {spec: "csv,,", wantNames: []string{FormatCSV}},
+		"unknown names":     {spec: "json,xml,pdf", wantErr: []string{`"xml", "pdf"`, "valid formats:", "yaml (yml)"}},
+		"empty spec":        {spec: " ", wantErr: []string{"no output format specified"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseFormats(tt.spec)
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatalf("ParseFormats(%q) expected error", tt.spec)
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("error %q does not contain %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFormats(%q) error = %v", tt.spec, err)
+			}
+			var names []string
+			for _, format := range got {
+				names = append(names, format.Name)
+			}
+			if !slices.Equal(names, tt.wantNames) {
+				t.Errorf("ParseFormats(%q) names = %v, want %v", tt.spec, names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestParseFormats_UsableWithOutput(t *testing.T) {
+	parsed, err := ParseFormats("json,csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := NewOutput(WithFormats(parsed...), WithWriter(NewStdoutWriter()))
+	if got := len(out.GetFormats()); got != 2 {
+		t.Errorf("len(GetFormats()) = %d, want 2", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"maps"
 	"slices"
 	"strings"
+	"time"
 )
 
 // GraphContent represents graph/diagram data
@@ -186,7 +187,7 @@ func (g *GraphContent) GetTransformations() []Operation {
 type ChartContent struct {
 	id        string
 	title     string
-	chartType string // "gantt", "pie", "flowchart"
+	chartType string // "gantt", "pie", "bar", "line", "scatter", "flowchart"
 	data      any    // Chart-specific data structure
 }
 
@@ -194,6 +195,9 @@ type ChartContent struct {
 const (
 	ChartTypeGantt     = "gantt"
 	ChartTypePie       = "pie"
+	ChartTypeBar       = "bar"
+	ChartTypeLine      = "line"
+	ChartTypeScatter   = "scatter"
 	ChartTypeFlowchart = "flowchart"
 )
 
@@ -215,6 +219,25 @@ type PieSlice struct {
 	Value float64
 }
 
+// ChartSeries represents a named series of values in a bar or line chart,
+// with one value per category
+type ChartSeries struct {
+	Name   string
+	Values []float64
+}
+
+// XYPoint is a point of a scatter chart
+type XYPoint struct {
+	X float64
+	Y float64
+}
+
+// ScatterSeries represents a named set of points in a scatter chart
+type ScatterSeries struct {
+	Name   string
+	Points []XYPoint
+}
+
 // cloneGanttTasks returns a deep copy of a Gantt task slice. Each task's
 // Dependencies slice is also copied so callers cannot mutate stored content.
 func cloneGanttTasks(tasks []GanttTask) []GanttTask {
@@ -238,10 +261,39 @@ func clonePieSlices(pieSlices []PieSlice) []PieSlice {
 	return slices.Clone(pieSlices)
 }
 
+// cloneChartSeries returns a deep copy of a series slice, including each
+// series' values
+func cloneChartSeries(series []ChartSeries) []ChartSeries {
+	if series == nil {
+		return nil
+	}
+	out := make([]ChartSeries, len(series))
+	for i, s := range series {
+		s.Values = slices.Clone(s.Values)
+		out[i] = s
+	}
+	return out
+}
+
+// cloneScatterSeries returns a deep copy of a scatter series slice,
+// including each series' points
+func cloneScatterSeries(series []ScatterSeries) []ScatterSeries {
+	if series == nil {
+		return nil
+	}
+	out := make([]ScatterSeries, len(series))
+	for i, s := range series {
+		s.Points = slices.Clone(s.Points)
+		out[i] = s
+	}
+	return out
+}
+
 // cloneChartData returns a deep copy of chart-specific data so callers cannot
-// mutate the content's internal state. Known chart types (*GanttData and
-// *PieData) are copied along with their slices; any other payload is returned
-// unchanged since its type is not known to this package.
+// mutate the content's internal state. Known chart types (*GanttData,
+// *PieData, *BarData, *LineData and *ScatterData) are copied along with their
+// slices; any other payload is returned unchanged since its type is not known to this
+// package.
 func cloneChartData(data any) any {
 	switch d := data.(type) {
 	case *GanttData:
@@ -258,6 +310,30 @@ func cloneChartData(data any) any {
 		clone := *d
 		clone.Slices = clonePieSlices(d.Slices)
 		return &clone
+	case *BarData:
+		if d == nil {
+			return d
+		}
+		clone := *d
+		clone.Categories = slices.Clone(d.Categories)
+		clone.Series = cloneChartSeries(d.Series)
+		return &clone
+	case *LineData:
+		if d == nil {
+			return d
+		}
+		clone := *d
+		clone.Categories = slices.Clone(d.Categories)
+		clone.Series = cloneChartSeries(d.Series)
+		clone.Times = slices.Clone(d.Times)
+		return &clone
+	case *ScatterData:
+		if d == nil {
+			return d
+		}
+		clone := *d
+		clone.Series = cloneScatterSeries(d.Series)
+		return &clone
 	default:
 		return data
 	}
@@ -276,6 +352,31 @@ type PieData struct {
 	Slices   []PieSlice
 }
 
+// BarData represents data for a bar chart: each category has one bar per
+// series, or a single bar of all series stacked on top of each other when
+// Stacked is set
+type BarData struct {
+	Categories []string
+	Series     []ChartSeries
+	Stacked    bool
+}
+
+// LineData represents data for a line chart: each series is a line through
+// one point per category
+type LineData struct {
+	Categories []string
+	Series     []ChartSeries
+	// Times optionally holds the time of each category, making the chart a
+	// line over time. SVG output spaces the points by time rather than
+	// evenly when there is one time per category.
+	Times []time.Time
+}
+
+// ScatterData represents data for an XY scatter chart
+type ScatterData struct {
+	Series []ScatterSeries
+}
+
 // NewChartContent creates a new chart content
 func NewChartContent(title, chartType string, data any) *ChartContent {
 	return &ChartContent{
@@ -305,6 +406,63 @@ func NewPieChart(title string, slices []PieSlice, showData bool) *ChartContent {
 	return NewChartContent(title, ChartTypePie, data)
 }
 
+// NewBarChart creates a new bar chart content
+func NewBarChart(title string, categories []string, series []ChartSeries) *ChartContent {
+	data := &BarData{
+		Categories: slices.Clone(categories),
+		Series:     cloneChartSeries(series),
+	}
+	return NewChartContent(title, ChartTypeBar, data)
+}
+
+// NewStackedBarChart creates a new bar chart content with the series of
+// each category stacked into a single bar
+func NewStackedBarChart(title string, categories []string, series []ChartSeries) *ChartContent {
+	chart := NewBarChart(title, categories, series)
+	chart.data.(*BarData).Stacked = true
+	return chart
+}
+
+// NewLineChart creates a new line chart content
+func NewLineChart(title string, categories []string, series []ChartSeries) *ChartContent {
+	data := &LineData{
+		Categories: slices.Clone(categories),
+		Series:     cloneChartSeries(series),
+	}
+	return NewChartContent(title, ChartTypeLine, data)
+}
+
+// NewTimeSeriesChart creates a new line chart content over time, with one
+// value of each series per time. The categories are the times formatted as
+// dates, or as date and time when any of them is not at midnight.
+func NewTimeSeriesChart(title string, times []time.Time, series []ChartSeries) *ChartContent {
+	layout := time.DateOnly
+	for _, t := range times {
+		if hour, minute, sec := t.Clock(); hour+minute+sec+t.Nanosecond() != 0 {
+			layout = "2006-01-02 15:04"
+			break
+		}
+	}
+	categories := make([]string, len(times))
+	for i, t := range times {
+		categories[i] = t.Format(layout)
+	}
+	data := &LineData{
+		Categories: categories,
+		Series:     cloneChartSeries(series),
+		Times:      slices.Clone(times),
+	}
+	return NewChartContent(title, ChartTypeLine, data)
+}
+
+// NewScatterChart creates a new XY scatter chart content
+func NewScatterChart(title string, series []ScatterSeries) *ChartContent {
+	data := &ScatterData{
+		Series: cloneScatterSeries(series),
+	}
+	return NewChartContent(title, ChartTypeScatter, data)
+}
+
 // Type returns the content type
 func (c *ChartContent) Type() ContentType {
 	return ContentTypeRaw // Charts are format-specific
@@ -326,8 +484,8 @@ func (c *ChartContent) GetTitle() string {
 }
 
 // GetData returns a copy of the chart data so callers cannot mutate the
-// content's internal state. For known chart types (*GanttData, *PieData) the
-// returned value is an independent deep copy.
+// content's internal state. For known chart types (*GanttData, *PieData,
+// *BarData, *LineData, *ScatterData) the returned value is an independent deep copy.
 func (c *ChartContent) GetData() any {
 	return cloneChartData(c.data)
 }
@@ -367,11 +525,55 @@ func (c *ChartContent) AppendText(b []byte) ([]byte, error) {
 				b = append(b, '\n')
 			}
 		}
+	case ChartTypeBar:
+		if barData, ok := c.data.(*BarData); ok {
+			b = appendSeriesText(b, barData.Categories, barData.Series)
+		}
+	case ChartTypeLine:
+		if lineData, ok := c.data.(*LineData); ok {
+			b = appendSeriesText(b, lineData.Categories, lineData.Series)
+		}
+	case ChartTypeScatter:
+		if scatterData, ok := c.data.(*ScatterData); ok {
+			for _, s := range scatterData.Series {
+				b = append(b, s.Name...)
+				b = append(b, ':')
+				for i, p := range s.Points {
+					if i > 0 {
+						b = append(b, ',')
+					}
+					b = append(b, fmt.Sprintf(" (%.2f, %.2f)", p.X, p.Y)...)
+				}
+				b = append(b, '\n')
+			}
+		}
 	}
 
 	return b, nil
 }
 
+// appendSeriesText appends one line per series listing its value for each
+// category
+func appendSeriesText(b []byte, categories []string, series []ChartSeries) []byte {
+	for _, s := range series {
+		b = append(b, s.Name...)
+		b = append(b, ':')
+		for i, value := range s.Values {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, ' ')
+			if i < len(categories) {
+				b = append(b, categories[i]...)
+				b = append(b, '=')
+			}
+			b = append(b, fmt.Sprintf("%.2f", value)...)
+		}
+		b = append(b, '\n')
+	}
+	return b
+}
+
 // AppendBinary implements encoding.BinaryAppender
 func (c *ChartContent) AppendBinary(b []byte) ([]byte, error) {
 	// For now, just use text representation
@@ -3,6 +3,7 @@ package output
 import (
 	"slices"
 	"testing"
+	"time"
 )
 
 // Regression tests for T-1295: Graph and Draw.io content expose mutable
@@ -288,6 +289,77 @@ func TestChartContent_CloneIndependentPie(t *testing.T) {
 	}
 }
 
+// TestChartContent_CloneIndependentSeries verifies that bar and line chart
+// data is copied on construction, through GetData and by Clone.
+func TestChartContent_CloneIndependentSeries(t *testing.T) {
+	tests := map[string]func([]string, []ChartSeries) *ChartContent{
+		"bar": func(categories []string, series []ChartSeries) *ChartContent {
+			return NewBarChart("sales", categories, series)
+		},
+		"line": func(categories []string, series []ChartSeries) *ChartContent {
+			return NewLineChart("sales", categories, series)
+		},
+	}
+	for name, newChart := range tests {
+		t.Run(name, func(t *testing.T) {
+			categories := []string{"Q1"}
+			series := []ChartSeries{{Name: "2024", Values: []float64{1}}}
+			c := newChart(categories, series)
+
+			categories[0] = "mutated"
+			series[0].Values[0] = 99
+			clone := c.Clone().(*ChartContent)
+			for _, data := range []any{c.GetData(), clone.GetData()} {
+				switch d := data.(type) {
+				case *BarData:
+					d.Categories[0] = "mutated"
+					d.Series[0].Values[0] = 99
+				case *LineData:
+					d.Categories[0] = "mutated"
+					d.Series[0].Values[0] = 99
+				default:
+					t.Fatalf("GetData() returned %T", data)
+				}
+			}
+
+			var got []string
+			var values []float64
+			switch d := c.GetData().(type) {
+			case *BarData:
+				got, values = d.Categories, d.Series[0].Values
+			case *LineData:
+				got, values = d.Categories, d.Series[0].Values
+			}
+			if got[0] != "Q1" || values[0] != 1 {
+				t.Errorf("chart data was mutated: categories %v, values %v", got, values)
+			}
+		})
+	}
+}
+
+// TestChartContent_CloneIndependentScatter verifies that scatter chart data
+// and the times of a line chart over time are copied on construction,
+// through GetData and by Clone.
+func TestChartContent_CloneIndependentScatter(t *testing.T) {
+	series := []ScatterSeries{{Name: "a", Points: []XYPoint{{X: 1, Y: 2}}}}
+	scatter := NewScatterChart("points", series)
+	series[0].Points[0].X = 99
+	scatter.Clone().(*ChartContent).GetData().(*ScatterData).Series[0].Points[0].Y = 99
+	scatter.GetData().(*ScatterData).Series[0].Name = "mutated"
+	if got := scatter.GetData().(*ScatterData).Series[0]; got.Name != "a" || got.Points[0] != (XYPoint{X: 1, Y: 2}) {
+		t.Errorf("scatter data was mutated: %+v", got)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	times := []time.Time{start}
+	line := NewTimeSeriesChart("requests", times, []ChartSeries{{Values: []float64{1}}})
+	times[0] = start.AddDate(1, 0, 0)
+	line.Clone().(*ChartContent).GetData().(*LineData).Times[0] = times[0]
+	if got := line.GetData().(*LineData).Times[0]; !got.Equal(start) {
+		t.Errorf("Times[0] = %v, want %v", got, start)
+	}
+}
+
 // TestGraphContent_GetNodesStableOrder is a regression test for T-1339: GetNodes
 // built a set as a map and ranged it to produce the result, so node order was
 // derived from Go's randomized map iteration. GetNodes must instead return nodes
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -225,6 +227,8 @@ func (m *mermaidRenderer) Render(ctx context.Context, doc *Document) ([]byte, er
 				specializedCharts = append(specializedCharts, c)
 			case ChartTypePie:
 				specializedCharts = append(specializedCharts, c)
+			case ChartTypeBar, ChartTypeLine, ChartTypeScatter:
+				specializedCharts = append(specializedCharts, c)
 			case ChartTypeFlowchart:
 				hasFlowchart = true
 			}
@@ -253,6 +257,13 @@ func (m *mermaidRenderer) Render(ctx context.Context, doc *Document) ([]byte, er
 			m.renderGanttChart(&buf, c)
 		case ChartTypePie:
 			m.renderPieChart(&buf, c)
+		case ChartTypeBar, ChartTypeLine:
+			if err := m.renderXYChart(&buf, c); err != nil {
+				return nil, err
+			}
+		case ChartTypeScatter:
+			// Mermaid has no scatter charts; say so rather than drop it silently
+			fmt.Fprintf(&buf, "%%%% scatter chart %q has no Mermaid equivalent\n", c.GetTitle())
 		}
 	}
 
@@ -445,6 +456,90 @@ func (m *mermaidRenderer) renderPieChart(buf *bytes.Buffer, chart *ChartContent)
 	}
 }
 
+// renderXYChart renders a bar or line ChartContent as a Mermaid xychart.
+// Mermaid draws the bars of several series on top of each other rather than
+// side by side, so stacked bars are written as running totals, largest
+// first, which draws each series as a segment of the stack. Mermaid has no
+// missing values; NaN and infinite values are written as 0. Series without
+// values are left out, and a series whose values do not match the
+// categories one to one is an error.
+func (m *mermaidRenderer) renderXYChart(buf *bytes.Buffer, chart *ChartContent) error {
+	var categories []string
+	var series []ChartSeries
+	kind := "bar"
+	switch data := chart.GetData().(type) {
+	case *BarData:
+		if data == nil {
+			return nil
+		}
+		categories, series = data.Categories, data.Series
+	case *LineData:
+		if data == nil {
+			return nil
+		}
+		categories, series = data.Categories, data.Series
+		kind = "line"
+	default:
+		return nil
+	}
+
+	series = slices.DeleteFunc(slices.Clone(series), func(s ChartSeries) bool { return len(s.Values) == 0 })
+	if len(categories) > 0 {
+		for _, s := range series {
+			if len(s.Values) != len(categories) {
+				return fmt.Errorf("chart %q: series %q has %d values for %d categories", chart.GetTitle(), s.Name, len(s.Values), len(categories))
+			}
+		}
+	}
+	if data, ok := chart.GetData().(*BarData); ok && data.Stacked {
+		series = stackedSeries(series)
+		slices.Reverse(series)
+	}
+
+	buf.WriteString("xychart-beta\n")
+	if title := chart.GetTitle(); title != "" {
+		fmt.Fprintf(buf, "    title \"%s\"\n", escapeMermaidLabel(title))
+	}
+	if len(categories) > 0 {
+		quoted := make([]string, len(categories))
+		for i, category := range categories {
+			quoted[i] = `"` + escapeMermaidLabel(category) + `"`
+		}
+		fmt.Fprintf(buf, "    x-axis [%s]\n", strings.Join(quoted, ", "))
+	}
+	for _, s := range series {
+		values := make([]string, len(s.Values))
+		for i, v := range s.Values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				v = 0
+			}
+			values[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		fmt.Fprintf(buf, "    %s [%s]\n", kind, strings.Join(values, ", "))
+	}
+	return nil
+}
+
+// stackedSeries returns the running totals of series: the i-th result holds,
+// for each category, the sum of the values of series 0 to i. NaN and
+// infinite values count as 0.
+func stackedSeries(series []ChartSeries) []ChartSeries {
+	stacked := make([]ChartSeries, len(series))
+	var totals []float64
+	for i, s := range series {
+		for len(totals) < len(s.Values) {
+			totals = append(totals, 0)
+		}
+		for j, v := range s.Values {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				totals[j] += v
+			}
+		}
+		stacked[i] = ChartSeries{Name: s.Name, Values: slices.Clone(totals)}
+	}
+	return stacked
+}
+
 // renderFlowchartContent renders a ChartContent as flowchart (uses GraphContent structure)
 func (m *mermaidRenderer) renderFlowchartContent(buf *bytes.Buffer, chart *ChartContent) {
 	// For flowchart type, expect the data to be compatible with GraphContent
@@ -567,6 +662,8 @@ func containsSpecialChars(s string) bool {
 // drawioRenderer implements Draw.io CSV output format (v1 compatibility)
 type drawioRenderer struct {
 	baseRenderer
+	// formulaEscaping escapes cells spreadsheets would evaluate as formulas
+	formulaEscaping bool
 }
 
 func (d *drawioRenderer) Format() string {
@@ -850,6 +947,9 @@ func (d *drawioRenderer) writeCSVRow(buf *bytes.Buffer, row []string) {
 		if i > 0 {
 			buf.WriteByte(',')
 		}
+		if d.formulaEscaping {
+			field = escapeCSVFormula(field)
+		}
 
 		// Quote fields containing comma, quote, or newline; fields starting
 		// with '#' (which would otherwise look like a comment or directive);
@@ -145,6 +145,28 @@ a:focus {
   color: var(--color-text-muted);
 }
 
+/* Conditional cell styles (StyleRule) */
+.data-table td.cell-critical {
+  color: var(--color-error);
+  font-weight: 700;
+}
+
+.data-table td.cell-negative {
+  color: var(--color-error);
+}
+
+.data-table td.cell-warning {
+  color: var(--color-warning);
+}
+
+.data-table td.cell-positive {
+  color: var(--color-success);
+}
+
+.data-table td.cell-info {
+  color: var(--color-primary);
+}
+
 /* Mobile-specific adjustments */
 @media (max-width: 480px) {
   body {
@@ -203,6 +225,19 @@ a:focus {
     border-bottom: 2px solid var(--color-border);
   }
 
+  /* Column alignment (Field.Align) */
+  .data-table .align-left {
+    text-align: left;
+  }
+
+  .data-table .align-center {
+    text-align: center;
+  }
+
+  .data-table .align-right {
+    text-align: right;
+  }
+
   .data-table tbody tr:hover {
     background-color: var(--color-surface);
   }
@@ -0,0 +1,267 @@
+package output
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// defaultTablePageSize is the number of rows per page of interactive tables
+// when HTMLTemplate.TablePageSize is not set
+const defaultTablePageSize = 25
+
+// HTMLInteractive returns a Format configured for complete HTML documents
+// with the default template and interactive tables (see
+// HTMLTemplate.InteractiveTables)
+func HTMLInteractive() Format {
+	tmpl := *DefaultHTMLTemplate
+	tmpl.InteractiveTables = true
+	return HTMLWithTemplate(&tmpl)
+}
+
+// htmlSortTimeLayout writes times in UTC at a fixed width, so they sort in
+// time order as text
+const htmlSortTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// htmlSortValueAttr returns a data-sort-value attribute holding the raw value
+// of a cell that shows formatted text, so interactive tables sort "2 KiB"
+// before "1 MiB" and "45s" before "3h4m". Other cells sort on their text and
+// get no attribute.
+func htmlSortValueAttr(val any) string {
+	fv, ok := val.(FormattedValue)
+	if !ok || fv.Raw == nil || !fv.displayedIn(FormatHTML) {
+		return ""
+	}
+	var sortValue string
+	if d, ok := fv.Raw.(time.Duration); ok {
+		sortValue = fmt.Sprint(int64(d))
+	} else if t, ok := formatterTime(fv.Raw); ok {
+		sortValue = t.UTC().Format(htmlSortTimeLayout)
+	} else {
+		sortValue = fmt.Sprint(fv.Raw)
+	}
+	return fmt.Sprintf(` data-sort-value="%s"`, html.EscapeString(sortValue))
+}
+
+// writeInteractiveTablesHead writes the stylesheet and script that make the
+// data tables of a page interactive. The script runs once the page has
+// loaded, so it also picks up tables appended before the HTMLAppendMarker
+// after the page was first written.
+func writeInteractiveTablesHead(buf *strings.Builder, tmpl *HTMLTemplate) {
+	pageSize := tmpl.TablePageSize
+	switch {
+	case pageSize == 0:
+		pageSize = defaultTablePageSize
+	case pageSize < 0:
+		pageSize = 0
+	}
+
+	buf.WriteString("  <style>\n")
+	buf.WriteString(interactiveTablesCSS)
+	buf.WriteString("  </style>\n")
+	buf.WriteString("  <script>\n")
+	fmt.Fprintf(buf, interactiveTablesScript, pageSize)
+	buf.WriteString("  </script>\n")
+}
+
+// interactiveTablesCSS styles the controls the interactive tables script
+// adds. Rows hidden by filtering or paging need display: none restated,
+// since the responsive table CSS sets an explicit display on rows.
+const interactiveTablesCSS = `.data-table tr[hidden] {
+  display: none !important;
+}
+
+.data-table th.sortable {
+  cursor: pointer;
+  user-select: none;
+}
+
+.data-table th[aria-sort="ascending"]::after {
+  content: " \25B2";
+}
+
+.data-table th[aria-sort="descending"]::after {
+  content: " \25BC";
+}
+
+.table-search {
+  display: block;
+  margin-bottom: var(--spacing-sm, 0.5rem);
+  padding: var(--spacing-xs, 0.25rem) var(--spacing-sm, 0.5rem);
+  border: 1px solid var(--color-border, #e5e7eb);
+  border-radius: var(--border-radius, 0.375rem);
+  font: inherit;
+}
+
+.table-pager {
+  display: flex;
+  align-items: center;
+  gap: var(--spacing-sm, 0.5rem);
+  margin-bottom: var(--spacing-lg, 1.5rem);
+  color: var(--color-text-muted, #6b7280);
+}
+
+.table-pager:empty {
+  display: none;
+}
+`
+
+// interactiveTablesScript adds sorting, filtering and pagination to every
+// table.data-table. Cells sort on their data-sort-value attribute when they
+// have one, and on their text otherwise. It is a format string taking the
+// page size (0 turns pagination off). Without scripts the tables stay
+// complete static HTML.
+const interactiveTablesScript = `(function (pageSize) {
+  "use strict";
+  var collator = new Intl.Collator(undefined, {numeric: true, sensitivity: "base"});
+
+  function compare(a, b) {
+    var x = Number(a.replace(/,/g, "")), y = Number(b.replace(/,/g, ""));
+    if (a.trim() !== "" && b.trim() !== "" && !isNaN(x) && !isNaN(y)) {
+      return x - y;
+    }
+    return collator.compare(a, b);
+  }
+
+  // headerColumns returns the header cells that cover a single column,
+  // with the index of that column, accounting for grouped headers
+  function headerColumns(thead) {
+    var taken = [], cells = [];
+    Array.prototype.forEach.call(thead.rows, function (row, r) {
+      var col = 0;
+      Array.prototype.forEach.call(row.cells, function (cell) {
+        while (taken[r] && taken[r][col]) {
+          col++;
+        }
+        for (var i = 0; i < cell.rowSpan; i++) {
+          taken[r + i] = taken[r + i] || [];
+          for (var j = 0; j < cell.colSpan; j++) {
+            taken[r + i][col + j] = true;
+          }
+        }
+        if (cell.colSpan === 1) {
+          cells.push({cell: cell, index: col});
+        }
+        col += cell.colSpan;
+      });
+    });
+    return cells;
+  }
+
+  function button(label, onClick) {
+    var b = document.createElement("button");
+    b.type = "button";
+    b.textContent = label;
+    b.addEventListener("click", onClick);
+    return b;
+  }
+
+  function enhance(table) {
+    var body = table.tBodies[0];
+    if (!body || table.hasAttribute("data-interactive")) {
+      return;
+    }
+    table.setAttribute("data-interactive", "");
+
+    var rows = Array.prototype.slice.call(body.rows);
+    var visible = rows, page = 0;
+
+    var search = document.createElement("input");
+    search.type = "search";
+    search.className = "table-search";
+    search.placeholder = "Filter rows";
+    search.setAttribute("aria-label", "Filter table rows");
+    table.parentNode.insertBefore(search, table);
+
+    var pager = document.createElement("div");
+    pager.className = "table-pager";
+    table.parentNode.insertBefore(pager, table.nextSibling);
+
+    function render() {
+      var pages = pageSize > 0 ? Math.max(1, Math.ceil(visible.length / pageSize)) : 1;
+      page = Math.min(page, pages - 1);
+      rows.forEach(function (row) {
+        row.hidden = true;
+        body.appendChild(row);
+      });
+      visible.forEach(function (row, i) {
+        row.hidden = pageSize > 0 && Math.floor(i / pageSize) !== page;
+      });
+
+      pager.textContent = "";
+      if (pages > 1) {
+        var prev = button("Previous", function () { page--; render(); });
+        var next = button("Next", function () { page++; render(); });
+        prev.disabled = page === 0;
+        next.disabled = page === pages - 1;
+        pager.appendChild(prev);
+        pager.appendChild(document.createTextNode("Page " + (page + 1) + " of " + pages));
+        pager.appendChild(next);
+      }
+      if (visible.length !== rows.length) {
+        pager.appendChild(document.createTextNode(visible.length + " of " + rows.length + " rows"));
+      }
+    }
+
+    search.addEventListener("input", function () {
+      var query = search.value.toLowerCase();
+      visible = rows.filter(function (row) {
+        return row.textContent.toLowerCase().indexOf(query) !== -1;
+      });
+      page = 0;
+      render();
+    });
+
+    if (table.tHead) {
+      headerColumns(table.tHead).forEach(function (header) {
+        var cell = header.cell;
+        cell.classList.add("sortable");
+        cell.tabIndex = 0;
+        cell.setAttribute("aria-sort", "none");
+        function sort() {
+          var ascending = cell.getAttribute("aria-sort") !== "ascending";
+          table.tHead.querySelectorAll("th[aria-sort]").forEach(function (th) {
+            th.setAttribute("aria-sort", "none");
+          });
+          cell.setAttribute("aria-sort", ascending ? "ascending" : "descending");
+          var value = function (row) {
+            var c = row.cells[header.index];
+            if (!c) {
+              return "";
+            }
+            var raw = c.getAttribute("data-sort-value");
+            return raw !== null ? raw : c.textContent.trim();
+          };
+          rows.sort(function (a, b) {
+            var result = compare(value(a), value(b));
+            return ascending ? result : -result;
+          });
+          var shown = new Set(visible);
+          visible = rows.filter(function (row) { return shown.has(row); });
+          render();
+        }
+        cell.addEventListener("click", sort);
+        cell.addEventListener("keydown", function (event) {
+          if (event.key === "Enter" || event.key === " ") {
+            event.preventDefault();
+            sort();
+          }
+        });
+      });
+    }
+
+    render();
+  }
+
+  function init() {
+    document.querySelectorAll("table.data-table").forEach(enhance);
+  }
+
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", init);
+  } else {
+    init();
+  }
+})(%d);
+`
@@ -0,0 +1,141 @@
+package output
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTMLInteractive(t *testing.T) {
+	table, err := NewTableContent("Hosts", []Record{{"Host": "web"}}, WithKeys("Host"))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	doc := New().AddContent(table).Build()
+
+	tests := map[string]struct {
+		format   Format
+		want     []string
+		unwanted []string
+	}{
+		"interactive": {
+			format: HTMLInteractive(),
+			want:   []string{"<script>", `querySelectorAll("table.data-table")`, "})(25);", ".data-table tr[hidden]"},
+		},
+		"custom page size": {
+			format: HTMLWithTemplate(&HTMLTemplate{Title: "T", InteractiveTables: true, TablePageSize: 100}),
+			want:   []string{"})(100);"},
+		},
+		"pagination off": {
+			format: HTMLWithTemplate(&HTMLTemplate{Title: "T", InteractiveTables: true, TablePageSize: -1}),
+			want:   []string{"})(0);"},
+		},
+		"default template is static": {
+			format:   HTML(),
+			unwanted: []string{"<script>"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.format.Renderer.Render(context.Background(), doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			got := string(out)
+			for _, want := range tc.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q", want)
+				}
+			}
+			for _, unwanted := range tc.unwanted {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("output contains %q", unwanted)
+				}
+			}
+			// The static table is always complete, for readers without scripts
+			if !strings.Contains(got, "<td>web</td>") {
+				t.Errorf("output missing static table\n%s", got)
+			}
+		})
+	}
+
+	if HTMLInteractive().Renderer.(*htmlRenderer).template == DefaultHTMLTemplate {
+		t.Error("HTMLInteractive() shares DefaultHTMLTemplate")
+	}
+	if DefaultHTMLTemplate.InteractiveTables {
+		t.Error("HTMLInteractive() modified DefaultHTMLTemplate")
+	}
+}
+
+func TestHTMLInteractive_Append(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	render := func(format Format, host string) []byte {
+		table, err := NewTableContent("", []Record{{"Host": host}}, WithKeys("Host"))
+		if err != nil {
+			t.Fatalf("NewTableContent() error = %v", err)
+		}
+		out, err := format.Renderer.Render(ctx, New().AddContent(table).Build())
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		return out
+	}
+
+	fw, err := NewFileWriterWithOptions(dir, "report.html", WithAppendMode())
+	if err != nil {
+		t.Fatalf("NewFileWriterWithOptions() error = %v", err)
+	}
+	if err := fw.Write(ctx, FormatHTML, render(HTMLInteractive(), "web")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := fw.Write(ctx, FormatHTML, render(HTMLFragment(), "db")); err != nil {
+		t.Fatalf("append Write() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "report.html"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	got := string(data)
+	script := strings.Index(got, "<script>")
+	appended := strings.Index(got, "<td>db</td>")
+	marker := strings.Index(got, HTMLAppendMarker)
+	if script < 0 || appended < 0 || marker < 0 || !(script < appended && appended < marker) {
+		t.Errorf("want script, appended table and marker in that order\n%s", got)
+	}
+}
+
+func TestHTMLInteractive_SortValues(t *testing.T) {
+	launched := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	doc := New().Table("", []Record{
+		{"Host": "web", "Size": 2048, "Uptime": 45 * time.Second, "Launched": launched, "Raw": FormattedValue{Raw: 3, Text: "three", formats: []string{FormatJSON}}},
+	}, WithSchema(
+		Field{Name: "Host"},
+		Field{Name: "Size", Formatter: BytesFormatter(BytesIEC)},
+		Field{Name: "Uptime", Formatter: DurationFormatter()},
+		Field{Name: "Launched", Formatter: TimeFormatter(time.DateOnly, nil)},
+		Field{Name: "Raw"},
+	)).Build()
+
+	out, err := HTMLInteractive().Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	got := string(out)
+	for _, want := range []string{
+		"<td>web</td>",
+		`<td data-sort-value="2048">2.0 KiB</td>`,
+		`<td data-sort-value="45000000000">45s</td>`,
+		`<td data-sort-value="2024-03-01T11:00:00.000000000Z">2024-03-01</td>`,
+		"<td>3</td>",
+		`c.getAttribute("data-sort-value")`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q\n%s", want, got)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"html"
@@ -57,7 +58,7 @@ func (h *htmlRenderer) Render(ctx context.Context, doc *Document) ([]byte, error
 	}
 
 	// Check if document contains any ChartContent that would need mermaid.js
-	if h.documentContainsMermaidCharts(doc) {
+	if !h.inlineCharts() && h.documentContainsMermaidCharts(doc) {
 		// Inject mermaid.js script
 		result = h.injectMermaidScript(result)
 	}
@@ -95,7 +96,7 @@ func (h *htmlRenderer) RenderTo(ctx context.Context, doc *Document, w io.Writer)
 	}
 
 	// Check if document contains any ChartContent that would need mermaid.js
-	if h.documentContainsMermaidCharts(doc) {
+	if !h.inlineCharts() && h.documentContainsMermaidCharts(doc) {
 		if _, err := w.Write([]byte(h.getMermaidScript())); err != nil {
 			return err
 		}
@@ -125,6 +126,13 @@ func (h *htmlRenderer) renderContent(ctx context.Context, content Content) ([]by
 	switch c := content.(type) {
 	case *TableContent:
 		return h.renderTableContentHTML(c)
+	case *StreamingTableContent:
+		// HTML is rendered per content item, so collect the rows first
+		table, err := c.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return h.renderTableContentHTML(table)
 	case *TextContent:
 		return h.renderTextContentHTML(c)
 	case *RawContent:
@@ -135,17 +143,28 @@ func (h *htmlRenderer) renderContent(ctx context.Context, content Content) ([]by
 		return h.renderCollapsibleSection(ctx, c)
 	case *ChartContent:
 		return h.renderChartContentHTML(c)
-	default:
-		// Fallback to basic rendering with HTML escaping
-		data, err := h.baseRenderer.renderContent(content)
-		if err != nil {
-			return nil, err
+	case *GraphContent:
+		if h.inlineCharts() {
+			return h.renderGraphContentSVG(c), nil
 		}
-		escaped := html.EscapeString(string(data))
-		return fmt.Appendf(nil, "<pre>%s</pre>\n", escaped), nil
+		return h.renderDefaultContentHTML(c)
+	default:
+		return h.renderDefaultContentHTML(content)
 	}
 }
 
+// renderDefaultContentHTML renders content without an HTML representation
+// as escaped text
+func (h *htmlRenderer) renderDefaultContentHTML(content Content) ([]byte, error) {
+	// Fallback to basic rendering with HTML escaping
+	data, err := h.baseRenderer.renderContent(content)
+	if err != nil {
+		return nil, err
+	}
+	escaped := html.EscapeString(string(data))
+	return fmt.Appendf(nil, "<pre>%s</pre>\n", escaped), nil
+}
+
 // renderContentTo renders content to a writer for HTML format
 func (h *htmlRenderer) renderContentTo(ctx context.Context, content Content, w io.Writer) error {
 	data, err := h.renderContent(ctx, content)
@@ -175,37 +194,107 @@ func (h *htmlRenderer) renderTableContentHTML(table *TableContent) ([]byte, erro
 		return []byte(result.String()), nil // No columns to render
 	}
 
+	schema := table.getSchema()
+	aligns := make([]Alignment, len(keyOrder))
+	for i, key := range keyOrder {
+		aligns[i] = columnAlignment(schema.FindField(key), key, table.Records())
+	}
+
 	// Write header
 	result.WriteString("    <thead>\n")
-	result.WriteString("      <tr>\n")
-	for _, key := range keyOrder {
-		fmt.Fprintf(&result, "        <th>%s</th>\n", html.EscapeString(key))
-	}
-	result.WriteString("      </tr>\n")
+	h.writeTableHeaderHTML(&result, schema, keyOrder, aligns)
 	result.WriteString("    </thead>\n")
 
 	// Write body
 	result.WriteString("    <tbody>\n")
 	for _, record := range table.Records() {
 		result.WriteString("      <tr>\n")
-		for _, key := range keyOrder {
-			var cellValue string
+		for i, key := range keyOrder {
+			var cellValue, class, sortAttr string
 			if val, exists := record[key]; exists {
 				// Apply field formatter if available
-				field := table.getSchema().FindField(key)
-				cellValue = h.formatCellValue(val, field)
+				field := schema.FindField(key)
+				processed := h.processFieldValue(val, field)
+				cellValue = h.formatProcessedCellValue(processed)
+				sortAttr = htmlSortValueAttr(processed)
+				class = cellStyleClass(cellStyle(field, record, key))
 			}
-			fmt.Fprintf(&result, "        <td>%s</td>\n", cellValue)
+			class = strings.TrimSpace(class + " " + aligns[i].htmlClass())
+			if class != "" {
+				fmt.Fprintf(&result, "        <td class=\"%s\"%s>%s</td>\n", class, sortAttr, cellValue)
+				continue
+			}
+			fmt.Fprintf(&result, "        <td%s>%s</td>\n", sortAttr, cellValue)
 		}
 		result.WriteString("      </tr>\n")
 	}
 	result.WriteString("    </tbody>\n")
 
+	// Write footer
+	if footer := table.Footer(); footer != nil {
+		result.WriteString("    <tfoot>\n")
+		result.WriteString("      <tr>\n")
+		for i, key := range keyOrder {
+			var cellValue string
+			if val, exists := footer[key]; exists {
+				cellValue = h.formatCellValue(val, schema.FindField(key))
+			}
+			if class := aligns[i].htmlClass(); class != "" {
+				fmt.Fprintf(&result, "        <td class=\"%s\">%s</td>\n", class, cellValue)
+				continue
+			}
+			fmt.Fprintf(&result, "        <td>%s</td>\n", cellValue)
+		}
+		result.WriteString("      </tr>\n")
+		result.WriteString("    </tfoot>\n")
+	}
+
 	result.WriteString("  </table>\n</div>\n")
 
 	return []byte(result.String()), nil
 }
 
+// writeTableHeaderHTML writes the header rows. Column groups add a row of
+// colspan group headers, with ungrouped columns spanning both rows. Column
+// headers carry the alignment class and width hints of their column.
+func (h *htmlRenderer) writeTableHeaderHTML(result *strings.Builder, schema *Schema, keyOrder []string, aligns []Alignment) {
+	attrs := make(map[string]string, len(keyOrder))
+	for i, key := range keyOrder {
+		attrs[key] = htmlHeaderAttrs(schema.FindField(key), aligns[i])
+	}
+
+	if !schema.hasColumnGroups(keyOrder) {
+		result.WriteString("      <tr>\n")
+		for _, key := range keyOrder {
+			fmt.Fprintf(result, "        <th%s>%s</th>\n", attrs[key], html.EscapeString(key))
+		}
+		result.WriteString("      </tr>\n")
+		return
+	}
+
+	spans := schema.columnGroupSpans(keyOrder)
+	result.WriteString("      <tr>\n")
+	for _, span := range spans {
+		if span.group == nil {
+			key := span.columns[0]
+			fmt.Fprintf(result, "        <th rowspan=\"2\"%s>%s</th>\n", attrs[key], html.EscapeString(key))
+			continue
+		}
+		fmt.Fprintf(result, "        <th colspan=\"%d\" scope=\"colgroup\">%s</th>\n", len(span.columns), html.EscapeString(span.group.Name))
+	}
+	result.WriteString("      </tr>\n")
+	result.WriteString("      <tr>\n")
+	for _, span := range spans {
+		if span.group == nil {
+			continue
+		}
+		for _, key := range span.columns {
+			fmt.Fprintf(result, "        <th%s>%s</th>\n", attrs[key], html.EscapeString(span.group.label(key)))
+		}
+	}
+	result.WriteString("      </tr>\n")
+}
+
 // renderTextContentHTML renders text content as HTML with proper escaping and styling
 func (h *htmlRenderer) renderTextContentHTML(text *TextContent) ([]byte, error) {
 	var result strings.Builder
@@ -308,6 +397,24 @@ func (h *htmlRenderer) renderSectionContentHTML(ctx context.Context, section *Se
 
 // renderChartContentHTML renders chart content as HTML with mermaid class
 func (h *htmlRenderer) renderChartContentHTML(chart *ChartContent) ([]byte, error) {
+	// Mermaid has no scatter charts, so they are always drawn as SVG
+	if h.inlineCharts() || chart.GetChartType() == ChartTypeScatter {
+		var buf bytes.Buffer
+		buf.WriteString("<figure class=\"chart\">\n")
+		theme := h.svgTheme()
+		if _, _, ok := renderChartSVG(&buf, chart, &theme); !ok {
+			// Chart types without an SVG drawing are shown as text rather
+			// than relying on Mermaid.js
+			text, err := chart.AppendText(nil)
+			if err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(&buf, "<pre>%s</pre>\n", html.EscapeString(string(text)))
+		}
+		buf.WriteString("</figure>\n")
+		return buf.Bytes(), nil
+	}
+
 	// Use mermaid renderer to generate the chart syntax
 	mermaidRenderer := &mermaidRenderer{}
 
@@ -336,6 +443,30 @@ func (h *htmlRenderer) renderChartContentHTML(chart *ChartContent) ([]byte, erro
 	return []byte(result.String()), nil
 }
 
+// renderGraphContentSVG renders graph content as an inline SVG diagram
+func (h *htmlRenderer) renderGraphContentSVG(graph *GraphContent) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("<figure class=\"chart\">\n")
+	theme := h.svgTheme()
+	renderGraphSVG(&buf, graph, &theme)
+	buf.WriteString("</figure>\n")
+	return buf.Bytes()
+}
+
+// inlineCharts reports whether charts and graphs are drawn as inline SVG
+// instead of with Mermaid.js (see HTMLTemplate.InlineCharts)
+func (h *htmlRenderer) inlineCharts() bool {
+	return h.template != nil && h.template.InlineCharts
+}
+
+// svgTheme returns the chart theme with the template's ThemeOverrides applied
+func (h *htmlRenderer) svgTheme() svgTheme {
+	if h.template == nil {
+		return newSVGTheme(nil)
+	}
+	return newSVGTheme(h.template.ThemeOverrides)
+}
+
 // documentContainsMermaidCharts checks if a document contains any ChartContent
 func (h *htmlRenderer) documentContainsMermaidCharts(doc *Document) bool {
 	for _, content := range doc.GetContents() {
@@ -388,7 +519,13 @@ func (h *htmlRenderer) injectMermaidScript(html []byte) []byte {
 // formatCellValue processes field values and handles CollapsibleValue interface
 func (h *htmlRenderer) formatCellValue(val any, field *Field) string {
 	// Apply field formatter first using base renderer method
-	processed := h.processFieldValue(val, field)
+	return h.formatProcessedCellValue(h.processFieldValue(val, field))
+}
+
+// formatProcessedCellValue renders a value the field formatter has already
+// been applied to
+func (h *htmlRenderer) formatProcessedCellValue(processed any) string {
+	processed = formattedValueFor(processed, FormatHTML)
 
 	// Check if result is CollapsibleValue (Requirement 7.1)
 	if cv, ok := processed.(CollapsibleValue); ok {
@@ -638,6 +775,11 @@ func (h *htmlRenderer) getTemplateHeader(tmpl *HTMLTemplate) []byte {
 		buf.WriteString("  </style>\n")
 	}
 
+	// Interactive tables script, before HeadExtra so it can restyle the controls
+	if tmpl.InteractiveTables {
+		writeInteractiveTablesHead(&buf, tmpl)
+	}
+
 	// Additional head content
 	if tmpl.HeadExtra != "" {
 		buf.WriteString(tmpl.HeadExtra) // NOT escaped (assumed safe, user responsibility)
@@ -62,6 +62,26 @@ type HTMLTemplate struct {
 	// but no validation is performed on the CSS content itself.
 	ThemeOverrides map[string]string
 
+	// Interactive tables
+	//
+	// InteractiveTables embeds a small self-contained script, with no external
+	// dependencies, that lets readers sort data tables by clicking a header,
+	// filter them with a search box and page through long tables. Without
+	// scripts the tables stay complete and static.
+	InteractiveTables bool
+
+	// TablePageSize is the number of rows per page of interactive tables.
+	// Defaults to 25 if zero; a negative value shows all rows on one page.
+	TablePageSize int
+
+	// Offline charts
+	//
+	// InlineCharts draws pie and Gantt charts and graphs as inline SVG,
+	// rendered in Go, instead of loading Mermaid.js from a CDN. Pages then work
+	// offline and without scripts, and the same document always renders to
+	// the same bytes, so reports can be diffed.
+	InlineCharts bool
+
 	// Additional content
 	//
 	// HeadExtra contains additional HTML content injected into the <head> section.
@@ -1,12 +1,14 @@
 package output
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"maps"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 )
@@ -138,7 +140,7 @@ func buildTextContentData(text *TextContent) map[string]any {
 
 	style := text.Style()
 	if style.Bold || style.Italic || style.Color != "" || style.Size > 0 || style.Header {
-		result["style"] = map[string]any{
+		result[keyStyle] = map[string]any{
 			keyBold:   style.Bold,
 			keyItalic: style.Italic,
 			keyColor:  style.Color,
@@ -176,12 +178,7 @@ func (j *jsonRenderer) RenderTo(ctx context.Context, doc *Document, w io.Writer)
 	if w == nil {
 		return fmt.Errorf("writer cannot be nil")
 	}
-	data, err := j.renderDocumentJSON(ctx, doc)
-	if err != nil {
-		return err
-	}
-	_, err = w.Write(data)
-	return err
+	return j.renderDocumentJSONTo(ctx, doc, w)
 }
 
 func (j *jsonRenderer) SupportsStreaming() bool {
@@ -201,11 +198,157 @@ func (j *jsonRenderer) renderDocumentJSON(ctx context.Context, doc *Document) ([
 	})
 }
 
+// renderDocumentJSONTo writes the same bytes as renderDocumentJSON, but
+// streams StreamingTableContent rows to w as they are pulled instead of
+// rendering the whole document first. Other content is rendered per item and
+// indented into place, matching json.MarshalIndent of the content array.
+func (j *jsonRenderer) renderDocumentJSONTo(ctx context.Context, doc *Document, w io.Writer) error {
+	if doc == nil {
+		return fmt.Errorf("document cannot be nil")
+	}
+
+	contents := doc.GetContents()
+
+	// A single content is rendered directly, as in renderDocumentGeneric
+	if len(contents) == 1 {
+		transformed, err := applyContentTransformations(ctx, contents[0])
+		if err != nil {
+			return err
+		}
+		return j.writeContentJSON(ctx, transformed, w, "")
+	}
+
+	if len(contents) == 0 {
+		_, err := io.WriteString(w, "null")
+		return err
+	}
+
+	if _, err := io.WriteString(w, "[\n"); err != nil {
+		return err
+	}
+	for i, content := range contents {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		transformed, err := applyContentTransformations(ctx, content)
+		if err != nil {
+			return err
+		}
+
+		separator := "  "
+		if i > 0 {
+			separator = ",\n  "
+		}
+		if _, err := io.WriteString(w, separator); err != nil {
+			return err
+		}
+		if err := j.writeContentJSON(ctx, transformed, w, "  "); err != nil {
+			return fmt.Errorf("failed to render content %s: %w", content.ID(), err)
+		}
+	}
+	_, err := io.WriteString(w, "\n]")
+	return err
+}
+
+// writeContentJSON writes one content item to w, indenting continuation
+// lines with prefix so it can be embedded in an enclosing array.
+func (j *jsonRenderer) writeContentJSON(ctx context.Context, content Content, w io.Writer, prefix string) error {
+	if streaming, ok := content.(*StreamingTableContent); ok {
+		return j.writeStreamingTableJSON(ctx, streaming, w, prefix)
+	}
+
+	data, err := j.renderContent(ctx, content)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, prefix, "  "); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// writeStreamingTableJSON writes the table envelope (title, schema, data)
+// with each record encoded as it is pulled from the sequence. The layout
+// matches json.MarshalIndent, except that an empty table has "data": []
+// rather than null.
+func (j *jsonRenderer) writeStreamingTableJSON(ctx context.Context, table *StreamingTableContent, w io.Writer, prefix string) error {
+	bw := bufio.NewWriter(w)
+	memberIndent := prefix + "  "
+	recordIndent := memberIndent + "  "
+
+	// writeIndented marshals v and writes it re-indented for its position.
+	writeIndented := func(v any, indent string) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, indent, "  "); err != nil {
+			return err
+		}
+		_, err = bw.Write(buf.Bytes())
+		return err
+	}
+
+	bw.WriteString("{\n")
+	if table.Title() != "" {
+		bw.WriteString(memberIndent + `"title": `)
+		if err := writeIndented(table.Title(), memberIndent); err != nil {
+			return err
+		}
+		bw.WriteString(",\n")
+	}
+	bw.WriteString(memberIndent + `"schema": `)
+	if err := writeIndented(j.buildSchemaJSON(table.getSchema()), memberIndent); err != nil {
+		return err
+	}
+	bw.WriteString(",\n" + memberIndent + `"data": [`)
+
+	rows := 0
+	for record, err := range table.All() {
+		if err != nil {
+			bw.Flush()
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			bw.Flush()
+			return err
+		}
+
+		if rows > 0 {
+			bw.WriteString(",")
+		}
+		bw.WriteString("\n" + recordIndent)
+		if err := writeIndented(j.buildRecordJSON(record, table.getSchema()), recordIndent); err != nil {
+			return err
+		}
+		rows++
+	}
+
+	if rows > 0 {
+		bw.WriteString("\n" + memberIndent)
+	}
+	bw.WriteString("]\n" + prefix + "}")
+	return bw.Flush()
+}
+
 // renderContent renders content specifically for JSON format
 func (j *jsonRenderer) renderContent(ctx context.Context, content Content) ([]byte, error) {
 	switch c := content.(type) {
 	case *TableContent:
 		return j.renderTableContentJSON(c)
+	case *StreamingTableContent:
+		var buf bytes.Buffer
+		if err := j.writeStreamingTableJSON(ctx, c, &buf, ""); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
 	case *TextContent:
 		return j.renderTextContentJSON(c)
 	case *RawContent:
@@ -267,7 +410,8 @@ func (j *jsonRenderer) renderTableContentJSON(table *TableContent) ([]byte, erro
 
 // buildTableContentJSON builds the ordered JSON structure for table content.
 // Record objects serialize their keys in the user-specified order (the
-// library's core guarantee); the envelope is title, schema, data.
+// library's core guarantee); the envelope is title, schema, data and, for
+// tables with a footer, footer.
 func (j *jsonRenderer) buildTableContentJSON(table *TableContent) orderedJSONObject {
 	var result orderedJSONObject
 
@@ -275,44 +419,87 @@ func (j *jsonRenderer) buildTableContentJSON(table *TableContent) orderedJSONObj
 		result = append(result, jsonMember{keyTitle, table.Title()})
 	}
 
-	keyOrder := table.getSchema().GetKeyOrder()
-
 	var tableData []any
 	for _, record := range table.Records() {
-		// Build an ordered record preserving key order
-		var orderedRecord orderedJSONObject
-		for _, key := range keyOrder {
-			if val, exists := record[key]; exists {
-				// Find field for this key to apply formatter
-				field := table.getSchema().FindField(key)
-				// Process field value and handle CollapsibleValue
-				orderedRecord = append(orderedRecord, jsonMember{key, j.formatValueForJSON(val, field)})
-			}
-		}
-		tableData = append(tableData, orderedRecord)
+		tableData = append(tableData, j.buildRecordJSON(record, table.getSchema()))
 	}
 
-	return append(result,
-		jsonMember{"schema", orderedJSONObject{
-			{keyKeys, keyOrder},
-			{keyFields, j.convertFieldsToJSON(table.getSchema())},
-		}},
+	result = append(result,
+		jsonMember{keySchema, j.buildSchemaJSON(table.getSchema())},
 		jsonMember{keyData, tableData},
 	)
+	if footer := table.Footer(); footer != nil {
+		result = append(result, jsonMember{keyFooter, j.buildRecordJSON(footer, table.getSchema())})
+	}
+	return result
+}
+
+// buildSchemaJSON builds the ordered "schema" member of the table envelope.
+// Column groups are included only when the schema has them.
+func (j *jsonRenderer) buildSchemaJSON(schema *Schema) orderedJSONObject {
+	result := orderedJSONObject{
+		{keyKeys, schema.GetKeyOrder()},
+		{keyFields, j.convertFieldsToJSON(schema)},
+	}
+	if len(schema.Groups) > 0 {
+		groups := make([]orderedJSONObject, len(schema.Groups))
+		for i, group := range schema.Groups {
+			groups[i] = orderedJSONObject{{keyName, group.Name}, {keyColumns, group.Columns}}
+			if len(group.Labels) > 0 {
+				groups[i] = append(groups[i], jsonMember{keyLabels, group.Labels})
+			}
+			if group.Nest {
+				groups[i] = append(groups[i], jsonMember{keyNest, true})
+			}
+		}
+		result = append(result, jsonMember{keyGroups, groups})
+	}
+	return result
+}
+
+// buildRecordJSON builds an ordered record object preserving key order.
+// Keys missing from the record are omitted. Columns of nested column groups
+// are written in an object named after the group, at the position of the
+// group's first column.
+func (j *jsonRenderer) buildRecordJSON(record Record, schema *Schema) orderedJSONObject {
+	var orderedRecord orderedJSONObject
+	nested := make(map[*ColumnGroup]int)
+	for _, key := range schema.GetKeyOrder() {
+		if val, exists := record[key]; exists {
+			// Find field for this key to apply formatter
+			field := schema.FindField(key)
+			// Process field value and handle CollapsibleValue
+			member := jsonMember{key, j.formatValueForJSON(val, field)}
+			group := schema.columnGroup(key)
+			if group == nil || !group.Nest {
+				orderedRecord = append(orderedRecord, member)
+				continue
+			}
+			member.key = group.label(key)
+			i, ok := nested[group]
+			if !ok {
+				i = len(orderedRecord)
+				nested[group] = i
+				orderedRecord = append(orderedRecord, jsonMember{group.Name, orderedJSONObject{}})
+			}
+			orderedRecord[i].value = append(orderedRecord[i].value.(orderedJSONObject), member)
+		}
+	}
+	return orderedRecord
 }
 
 // formatValueForJSON processes field values and handles CollapsibleValue interface
 func (j *jsonRenderer) formatValueForJSON(val any, field *Field) any {
-	// Apply field formatter if present
-	processed := j.processFieldValue(val, field)
+	// Apply field formatter if present; formatted values keep their raw value
+	processed := formattedValueFor(j.processFieldValue(val, field), FormatJSON)
 
 	// Check if result is CollapsibleValue (Requirement 4.1)
 	if cv, ok := processed.(CollapsibleValue); ok {
 		result := map[string]any{
-			keyType:     "collapsible",   // Requirement 4.1: type indication
-			keySummary:  cv.Summary(),    // Requirement 4.2: include summary
-			keyDetails:  cv.Details(),    // Requirement 4.2: include details
-			keyExpanded: cv.IsExpanded(), // Requirement 4.2: include expanded
+			keyType:     valueTypeNameCollapsible, // Requirement 4.1: type indication
+			keySummary:  cv.Summary(),             // Requirement 4.2: include summary
+			keyDetails:  cv.Details(),             // Requirement 4.2: include details
+			keyExpanded: cv.IsExpanded(),          // Requirement 4.2: include expanded
 		}
 
 		// Add format-specific hints (Requirement 4.3)
@@ -371,7 +558,7 @@ func (j *jsonRenderer) buildSectionContentJSON(ctx context.Context, section *Sec
 		{keyType, contentTypeNameSection},
 		{keyTitle, section.Title()},
 		{keyLevel, section.Level()},
-		{"contents", contents},
+		{keyContents, contents},
 	}, nil
 }
 
@@ -430,7 +617,7 @@ func (j *jsonRenderer) renderChartContentJSON(content *ChartContent) ([]byte, er
 	chartData := map[string]any{
 		keyType:      content.Type(),
 		keyTitle:     content.GetTitle(),
-		"chart_type": content.GetChartType(),
+		keyChartType: content.GetChartType(),
 		keyData:      content.GetData(),
 	}
 	return json.MarshalIndent(chartData, "", "  ")
@@ -441,8 +628,8 @@ func (j *jsonRenderer) renderGraphContentJSON(content *GraphContent) ([]byte, er
 	graphData := map[string]any{
 		keyType:  content.Type(),
 		keyTitle: content.GetTitle(),
-		"nodes":  content.GetNodes(),
-		"edges":  content.GetEdges(),
+		keyNodes: content.GetNodes(),
+		keyEdges: content.GetEdges(),
 	}
 	return json.MarshalIndent(graphData, "", "  ")
 }
@@ -450,10 +637,10 @@ func (j *jsonRenderer) renderGraphContentJSON(content *GraphContent) ([]byte, er
 // renderDrawIOContentJSON renders DrawIOContent as JSON
 func (j *jsonRenderer) renderDrawIOContentJSON(content *DrawIOContent) ([]byte, error) {
 	drawioData := map[string]any{
-		keyType:   content.Type(),
-		keyTitle:  content.GetTitle(),
-		"records": content.GetRecords(),
-		keyHeader: content.GetHeader(),
+		keyType:    content.Type(),
+		keyTitle:   content.GetTitle(),
+		keyRecords: content.GetRecords(),
+		keyHeader:  content.GetHeader(),
 	}
 	return json.MarshalIndent(drawioData, "", "  ")
 }
@@ -499,6 +686,13 @@ func (y *yamlRenderer) renderContent(ctx context.Context, content Content) ([]by
 	switch c := content.(type) {
 	case *TableContent:
 		return y.renderTableContentYAML(c)
+	case *StreamingTableContent:
+		// YAML output is built as a node tree, so collect the rows first
+		table, err := c.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return y.renderTableContentYAML(table)
 	case *TextContent:
 		return y.renderTextContentYAML(c)
 	case *RawContent:
@@ -569,7 +763,7 @@ func (y *yamlRenderer) buildTableContentYAMLNode(table *TableContent) *yaml.Node
 	if table.Title() != "" {
 		result.Content = append(result.Content,
 			&yaml.Node{Kind: yaml.ScalarNode, Value: keyTitle},
-			&yaml.Node{Kind: yaml.ScalarNode, Value: table.Title()},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: table.Title(), Tag: "!!str"},
 		)
 	}
 
@@ -610,31 +804,53 @@ func (y *yamlRenderer) buildTableContentYAMLNode(table *TableContent) *yaml.Node
 		fieldsArrayNode,
 	)
 
+	// Add column groups, only when the schema has them
+	if groups := table.getSchema().Groups; len(groups) > 0 {
+		groupsArrayNode := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, group := range groups {
+			groupNode := &yaml.Node{Kind: yaml.MappingNode}
+			groupNode.Content = append(groupNode.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: keyName},
+				y.createYAMLValueNode(group.Name),
+				&yaml.Node{Kind: yaml.ScalarNode, Value: keyColumns},
+				y.createYAMLValueNode(group.Columns),
+			)
+			if len(group.Labels) > 0 {
+				labelsNode := &yaml.Node{Kind: yaml.MappingNode}
+				for _, column := range slices.Sorted(maps.Keys(group.Labels)) {
+					labelsNode.Content = append(labelsNode.Content,
+						&yaml.Node{Kind: yaml.ScalarNode, Value: column},
+						y.createYAMLValueNode(group.Labels[column]),
+					)
+				}
+				groupNode.Content = append(groupNode.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Value: keyLabels},
+					labelsNode,
+				)
+			}
+			if group.Nest {
+				groupNode.Content = append(groupNode.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Value: keyNest},
+					y.createYAMLValueNode(true),
+				)
+			}
+			groupsArrayNode.Content = append(groupsArrayNode.Content, groupNode)
+		}
+		schemaNode.Content = append(schemaNode.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: keyGroups},
+			groupsArrayNode,
+		)
+	}
+
 	result.Content = append(result.Content,
-		&yaml.Node{Kind: yaml.ScalarNode, Value: "schema"},
+		&yaml.Node{Kind: yaml.ScalarNode, Value: keySchema},
 		schemaNode,
 	)
 
 	// Create data array with preserved key order
 	dataArrayNode := &yaml.Node{Kind: yaml.SequenceNode}
 	for _, record := range table.Records() {
-		recordNode := &yaml.Node{Kind: yaml.MappingNode}
-
-		// Add keys in the specified order
-		for _, key := range keyOrder {
-			if val, exists := record[key]; exists {
-				// Find field for this key to apply formatter
-				field := table.getSchema().FindField(key)
-				// Process field value and handle CollapsibleValue
-				processedVal := y.formatValueForYAML(val, field)
-				recordNode.Content = append(recordNode.Content,
-					&yaml.Node{Kind: yaml.ScalarNode, Value: key},
-					y.createYAMLValueNode(processedVal),
-				)
-			}
-		}
-
-		dataArrayNode.Content = append(dataArrayNode.Content, recordNode)
+		dataArrayNode.Content = append(dataArrayNode.Content, y.buildRecordYAMLNode(record, table.getSchema()))
 	}
 
 	result.Content = append(result.Content,
@@ -642,9 +858,50 @@ func (y *yamlRenderer) buildTableContentYAMLNode(table *TableContent) *yaml.Node
 		dataArrayNode,
 	)
 
+	if footer := table.Footer(); footer != nil {
+		result.Content = append(result.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: keyFooter},
+			y.buildRecordYAMLNode(footer, table.getSchema()),
+		)
+	}
+
 	return result
 }
 
+// buildRecordYAMLNode builds a record mapping in schema key order. Keys
+// missing from the record are omitted. Columns of nested column groups are
+// written in a mapping named after the group, as buildRecordJSON does.
+func (y *yamlRenderer) buildRecordYAMLNode(record Record, schema *Schema) *yaml.Node {
+	recordNode := &yaml.Node{Kind: yaml.MappingNode}
+	nested := make(map[*ColumnGroup]*yaml.Node)
+	for _, key := range schema.GetKeyOrder() {
+		if val, exists := record[key]; exists {
+			// Find field for this key to apply formatter
+			field := schema.FindField(key)
+			// Process field value and handle CollapsibleValue
+			processedVal := y.formatValueForYAML(val, field)
+			parent := recordNode
+			if group := schema.columnGroup(key); group != nil && group.Nest {
+				parent = nested[group]
+				if parent == nil {
+					parent = &yaml.Node{Kind: yaml.MappingNode}
+					nested[group] = parent
+					recordNode.Content = append(recordNode.Content,
+						&yaml.Node{Kind: yaml.ScalarNode, Value: group.Name},
+						parent,
+					)
+				}
+				key = group.label(key)
+			}
+			parent.Content = append(parent.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+				y.createYAMLValueNode(processedVal),
+			)
+		}
+	}
+	return recordNode
+}
+
 // renderTextContentYAML renders text content as YAML
 func (y *yamlRenderer) renderTextContentYAML(text *TextContent) ([]byte, error) {
 	result := buildTextContentData(text)
@@ -686,7 +943,7 @@ func (y *yamlRenderer) renderSectionContentYAML(ctx context.Context, section *Se
 		contents = append(contents, contentData)
 	}
 
-	result["contents"] = contents
+	result[keyContents] = contents
 
 	return yaml.Marshal(result)
 }
@@ -695,12 +952,9 @@ func (y *yamlRenderer) renderSectionContentYAML(ctx context.Context, section *Se
 func (y *yamlRenderer) createYAMLValueNode(val any) *yaml.Node {
 	switch v := val.(type) {
 	case string:
-		// For empty strings, we need to ensure they are preserved as empty strings
-		// and not interpreted as null by YAML parsers
-		if v == "" {
-			return &yaml.Node{Kind: yaml.ScalarNode, Value: "", Tag: "!!str"}
-		}
-		return &yaml.Node{Kind: yaml.ScalarNode, Value: v}
+		// Tag strings explicitly so values such as "", "007" or "true" are
+		// quoted and read back as strings rather than null, numbers or bools
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: v, Tag: "!!str"}
 	case bool:
 		if v {
 			return &yaml.Node{Kind: yaml.ScalarNode, Value: "true"}
@@ -709,12 +963,13 @@ func (y *yamlRenderer) createYAMLValueNode(val any) *yaml.Node {
 	case nil:
 		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
 	case map[string]any:
-		// Handle map structures (like CollapsibleValue results)
+		// Handle map structures (like CollapsibleValue results). Keys are
+		// sorted, as yaml.Marshal does for maps, so output is deterministic.
 		mapNode := &yaml.Node{Kind: yaml.MappingNode}
-		for key, value := range v {
+		for _, key := range slices.Sorted(maps.Keys(v)) {
 			mapNode.Content = append(mapNode.Content,
 				&yaml.Node{Kind: yaml.ScalarNode, Value: key},
-				y.createYAMLValueNode(value),
+				y.createYAMLValueNode(v[key]),
 			)
 		}
 		return mapNode
@@ -729,7 +984,7 @@ func (y *yamlRenderer) createYAMLValueNode(val any) *yaml.Node {
 		// Handle string array structures
 		arrayNode := &yaml.Node{Kind: yaml.SequenceNode}
 		for _, item := range v {
-			arrayNode.Content = append(arrayNode.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: item})
+			arrayNode.Content = append(arrayNode.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: item, Tag: "!!str"})
 		}
 		return arrayNode
 	default:
@@ -739,8 +994,8 @@ func (y *yamlRenderer) createYAMLValueNode(val any) *yaml.Node {
 
 // formatValueForYAML processes field values and handles CollapsibleValue interface
 func (y *yamlRenderer) formatValueForYAML(val any, field *Field) any {
-	// Apply field formatter if present
-	processed := y.processFieldValue(val, field)
+	// Apply field formatter if present; formatted values keep their raw value
+	processed := formattedValueFor(y.processFieldValue(val, field), FormatYAML)
 
 	// Check if result is CollapsibleValue (Requirement 5.1)
 	if cv, ok := processed.(CollapsibleValue); ok {
@@ -827,7 +1082,7 @@ func (y *yamlRenderer) renderSectionContentYAMLStream(ctx context.Context, secti
 		contents = append(contents, contentData)
 	}
 
-	result["contents"] = contents
+	result[keyContents] = contents
 
 	return encoder.Encode(result)
 }
@@ -837,7 +1092,7 @@ func (y *yamlRenderer) renderChartContentYAML(content *ChartContent) ([]byte, er
 	chartData := map[string]any{
 		keyType:      content.Type(),
 		keyTitle:     content.GetTitle(),
-		"chart_type": content.GetChartType(),
+		keyChartType: content.GetChartType(),
 		keyData:      content.GetData(),
 	}
 	return yaml.Marshal(chartData)
@@ -848,8 +1103,8 @@ func (y *yamlRenderer) renderGraphContentYAML(content *GraphContent) ([]byte, er
 	graphData := map[string]any{
 		keyType:  content.Type(),
 		keyTitle: content.GetTitle(),
-		"nodes":  content.GetNodes(),
-		"edges":  content.GetEdges(),
+		keyNodes: content.GetNodes(),
+		keyEdges: content.GetEdges(),
 	}
 	return yaml.Marshal(graphData)
 }
@@ -857,10 +1112,10 @@ func (y *yamlRenderer) renderGraphContentYAML(content *GraphContent) ([]byte, er
 // renderDrawIOContentYAML renders DrawIOContent as YAML
 func (y *yamlRenderer) renderDrawIOContentYAML(content *DrawIOContent) ([]byte, error) {
 	drawioData := map[string]any{
-		keyType:   content.Type(),
-		keyTitle:  content.GetTitle(),
-		"records": content.GetRecords(),
-		keyHeader: content.GetHeader(),
+		keyType:    content.Type(),
+		keyTitle:   content.GetTitle(),
+		keyRecords: content.GetRecords(),
+		keyHeader:  content.GetHeader(),
 	}
 	return yaml.Marshal(drawioData)
 }
@@ -868,10 +1123,10 @@ func (y *yamlRenderer) renderDrawIOContentYAML(content *DrawIOContent) ([]byte,
 // renderCollapsibleSectionJSON renders a CollapsibleSection as structured JSON (Requirement 15.5)
 func (j *jsonRenderer) renderCollapsibleSectionJSON(ctx context.Context, section *DefaultCollapsibleSection) ([]byte, error) {
 	result := map[string]any{
-		keyType:     "collapsible_section", // Requirement 15.5: type indication
-		keyTitle:    section.Title(),       // Requirement 15.5: section metadata
-		keyLevel:    section.Level(),       // Requirement 15.5: section metadata
-		keyExpanded: section.IsExpanded(),  // Requirement 15.5: section metadata
+		keyType:     contentTypeNameCollapsibleSection, // Requirement 15.5: type indication
+		keyTitle:    section.Title(),                   // Requirement 15.5: section metadata
+		keyLevel:    section.Level(),                   // Requirement 15.5: section metadata
+		keyExpanded: section.IsExpanded(),              // Requirement 15.5: section metadata
 	}
 
 	// Render nested content (Requirement 15.5: nested content)
@@ -913,10 +1168,10 @@ func (j *jsonRenderer) renderCollapsibleSectionJSON(ctx context.Context, section
 // renderCollapsibleSectionYAML renders a CollapsibleSection as structured YAML (Requirement 15.5)
 func (y *yamlRenderer) renderCollapsibleSectionYAML(ctx context.Context, section *DefaultCollapsibleSection) ([]byte, error) {
 	result := map[string]any{
-		keyType:     "collapsible_section", // Requirement 15.5: type indication
-		keyTitle:    section.Title(),       // Requirement 15.5: section metadata
-		keyLevel:    section.Level(),       // Requirement 15.5: section metadata
-		keyExpanded: section.IsExpanded(),  // Requirement 15.5: section metadata
+		keyType:     contentTypeNameCollapsibleSection, // Requirement 15.5: type indication
+		keyTitle:    section.Title(),                   // Requirement 15.5: section metadata
+		keyLevel:    section.Level(),                   // Requirement 15.5: section metadata
+		keyExpanded: section.IsExpanded(),              // Requirement 15.5: section metadata
 	}
 
 	// Render nested content as YAML structures (Requirement 15.5: nested content)
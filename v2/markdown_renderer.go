@@ -1,6 +1,7 @@
 package output
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"html"
@@ -33,12 +34,7 @@ func (m *markdownRenderer) RenderTo(ctx context.Context, doc *Document, w io.Wri
 	if w == nil {
 		return fmt.Errorf("writer cannot be nil")
 	}
-	data, err := m.renderDocumentMarkdown(ctx, doc)
-	if err != nil {
-		return err
-	}
-	_, err = w.Write(data)
-	return err
+	return m.renderDocumentMarkdownTo(ctx, doc, w)
 }
 
 func (m *markdownRenderer) SupportsStreaming() bool {
@@ -51,6 +47,20 @@ func (m *markdownRenderer) renderDocumentMarkdown(ctx context.Context, doc *Docu
 		return nil, fmt.Errorf("document cannot be nil")
 	}
 
+	var result bytes.Buffer
+	if err := m.renderDocumentMarkdownTo(ctx, doc, &result); err != nil {
+		return nil, err
+	}
+	return result.Bytes(), nil
+}
+
+// renderDocumentMarkdownTo writes the document to w one content item at a
+// time. StreamingTableContent rows are written as they are pulled.
+func (m *markdownRenderer) renderDocumentMarkdownTo(ctx context.Context, doc *Document, w io.Writer) error {
+	if doc == nil {
+		return fmt.Errorf("document cannot be nil")
+	}
+
 	var result strings.Builder
 
 	// Add front matter if configured. frontMatter is a map, so iterate over
@@ -74,33 +84,48 @@ func (m *markdownRenderer) renderDocumentMarkdown(ctx context.Context, doc *Docu
 		}
 	}
 
+	if _, err := io.WriteString(w, result.String()); err != nil {
+		return err
+	}
+
 	// Render content
 	for i, content := range doc.GetContents() {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return ctx.Err()
 		default:
 		}
 
 		if i > 0 {
-			result.WriteString("\n")
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
 		}
 
 		// Apply per-content transformations before rendering
 		transformed, err := applyContentTransformations(ctx, content)
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		if streaming, ok := transformed.(*StreamingTableContent); ok {
+			if err := m.writeStreamingTableMarkdown(ctx, streaming, w); err != nil {
+				return fmt.Errorf("failed to render content %s: %w", content.ID(), err)
+			}
+			continue
 		}
 
 		contentMD, err := m.renderContent(ctx, transformed)
 		if err != nil {
-			return nil, fmt.Errorf("failed to render content %s: %w", content.ID(), err)
+			return fmt.Errorf("failed to render content %s: %w", content.ID(), err)
 		}
 
-		result.Write(contentMD)
+		if _, err := w.Write(contentMD); err != nil {
+			return err
+		}
 	}
 
-	return []byte(result.String()), nil
+	return nil
 }
 
 // generateTableOfContents creates a markdown table of contents from document sections
@@ -157,6 +182,12 @@ func (m *markdownRenderer) renderContent(ctx context.Context, content Content) (
 	switch c := content.(type) {
 	case *TableContent:
 		return m.renderTableContentMarkdown(c)
+	case *StreamingTableContent:
+		var buf bytes.Buffer
+		if err := m.writeStreamingTableMarkdown(ctx, c, &buf); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
 	case *TextContent:
 		return m.renderTextContentMarkdown(c)
 	case *RawContent:
@@ -193,45 +224,132 @@ func (m *markdownRenderer) renderTableContentMarkdown(table *TableContent) ([]by
 		return []byte(""), nil // No columns to render
 	}
 
+	m.writeTableHeaderMarkdown(&result, table.getSchema().flatColumnHeaders(keyOrder), markdownAlignments(table.getSchema(), keyOrder, table.Records()))
+
+	// Write data rows
+	for _, record := range table.Records() {
+		m.writeTableRowMarkdown(&result, record, table.getSchema())
+	}
+	if footer := table.Footer(); footer != nil {
+		m.writeTableFooterMarkdown(&result, footer, table.getSchema())
+	}
+
+	result.WriteString("\n")
+	return []byte(result.String()), nil
+}
+
+// writeStreamingTableMarkdown writes a streaming table to w, one row at a
+// time as records are pulled from its sequence.
+func (m *markdownRenderer) writeStreamingTableMarkdown(ctx context.Context, table *StreamingTableContent, w io.Writer) error {
+	var result strings.Builder
+
+	if table.Title() != "" {
+		fmt.Fprintf(&result, "### %s\n\n", m.escapeMarkdown(table.Title()))
+	}
+
+	keyOrder := table.getSchema().GetKeyOrder()
+	if len(keyOrder) == 0 {
+		_, err := io.WriteString(w, result.String())
+		return err
+	}
+
+	m.writeTableHeaderMarkdown(&result, table.getSchema().flatColumnHeaders(keyOrder), markdownAlignments(table.getSchema(), keyOrder, nil))
+	if _, err := io.WriteString(w, result.String()); err != nil {
+		return err
+	}
+
+	for record, err := range table.All() {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		result.Reset()
+		m.writeTableRowMarkdown(&result, record, table.getSchema())
+		if _, err := io.WriteString(w, result.String()); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// writeTableHeaderMarkdown writes the header and separator rows, marking
+// column alignment in the separators. Markdown has no merged cells, so
+// grouped columns come in as flattened "Group / Column" headers.
+func (m *markdownRenderer) writeTableHeaderMarkdown(result *strings.Builder, headers []string, aligns []Alignment) {
 	// Write header row
 	result.WriteString("|")
-	for _, key := range keyOrder {
-		fmt.Fprintf(&result, " %s |", m.escapeMarkdown(key))
+	for _, header := range headers {
+		fmt.Fprintf(result, " %s |", m.escapeMarkdown(header))
 	}
 	result.WriteString("\n")
 
 	// Write separator row
 	result.WriteString("|")
-	for range keyOrder {
-		result.WriteString(" --- |")
+	for _, align := range aligns {
+		fmt.Fprintf(result, " %s |", align.markdownSeparator())
 	}
 	result.WriteString("\n")
+}
 
-	// Write data rows
-	for _, record := range table.Records() {
-		result.WriteString("|")
-		for _, key := range keyOrder {
-			var cellValue string
-			if val, exists := record[key]; exists {
-				// Apply field formatter if available
-				field := table.getSchema().FindField(key)
-				cellValue = m.formatCellValue(val, field)
-			}
-			// Escape markdown and handle newlines in table cells
-			// Skip escaping if this is a collapsible value (starts with <details)
-			if !strings.HasPrefix(cellValue, "<details") {
-				cellValue = m.escapeMarkdownTableCell(cellValue)
-			} else {
-				// For collapsible values, only replace newlines with <br>
-				cellValue = strings.ReplaceAll(cellValue, "\n", "<br>")
-			}
-			fmt.Fprintf(&result, " %s |", cellValue)
+// markdownAlignments resolves the alignment of each of keys. Streaming
+// tables pass no records, so AlignAuto goes by field type alone.
+func markdownAlignments(schema *Schema, keys []string, records []Record) []Alignment {
+	aligns := make([]Alignment, len(keys))
+	for i, key := range keys {
+		aligns[i] = columnAlignment(schema.FindField(key), key, records)
+	}
+	return aligns
+}
+
+// writeTableRowMarkdown writes one data row in schema key order, marking
+// cells matched by style rules.
+func (m *markdownRenderer) writeTableRowMarkdown(result *strings.Builder, record Record, schema *Schema) {
+	result.WriteString("|")
+	for _, key := range schema.GetKeyOrder() {
+		cellValue := m.tableCellMarkdown(record, key, schema)
+		if _, exists := record[key]; exists {
+			cellValue = styleCellMarkdown(cellValue, cellStyle(schema.FindField(key), record, key))
 		}
-		result.WriteString("\n")
+		fmt.Fprintf(result, " %s |", cellValue)
 	}
+	result.WriteString("\n")
+}
 
+// writeTableFooterMarkdown writes the footer as a final row with bold cells.
+// Markdown tables have no footer syntax.
+func (m *markdownRenderer) writeTableFooterMarkdown(result *strings.Builder, footer Record, schema *Schema) {
+	result.WriteString("|")
+	for _, key := range schema.GetKeyOrder() {
+		cellValue := m.tableCellMarkdown(footer, key, schema)
+		if cellValue != "" {
+			cellValue = "**" + cellValue + "**"
+		}
+		fmt.Fprintf(result, " %s |", cellValue)
+	}
 	result.WriteString("\n")
-	return []byte(result.String()), nil
+}
+
+// tableCellMarkdown formats and escapes the value of key in record for a
+// table cell. Missing values are empty.
+func (m *markdownRenderer) tableCellMarkdown(record Record, key string, schema *Schema) string {
+	var cellValue string
+	if val, exists := record[key]; exists {
+		// Apply field formatter if available
+		field := schema.FindField(key)
+		cellValue = m.formatCellValue(val, field)
+	}
+	// Escape markdown and handle newlines in table cells
+	// Skip escaping if this is a collapsible value (starts with <details)
+	if !strings.HasPrefix(cellValue, "<details") {
+		return m.escapeMarkdownTableCell(cellValue)
+	}
+	// For collapsible values, only replace newlines with <br>
+	return strings.ReplaceAll(cellValue, "\n", "<br>")
 }
 
 // renderTextContentMarkdown renders text content as Markdown with styling
@@ -339,6 +457,15 @@ func (m *markdownRenderer) renderSectionContentMarkdownWithDepth(ctx context.Con
 
 // renderChartContentMarkdown renders chart content as Markdown with mermaid code fence
 func (m *markdownRenderer) renderChartContentMarkdown(chart *ChartContent) ([]byte, error) {
+	// Mermaid has no scatter charts, so they are shown as text
+	if chart.GetChartType() == ChartTypeScatter {
+		text, err := chart.AppendText(nil)
+		if err != nil {
+			return nil, err
+		}
+		return []byte("```\n" + strings.TrimRight(string(text), "\n") + "\n```\n"), nil
+	}
+
 	// Use mermaid renderer to generate the chart syntax
 	mermaidRenderer := &mermaidRenderer{}
 
@@ -504,7 +631,7 @@ func NewMarkdownRendererWithOptions(includeToC bool, frontMatter map[string]stri
 // formatCellValue processes field values and handles CollapsibleValue interface
 func (m *markdownRenderer) formatCellValue(val any, field *Field) string {
 	// Apply field formatter first using base renderer method
-	processed := m.processFieldValue(val, field)
+	processed := formattedValueFor(m.processFieldValue(val, field), FormatMarkdown)
 
 	// Check if result is CollapsibleValue (Requirement 3.1)
 	if cv, ok := processed.(CollapsibleValue); ok {
@@ -2,8 +2,10 @@ package output
 
 import (
 	"context"
+	"math"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestMermaidRenderer_ChartSupport(t *testing.T) {
@@ -78,6 +80,74 @@ func TestMermaidRenderer_ChartSupport(t *testing.T) {
 			"showData",
 			"gantt",
 		},
+	}, "bar chart rendering": {
+
+		chart: NewBarChart("Sales 2024", []string{"Q1", "Q \"2\""}, []ChartSeries{
+			{Name: "EU", Values: []float64{10, 12.5}},
+			{Name: "US", Values: []float64{8, math.NaN()}},
+		}),
+		contains: []string{
+			"xychart-beta\n",
+			`title "Sales 2024"`,
+			`x-axis ["Q1", "Q &quot;2&quot;"]`,
+			"bar [10, 12.5]",
+			"bar [8, 0]",
+		},
+		notContains: []string{
+			"graph TD",
+			"line [",
+		},
+	}, "stacked bar chart rendering": {
+
+		chart: NewStackedBarChart("", []string{"Q1", "Q2"}, []ChartSeries{
+			{Name: "EU", Values: []float64{10, 12}},
+			{Name: "US", Values: []float64{5, 3}},
+		}),
+		contains: []string{
+			"xychart-beta\n    x-axis",
+			"bar [15, 15]\n    bar [10, 12]\n",
+		},
+		notContains: []string{
+			"title",
+		},
+	}, "line chart rendering": {
+
+		chart: NewTimeSeriesChart("Requests", []time.Time{
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		}, []ChartSeries{{Name: "api", Values: []float64{100, 120}}}),
+		contains: []string{
+			"xychart-beta",
+			`x-axis ["2024-01-01", "2024-01-02"]`,
+			"line [100, 120]",
+		},
+		notContains: []string{
+			"bar [",
+		},
+	}, "scatter chart has no mermaid equivalent": {
+
+		chart: NewScatterChart("Points", []ScatterSeries{
+			{Name: "a", Points: []XYPoint{{X: 1, Y: 2}}},
+		}),
+		contains: []string{
+			`%% scatter chart "Points" has no Mermaid equivalent`,
+		},
+		notContains: []string{
+			"xychart-beta",
+		},
+	}, "series without values are left out": {
+
+		chart: NewStackedBarChart("Sales", []string{"Q1", "Q2"}, []ChartSeries{
+			{Name: "EU", Values: []float64{10, 12}},
+			{Name: "APAC"},
+			{Name: "US", Values: []float64{5, 3}},
+		}),
+		contains: []string{
+			"    bar [15, 15]\n    bar [10, 12]\n",
+		},
+		notContains: []string{
+			"bar []",
+		},
 	}}
 
 	for name, tt := range tests {
@@ -109,6 +179,30 @@ func TestMermaidRenderer_ChartSupport(t *testing.T) {
 	}
 }
 
+func TestMermaidRenderer_SeriesLengthMismatch(t *testing.T) {
+	tests := map[string]*ChartContent{
+		"too few values": NewBarChart("Sales", []string{"Q1", "Q2"}, []ChartSeries{
+			{Name: "EU", Values: []float64{10, 12}},
+			{Name: "US", Values: []float64{8}},
+		}),
+		"too many values": NewLineChart("Sales", []string{"Q1", "Q2"}, []ChartSeries{
+			{Name: "US", Values: []float64{8, 9, 10}},
+		}),
+	}
+	for name, chart := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc := New().AddContent(chart).Build()
+			_, err := (&mermaidRenderer{}).Render(context.Background(), doc)
+			if err == nil || !strings.Contains(err.Error(), `chart "Sales": series "US" has`) {
+				t.Errorf("Render() error = %v, want series length error", err)
+			}
+			if _, err := Markdown().Renderer.Render(context.Background(), doc); err == nil {
+				t.Error("Markdown Render() error = nil, want series length error")
+			}
+		})
+	}
+}
+
 func TestMermaidRenderer_MixedContent(t *testing.T) {
 	// Test document with both flowchart and specialized charts
 	doc := New().
@@ -236,6 +236,36 @@ func TestMarkdownRenderer_ChartContent(t *testing.T) {
 	}
 }
 
+// TestScatterChart_HTMLAndMarkdown verifies that scatter charts, which
+// Mermaid cannot draw, are drawn as SVG in HTML and shown as text in Markdown.
+func TestScatterChart_HTMLAndMarkdown(t *testing.T) {
+	doc := New().ScatterChart("Latency", []ScatterSeries{
+		{Name: "api", Points: []XYPoint{{X: 10, Y: 120}, {X: 20, Y: 95}}},
+	}).Build()
+
+	htmlOutput, err := (&htmlRenderer{}).Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("HTML Render() error = %v", err)
+	}
+	for _, want := range []string{`<figure class="chart">`, "<svg", "<title>api: (10, 120)</title></circle>"} {
+		if !strings.Contains(string(htmlOutput), want) {
+			t.Errorf("HTML output should contain %q, got:\n%s", want, htmlOutput)
+		}
+	}
+	if strings.Contains(string(htmlOutput), `class="mermaid"`) {
+		t.Errorf("HTML output should not use Mermaid, got:\n%s", htmlOutput)
+	}
+
+	markdownOutput, err := (&markdownRenderer{headingLevel: 1}).Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Markdown Render() error = %v", err)
+	}
+	want := "```\nLatency\nChart Type: scatter\napi: (10.00, 120.00), (20.00, 95.00)\n```\n"
+	if !strings.Contains(string(markdownOutput), want) {
+		t.Errorf("Markdown output = %q, want it to contain %q", markdownOutput, want)
+	}
+}
+
 func TestMarkdownRenderer_ChartCodeFenceFormat(t *testing.T) {
 	// Test that the code fence is properly formatted with no extra spaces
 	chart := NewGanttChart("Test", []GanttTask{
@@ -40,6 +40,8 @@ type SortKey struct {
 // FilterOp implements filtering operation using predicate functions
 type FilterOp struct {
 	predicate func(Record) bool
+	expr      *Expression // Source expression (see NewFilterOpFromExpression)
+	schema    *Schema     // Schema bound for validating expr (see bindSchema)
 }
 
 // Name returns the operation name
@@ -62,6 +64,14 @@ func (o *FilterOp) Apply(ctx context.Context, content Content) (Content, error)
 			"filter operation requires table content")
 	}
 
+	// Streaming tables are filtered lazily as rows are pulled
+	if streaming, ok := content.(*StreamingTableContent); ok {
+		if err := validateExpressionFields("filter_expression", streaming.schema, o.expr); err != nil {
+			return nil, err
+		}
+		return o.applyStreaming(streaming), nil
+	}
+
 	// Type check
 	tableContent, ok := content.(*TableContent)
 	if !ok {
@@ -69,6 +79,10 @@ func (o *FilterOp) Apply(ctx context.Context, content Content) (Content, error)
 			"filter operation requires table content")
 	}
 
+	if err := validateExpressionFields("filter_expression", tableContent.schema, o.expr); err != nil {
+		return nil, err
+	}
+
 	// Clone the content to preserve immutability
 	cloned := tableContent.Clone().(*TableContent)
 
@@ -82,7 +96,11 @@ func (o *FilterOp) Apply(ctx context.Context, content Content) (Content, error)
 		default:
 		}
 
-		if o.predicate(record) {
+		keep, err := o.matches(record)
+		if err != nil {
+			return nil, err
+		}
+		if keep {
 			filtered = append(filtered, record)
 		}
 	}
@@ -103,7 +121,7 @@ func (o *FilterOp) Validate() error {
 	if o.predicate == nil {
 		return NewValidationError("predicate", nil, "filter predicate function is required and cannot be nil")
 	}
-	return nil
+	return validateExpressionFields("filter_expression", o.schema, o.expr)
 }
 
 // ApplyWithFormat applies the filter operation with format context
@@ -120,8 +138,10 @@ func (o *FilterOp) CanTransform(content Content, format string) bool {
 
 // SortOp implements sorting operation using keys or custom comparators
 type SortOp struct {
-	keys       []SortKey             // Sort keys (column and direction)
-	comparator func(a, b Record) int // Custom comparator function (optional)
+	keys        []SortKey             // Sort keys (column and direction)
+	comparator  func(a, b Record) int // Custom comparator function (optional)
+	expressions []sortExpression      // Expression sort keys (see NewSortOpFromExpression)
+	schema      *Schema               // Schema bound for validating expressions (see bindSchema)
 }
 
 // Name returns the operation name
@@ -144,6 +164,11 @@ func (o *SortOp) Apply(ctx context.Context, content Content) (Content, error) {
 			"sort operation requires table content")
 	}
 
+	// Sorting needs every record; streaming tables must be collected first
+	if _, ok := content.(*StreamingTableContent); ok {
+		return nil, errStreamingNeedsFullSet("sort")
+	}
+
 	// Type check
 	tableContent, ok := content.(*TableContent)
 	if !ok {
@@ -151,9 +176,25 @@ func (o *SortOp) Apply(ctx context.Context, content Content) (Content, error) {
 			"sort operation requires table content")
 	}
 
+	for _, key := range o.expressions {
+		if err := validateExpressionFields("sort_expression", tableContent.schema, key.expr); err != nil {
+			return nil, err
+		}
+	}
+
 	// Clone the content to preserve immutability
 	cloned := tableContent.Clone().(*TableContent)
 
+	// Expression keys are evaluated once per record, then sorted on
+	if len(o.keys) == 0 && o.comparator == nil && len(o.expressions) > 0 {
+		sorted, err := o.sortByExpressions(ctx, cloned.records)
+		if err != nil {
+			return nil, err
+		}
+		cloned.records = sorted
+		return cloned, nil
+	}
+
 	// Validate that sort columns exist in the data (only if we have records and are using keys)
 	if len(cloned.records) > 0 && o.comparator == nil && len(o.keys) > 0 {
 		// Check first record for column existence
@@ -334,7 +375,7 @@ func (o *SortOp) CanOptimize(with Operation) bool {
 
 // Validate checks if the sort operation is valid
 func (o *SortOp) Validate() error {
-	if len(o.keys) == 0 && o.comparator == nil {
+	if len(o.keys) == 0 && o.comparator == nil && len(o.expressions) == 0 {
 		return NewValidationError("sort_configuration", nil, "sort operation requires either sort keys or a custom comparator function")
 	}
 	// Validate individual sort keys
@@ -346,6 +387,11 @@ func (o *SortOp) Validate() error {
 			return NewValidationError("sort_key_direction", key.Direction, fmt.Sprintf("sort key at index %d has invalid direction", i))
 		}
 	}
+	for _, key := range o.expressions {
+		if err := validateExpressionFields("sort_expression", o.schema, key.expr); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -386,6 +432,11 @@ func (o *LimitOp) Apply(ctx context.Context, content Content) (Content, error) {
 			"limit operation requires table content")
 	}
 
+	// Streaming tables stop pulling rows once the limit is reached
+	if streaming, ok := content.(*StreamingTableContent); ok {
+		return o.applyStreaming(streaming), nil
+	}
+
 	// Type check
 	tableContent, ok := content.(*TableContent)
 	if !ok {
@@ -487,6 +538,11 @@ func (o *GroupByOp) Apply(ctx context.Context, content Content) (Content, error)
 			"groupBy operation requires table content")
 	}
 
+	// Grouping needs every record; streaming tables must be collected first
+	if _, ok := content.(*StreamingTableContent); ok {
+		return nil, errStreamingNeedsFullSet("groupBy")
+	}
+
 	// Type check
 	tableContent, ok := content.(*TableContent)
 	if !ok {
@@ -496,6 +552,8 @@ func (o *GroupByOp) Apply(ctx context.Context, content Content) (Content, error)
 
 	// Clone the content to preserve immutability
 	cloned := tableContent.Clone().(*TableContent)
+	// The footer aggregates the columns this operation replaces
+	cloned.footer = nil
 
 	// Group records by groupBy columns
 	groups := make(map[string][]Record)
@@ -852,6 +910,14 @@ func MaxAggregate(field string) AggregateFunc {
 	}
 }
 
+// LabelAggregate returns an aggregate function that always yields label, for
+// labelling a footer row (see WithFooter)
+func LabelAggregate(label string) AggregateFunc {
+	return func([]Record, string) any {
+		return label
+	}
+}
+
 // NewGroupByOp creates a new groupBy operation
 func NewGroupByOp(groupBy []string, aggregates map[string]AggregateFunc) *GroupByOp {
 	return &GroupByOp{
@@ -865,6 +931,8 @@ type AddColumnOp struct {
 	name     string           // Name of the new column
 	fn       func(Record) any // Function to calculate the field value
 	position *int             // Optional position to insert the column (nil = append)
+	expr     *Expression      // Source expression (see NewAddColumnOpFromExpression)
+	schema   *Schema          // Schema bound for validating expr (see bindSchema)
 }
 
 // Name returns the operation name
@@ -887,6 +955,11 @@ func (o *AddColumnOp) Apply(ctx context.Context, content Content) (Content, erro
 			"addColumn operation requires table content")
 	}
 
+	// Streaming tables compute the column as rows are pulled
+	if streaming, ok := content.(*StreamingTableContent); ok {
+		return o.applyStreaming(streaming)
+	}
+
 	// Type check
 	tableContent, ok := content.(*TableContent)
 	if !ok {
@@ -906,6 +979,10 @@ func (o *AddColumnOp) Apply(ctx context.Context, content Content) (Content, erro
 			fmt.Sprintf("addColumn cannot add column %q because it already exists in the table schema", o.name))
 	}
 
+	if err := validateExpressionFields("column_expression", cloned.schema, o.expr); err != nil {
+		return nil, err
+	}
+
 	// Add the new column to each record
 	for i, record := range cloned.records {
 		// Check context cancellation
@@ -916,7 +993,10 @@ func (o *AddColumnOp) Apply(ctx context.Context, content Content) (Content, erro
 		}
 
 		// Calculate the new field value
-		value := o.fn(record)
+		value, err := o.compute(record)
+		if err != nil {
+			return nil, err
+		}
 
 		// Add the new field to the record
 		cloned.records[i][o.name] = value
@@ -986,6 +1066,7 @@ func (o *AddColumnOp) evolveSchema(originalSchema *Schema) *Schema {
 
 	return &Schema{
 		Fields:   newFields,
+		Groups:   cloneColumnGroups(originalSchema.Groups),
 		keyOrder: newKeyOrder,
 	}
 }
@@ -1007,7 +1088,7 @@ func (o *AddColumnOp) Validate() error {
 	if o.position != nil && *o.position < 0 {
 		return NewValidationError("column_position", *o.position, "addColumn position must be non-negative (>= 0)")
 	}
-	return nil
+	return validateExpressionFields("column_expression", o.schema, o.expr)
 }
 
 // ApplyWithFormat applies the addColumn operation with format context
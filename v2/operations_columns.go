@@ -0,0 +1,532 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// columnRewrite describes how a column operation changes a table: the new
+// schema, a per-record rewrite (nil when records are unchanged) and the new
+// name of each column, reporting false for removed columns (nil when names
+// are unchanged).
+type columnRewrite struct {
+	schema  *Schema
+	rewrite func(Record) Record
+	column  func(string) (string, bool)
+}
+
+// applyColumnRewrite applies a column operation planned by plan to table or
+// streaming table content. Column operations only touch one record at a
+// time, so streaming tables stay lazy.
+func applyColumnRewrite(ctx context.Context, content Content, opName string, plan func(*Schema) (*columnRewrite, error)) (Content, error) {
+	planSchema := func(schema *Schema) (*columnRewrite, error) {
+		if schema == nil {
+			schema = &Schema{}
+		}
+		return plan(schema)
+	}
+
+	// Guard against nil content before dereferencing it for the error message
+	if content == nil {
+		return nil, NewValidationError("content_type", nil,
+			opName+" operation requires table content")
+	}
+
+	if streaming, ok := content.(*StreamingTableContent); ok {
+		rw, err := planSchema(streaming.schema)
+		if err != nil {
+			return nil, err
+		}
+		rows := streaming.All()
+		return streaming.withRows(func(yield func(Record, error) bool) {
+			for record, err := range rows {
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				if rw.rewrite != nil {
+					record = rw.rewrite(record)
+				}
+				if !yield(record, nil) {
+					return
+				}
+			}
+		}, rw.schema), nil
+	}
+
+	// Type check
+	tableContent, ok := content.(*TableContent)
+	if !ok {
+		return nil, NewValidationError("content_type", content.Type().String(),
+			opName+" operation requires table content")
+	}
+
+	rw, err := planSchema(tableContent.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clone the content to preserve immutability
+	cloned := tableContent.Clone().(*TableContent)
+
+	if rw.rewrite != nil {
+		for i, record := range cloned.records {
+			// Check context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+
+			cloned.records[i] = rw.rewrite(record)
+		}
+	}
+
+	cloned.schema = rw.schema
+	cloned.footer = rewriteFooter(cloned.footer, joinSchemaColumns(tableContent.schema), rw.column)
+	return cloned, nil
+}
+
+// rewriteFooter moves footer aggregates to the new names of their columns and
+// drops those of removed columns. Aggregates such as SumAggregate name the
+// column they read, so renamed columns are restored to their old names in
+// the records the aggregates are given.
+func rewriteFooter(footer map[string]AggregateFunc, columns []string, column func(string) (string, bool)) map[string]AggregateFunc {
+	if len(footer) == 0 || column == nil {
+		return footer
+	}
+	oldNames := make(map[string]string) // new name -> old name
+	for _, old := range columns {
+		if name, ok := column(old); ok && name != old {
+			oldNames[name] = old
+		}
+	}
+
+	rewritten := make(map[string]AggregateFunc, len(footer))
+	for old, agg := range footer {
+		name, ok := column(old)
+		if !ok {
+			continue
+		}
+		if len(oldNames) == 0 {
+			rewritten[name] = agg
+			continue
+		}
+		rewritten[name] = func(records []Record, _ string) any {
+			restored := make([]Record, len(records))
+			for i, record := range records {
+				restored[i] = make(Record, len(record))
+				for key, val := range record {
+					if oldName, ok := oldNames[key]; ok {
+						key = oldName
+					}
+					restored[i][key] = val
+				}
+			}
+			return agg(restored, old)
+		}
+	}
+	return rewritten
+}
+
+// requireColumns returns a validation error naming the first column not in
+// available.
+func requireColumns(opName string, columns, available []string) error {
+	for _, column := range columns {
+		if !slices.Contains(available, column) {
+			return NewValidationError("column_name", column,
+				fmt.Sprintf("%s column %q does not exist in the table schema. Available columns: %v", opName, column, available))
+		}
+	}
+	return nil
+}
+
+// validateColumnList checks a list of column names for emptiness and
+// duplicates.
+func validateColumnList(opName string, columns []string) error {
+	if len(columns) == 0 {
+		return NewValidationError("columns", columns, opName+" operation requires at least one column")
+	}
+	for i, column := range columns {
+		if column == "" {
+			return NewValidationError("column_name", column, fmt.Sprintf("%s column at index %d cannot be empty", opName, i))
+		}
+		if slices.Contains(columns[:i], column) {
+			return NewValidationError("column_name", column, fmt.Sprintf("%s column %q is listed more than once", opName, column))
+		}
+	}
+	return nil
+}
+
+// schemaField returns the field for column, or a plain field if the schema
+// has no definition for it.
+func schemaField(schema *Schema, column string) Field {
+	if field := findSchemaField(schema, column); field != nil {
+		return *field
+	}
+	return Field{Name: column}
+}
+
+// SelectColumnsOp keeps only the listed columns, in the listed order. It is
+// the operation equivalent of building the table with WithKeys, and suits a
+// CLI --columns flag. Selecting a hidden field makes it visible.
+type SelectColumnsOp struct {
+	columns []string
+}
+
+// NewSelectColumnsOp creates an operation that keeps only columns, in order
+func NewSelectColumnsOp(columns ...string) *SelectColumnsOp {
+	return &SelectColumnsOp{columns: slices.Clone(columns)}
+}
+
+// Name returns the operation name
+func (o *SelectColumnsOp) Name() string {
+	return "SelectColumns"
+}
+
+// Apply projects table records onto the selected columns.
+// It returns a validation error if the operation's configuration is invalid.
+func (o *SelectColumnsOp) Apply(ctx context.Context, content Content) (Content, error) {
+	// Validate configuration first: Apply is public API and callable outside
+	// the renderer pipeline, which performs this check before applying.
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return applyColumnRewrite(ctx, content, "selectColumns", o.plan)
+}
+
+func (o *SelectColumnsOp) plan(schema *Schema) (*columnRewrite, error) {
+	if err := requireColumns("selectColumns", o.columns, joinSchemaColumns(schema)); err != nil {
+		return nil, err
+	}
+
+	fields := make([]Field, len(o.columns))
+	for i, column := range o.columns {
+		fields[i] = schemaField(schema, column)
+		fields[i].Hidden = false
+	}
+
+	return &columnRewrite{
+		schema: &Schema{
+			Fields: fields,
+			Groups: pruneColumnGroups(schema.Groups, func(column string) bool {
+				return slices.Contains(o.columns, column)
+			}),
+			keyOrder: slices.Clone(o.columns),
+		},
+		rewrite: func(record Record) Record {
+			selected := make(Record, len(o.columns))
+			for _, column := range o.columns {
+				if val, ok := record[column]; ok {
+					selected[column] = val
+				}
+			}
+			return selected
+		},
+		column: func(column string) (string, bool) {
+			return column, slices.Contains(o.columns, column)
+		},
+	}, nil
+}
+
+// CanOptimize returns true if this operation can be optimized with another operation
+func (o *SelectColumnsOp) CanOptimize(with Operation) bool {
+	return false
+}
+
+// Validate checks if the selectColumns operation is valid
+func (o *SelectColumnsOp) Validate() error {
+	return validateColumnList("selectColumns", o.columns)
+}
+
+// ApplyWithFormat applies the selectColumns operation with format context
+func (o *SelectColumnsOp) ApplyWithFormat(ctx context.Context, content Content, format string) (Content, error) {
+	// Column operations are format-agnostic, so delegate to Apply
+	return o.Apply(ctx, content)
+}
+
+// CanTransform checks if selectColumns operation applies to the given content and format
+func (o *SelectColumnsOp) CanTransform(content Content, format string) bool {
+	return content != nil && content.Type() == ContentTypeTable
+}
+
+// DropColumnsOp removes the listed columns from the records and the schema
+type DropColumnsOp struct {
+	columns []string
+}
+
+// NewDropColumnsOp creates an operation that removes columns
+func NewDropColumnsOp(columns ...string) *DropColumnsOp {
+	return &DropColumnsOp{columns: slices.Clone(columns)}
+}
+
+// Name returns the operation name
+func (o *DropColumnsOp) Name() string {
+	return "DropColumns"
+}
+
+// Apply removes the dropped columns from table records.
+// It returns a validation error if the operation's configuration is invalid.
+func (o *DropColumnsOp) Apply(ctx context.Context, content Content) (Content, error) {
+	// Validate configuration first: Apply is public API and callable outside
+	// the renderer pipeline, which performs this check before applying.
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return applyColumnRewrite(ctx, content, "dropColumns", o.plan)
+}
+
+func (o *DropColumnsOp) plan(schema *Schema) (*columnRewrite, error) {
+	if err := requireColumns("dropColumns", o.columns, joinSchemaColumns(schema)); err != nil {
+		return nil, err
+	}
+
+	var fields []Field
+	for _, field := range schema.Fields {
+		if !slices.Contains(o.columns, field.Name) {
+			fields = append(fields, field)
+		}
+	}
+	keyOrder := slices.DeleteFunc(schema.GetKeyOrder(), func(column string) bool {
+		return slices.Contains(o.columns, column)
+	})
+
+	return &columnRewrite{
+		schema: &Schema{
+			Fields: fields,
+			Groups: pruneColumnGroups(schema.Groups, func(column string) bool {
+				return !slices.Contains(o.columns, column)
+			}),
+			keyOrder: keyOrder,
+		},
+		rewrite: func(record Record) Record {
+			for _, column := range o.columns {
+				delete(record, column)
+			}
+			return record
+		},
+		column: func(column string) (string, bool) {
+			return column, !slices.Contains(o.columns, column)
+		},
+	}, nil
+}
+
+// CanOptimize returns true if this operation can be optimized with another operation
+func (o *DropColumnsOp) CanOptimize(with Operation) bool {
+	return false
+}
+
+// Validate checks if the dropColumns operation is valid
+func (o *DropColumnsOp) Validate() error {
+	return validateColumnList("dropColumns", o.columns)
+}
+
+// ApplyWithFormat applies the dropColumns operation with format context
+func (o *DropColumnsOp) ApplyWithFormat(ctx context.Context, content Content, format string) (Content, error) {
+	// Column operations are format-agnostic, so delegate to Apply
+	return o.Apply(ctx, content)
+}
+
+// CanTransform checks if dropColumns operation applies to the given content and format
+func (o *DropColumnsOp) CanTransform(content Content, format string) bool {
+	return content != nil && content.Type() == ContentTypeTable
+}
+
+// RenameColumnsOp renames columns in the records and the schema. Field
+// definitions, including formatters, move with the column, and the column
+// keeps its position. Renames apply simultaneously, so two columns can swap
+// names.
+type RenameColumnsOp struct {
+	renames map[string]string // old name -> new name
+}
+
+// NewRenameColumnsOp creates an operation that renames columns from the keys
+// of renames to the corresponding values
+func NewRenameColumnsOp(renames map[string]string) *RenameColumnsOp {
+	return &RenameColumnsOp{renames: maps.Clone(renames)}
+}
+
+// Name returns the operation name
+func (o *RenameColumnsOp) Name() string {
+	return "RenameColumns"
+}
+
+// Apply renames columns in table records.
+// It returns a validation error if the operation's configuration is invalid.
+func (o *RenameColumnsOp) Apply(ctx context.Context, content Content) (Content, error) {
+	// Validate configuration first: Apply is public API and callable outside
+	// the renderer pipeline, which performs this check before applying.
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return applyColumnRewrite(ctx, content, "renameColumns", o.plan)
+}
+
+// renamed returns the new name for column
+func (o *RenameColumnsOp) renamed(column string) string {
+	if name, ok := o.renames[column]; ok {
+		return name
+	}
+	return column
+}
+
+func (o *RenameColumnsOp) plan(schema *Schema) (*columnRewrite, error) {
+	columns := joinSchemaColumns(schema)
+	oldNames := slices.Sorted(maps.Keys(o.renames))
+	if err := requireColumns("renameColumns", oldNames, columns); err != nil {
+		return nil, err
+	}
+	for _, oldName := range oldNames {
+		newName := o.renames[oldName]
+		if _, renamedAway := o.renames[newName]; slices.Contains(columns, newName) && !renamedAway {
+			return nil, NewValidationError("column_name", newName,
+				fmt.Sprintf("renameColumns cannot rename %q to %q because that column already exists", oldName, newName))
+		}
+	}
+
+	fields := make([]Field, len(schema.Fields))
+	for i, field := range schema.Fields {
+		fields[i] = field
+		fields[i].Name = o.renamed(field.Name)
+	}
+	keyOrder := schema.GetKeyOrder()
+	for i, column := range keyOrder {
+		keyOrder[i] = o.renamed(column)
+	}
+	renamedColumns := make([]string, len(columns))
+	for i, column := range columns {
+		renamedColumns[i] = o.renamed(column)
+	}
+	groups, err := renameColumnGroups(schema.Groups, o.renamed, renamedColumns)
+	if err != nil {
+		return nil, NewValidationError("column_groups", schema.Groups, "renameColumns "+err.Error())
+	}
+
+	return &columnRewrite{
+		schema: &Schema{Fields: fields, Groups: groups, keyOrder: keyOrder},
+		rewrite: func(record Record) Record {
+			renamed := make(Record, len(record))
+			for column, val := range record {
+				renamed[o.renamed(column)] = val
+			}
+			return renamed
+		},
+		column: func(column string) (string, bool) {
+			return o.renamed(column), true
+		},
+	}, nil
+}
+
+// CanOptimize returns true if this operation can be optimized with another operation
+func (o *RenameColumnsOp) CanOptimize(with Operation) bool {
+	return false
+}
+
+// Validate checks if the renameColumns operation is valid
+func (o *RenameColumnsOp) Validate() error {
+	if len(o.renames) == 0 {
+		return NewValidationError("renames", o.renames, "renameColumns operation requires at least one rename")
+	}
+	targets := make(map[string]string, len(o.renames))
+	for _, oldName := range slices.Sorted(maps.Keys(o.renames)) {
+		newName := o.renames[oldName]
+		if oldName == "" || newName == "" {
+			return NewValidationError("column_name", oldName, "renameColumns column names cannot be empty")
+		}
+		if other, ok := targets[newName]; ok {
+			return NewValidationError("column_name", newName,
+				fmt.Sprintf("renameColumns cannot rename both %q and %q to %q", other, oldName, newName))
+		}
+		targets[newName] = oldName
+	}
+	return nil
+}
+
+// ApplyWithFormat applies the renameColumns operation with format context
+func (o *RenameColumnsOp) ApplyWithFormat(ctx context.Context, content Content, format string) (Content, error) {
+	// Column operations are format-agnostic, so delegate to Apply
+	return o.Apply(ctx, content)
+}
+
+// CanTransform checks if renameColumns operation applies to the given content and format
+func (o *RenameColumnsOp) CanTransform(content Content, format string) bool {
+	return content != nil && content.Type() == ContentTypeTable
+}
+
+// ReorderColumnsOp moves the listed columns to the front, in the listed
+// order. Columns not listed keep their relative order after them. Records
+// are unchanged; only the schema key order moves.
+type ReorderColumnsOp struct {
+	columns []string
+}
+
+// NewReorderColumnsOp creates an operation that moves columns to the front
+func NewReorderColumnsOp(columns ...string) *ReorderColumnsOp {
+	return &ReorderColumnsOp{columns: slices.Clone(columns)}
+}
+
+// Name returns the operation name
+func (o *ReorderColumnsOp) Name() string {
+	return "ReorderColumns"
+}
+
+// Apply reorders the table columns.
+// It returns a validation error if the operation's configuration is invalid.
+func (o *ReorderColumnsOp) Apply(ctx context.Context, content Content) (Content, error) {
+	// Validate configuration first: Apply is public API and callable outside
+	// the renderer pipeline, which performs this check before applying.
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return applyColumnRewrite(ctx, content, "reorderColumns", o.plan)
+}
+
+func (o *ReorderColumnsOp) plan(schema *Schema) (*columnRewrite, error) {
+	keyOrder := schema.GetKeyOrder()
+	if err := requireColumns("reorderColumns", o.columns, keyOrder); err != nil {
+		return nil, err
+	}
+
+	reordered := slices.Clone(o.columns)
+	for _, column := range keyOrder {
+		if !slices.Contains(o.columns, column) {
+			reordered = append(reordered, column)
+		}
+	}
+
+	// Keep Fields in step with the key order; hidden fields stay at the end
+	fields := make([]Field, 0, len(schema.Fields))
+	for _, column := range reordered {
+		fields = append(fields, schemaField(schema, column))
+	}
+	for _, field := range schema.Fields {
+		if !slices.Contains(reordered, field.Name) {
+			fields = append(fields, field)
+		}
+	}
+
+	return &columnRewrite{schema: &Schema{Fields: fields, Groups: cloneColumnGroups(schema.Groups), keyOrder: reordered}}, nil
+}
+
+// CanOptimize returns true if this operation can be optimized with another operation
+func (o *ReorderColumnsOp) CanOptimize(with Operation) bool {
+	return false
+}
+
+// Validate checks if the reorderColumns operation is valid
+func (o *ReorderColumnsOp) Validate() error {
+	return validateColumnList("reorderColumns", o.columns)
+}
+
+// ApplyWithFormat applies the reorderColumns operation with format context
+func (o *ReorderColumnsOp) ApplyWithFormat(ctx context.Context, content Content, format string) (Content, error) {
+	// Column operations are format-agnostic, so delegate to Apply
+	return o.Apply(ctx, content)
+}
+
+// CanTransform checks if reorderColumns operation applies to the given content and format
+func (o *ReorderColumnsOp) CanTransform(content Content, format string) bool {
+	return content != nil && content.Type() == ContentTypeTable
+}
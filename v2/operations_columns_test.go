@@ -0,0 +1,200 @@
+package output
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func columnsTestTable(t *testing.T) *TableContent {
+	t.Helper()
+	upper := func(val any) any { return strings.ToUpper(formatValue(val)) }
+	table, err := NewTableContent("Instances", []Record{
+		{"ID": "i-1", "Name": "web", "State": "running", "Secret": "a"},
+		{"ID": "i-2", "Name": "db", "State": "stopped", "Secret": "b"},
+	}, WithSchema(
+		Field{Name: "ID", Type: fieldTypeString},
+		Field{Name: "Name", Type: fieldTypeString, Formatter: upper},
+		Field{Name: "State", Type: fieldTypeString},
+		Field{Name: "Secret", Type: fieldTypeString, Hidden: true},
+	))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	return table
+}
+
+func TestColumnOpsApply(t *testing.T) {
+	tests := map[string]struct {
+		op          Operation
+		wantKeys    []string
+		wantRecord  Record
+		wantFields  []string
+		wantErrText string
+	}{
+		"select keeps listed columns in order": {
+			op:         NewSelectColumnsOp("State", "ID"),
+			wantKeys:   []string{"State", "ID"},
+			wantRecord: Record{"State": "running", "ID": "i-1"},
+			wantFields: []string{"State", "ID"},
+		},
+		"select shows hidden field": {
+			op:         NewSelectColumnsOp("ID", "Secret"),
+			wantKeys:   []string{"ID", "Secret"},
+			wantRecord: Record{"ID": "i-1", "Secret": "a"},
+			wantFields: []string{"ID", "Secret"},
+		},
+		"select missing column": {
+			op:          NewSelectColumnsOp("ID", "Region"),
+			wantErrText: `"Region" does not exist`,
+		},
+		"drop removes columns": {
+			op:         NewDropColumnsOp("State", "Secret"),
+			wantKeys:   []string{"ID", "Name"},
+			wantRecord: Record{"ID": "i-1", "Name": "web"},
+			wantFields: []string{"ID", "Name"},
+		},
+		"drop missing column": {
+			op:          NewDropColumnsOp("Region"),
+			wantErrText: `"Region" does not exist`,
+		},
+		"rename keeps position": {
+			op:         NewRenameColumnsOp(map[string]string{"Name": "Host"}),
+			wantKeys:   []string{"ID", "Host", "State"},
+			wantRecord: Record{"ID": "i-1", "Host": "web", "State": "running", "Secret": "a"},
+			wantFields: []string{"ID", "Host", "State", "Secret"},
+		},
+		"rename swaps columns": {
+			op:         NewRenameColumnsOp(map[string]string{"Name": "State", "State": "Name"}),
+			wantKeys:   []string{"ID", "State", "Name"},
+			wantRecord: Record{"ID": "i-1", "State": "web", "Name": "running", "Secret": "a"},
+			wantFields: []string{"ID", "State", "Name", "Secret"},
+		},
+		"rename onto existing column": {
+			op:          NewRenameColumnsOp(map[string]string{"Name": "ID"}),
+			wantErrText: "already exists",
+		},
+		"rename missing column": {
+			op:          NewRenameColumnsOp(map[string]string{"Region": "Zone"}),
+			wantErrText: `"Region" does not exist`,
+		},
+		"reorder moves listed columns first": {
+			op:         NewReorderColumnsOp("State"),
+			wantKeys:   []string{"State", "ID", "Name"},
+			wantRecord: Record{"ID": "i-1", "Name": "web", "State": "running", "Secret": "a"},
+			wantFields: []string{"State", "ID", "Name", "Secret"},
+		},
+		"reorder hidden column": {
+			op:          NewReorderColumnsOp("Secret"),
+			wantErrText: `"Secret" does not exist`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			table := columnsTestTable(t)
+			result, err := tc.op.Apply(context.Background(), table)
+			if tc.wantErrText != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrText) {
+					t.Fatalf("Apply() error = %v, want error containing %q", err, tc.wantErrText)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+
+			out := result.(*TableContent)
+			if got := out.Schema().GetKeyOrder(); !slices.Equal(got, tc.wantKeys) {
+				t.Errorf("key order = %v, want %v", got, tc.wantKeys)
+			}
+			var fields []string
+			for _, field := range out.Schema().Fields {
+				fields = append(fields, field.Name)
+			}
+			if !slices.Equal(fields, tc.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tc.wantFields)
+			}
+			if got := out.Records()[0]; len(got) != len(tc.wantRecord) {
+				t.Errorf("record = %v, want %v", got, tc.wantRecord)
+			} else {
+				for key, want := range tc.wantRecord {
+					if got[key] != want {
+						t.Errorf("record[%q] = %v, want %v", key, got[key], want)
+					}
+				}
+			}
+
+			// The original table must be untouched
+			if got := table.Schema().GetKeyOrder(); !slices.Equal(got, []string{"ID", "Name", "State"}) {
+				t.Errorf("original key order = %v, want unchanged", got)
+			}
+			if len(table.Records()[0]) != 4 {
+				t.Errorf("original record = %v, want unchanged", table.Records()[0])
+			}
+		})
+	}
+}
+
+func TestRenameColumnsOp_KeepsFormatter(t *testing.T) {
+	result, err := NewRenameColumnsOp(map[string]string{"Name": "Host"}).Apply(context.Background(), columnsTestTable(t))
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	field := result.(*TableContent).Schema().FindField("Host")
+	if field == nil || field.Formatter == nil {
+		t.Fatalf("FindField(Host) = %+v, want field with formatter", field)
+	}
+	if got := field.Formatter("web"); got != "WEB" {
+		t.Errorf("Formatter(web) = %v, want WEB", got)
+	}
+}
+
+func TestColumnOpsValidate(t *testing.T) {
+	tests := map[string]Operation{
+		"select without columns":   NewSelectColumnsOp(),
+		"select duplicate column":  NewSelectColumnsOp("ID", "ID"),
+		"drop empty column":        NewDropColumnsOp(""),
+		"reorder without columns":  NewReorderColumnsOp(),
+		"rename without renames":   NewRenameColumnsOp(nil),
+		"rename to empty name":     NewRenameColumnsOp(map[string]string{"ID": ""}),
+		"rename two to one column": NewRenameColumnsOp(map[string]string{"ID": "Key", "Name": "Key"}),
+	}
+
+	for name, op := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := op.Validate(); err == nil {
+				t.Error("Validate() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestColumnOps_StreamingTable(t *testing.T) {
+	pulled := 0
+	table, err := NewStreamingTableContent("Instances", countingSeq([]Record{
+		{"ID": "i-1", "Name": "web", "State": "running"},
+		{"ID": "i-2", "Name": "db", "State": "stopped"},
+	}, &pulled), WithKeys("ID", "Name", "State"),
+		WithTransformations(
+			NewDropColumnsOp("State"),
+			NewRenameColumnsOp(map[string]string{"Name": "Host"}),
+			NewReorderColumnsOp("Host"),
+		))
+	if err != nil {
+		t.Fatalf("NewStreamingTableContent() error = %v", err)
+	}
+
+	out, err := CSV().Renderer.Render(context.Background(), New().AddContent(table).Build())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := "Host,ID\nweb,i-1\ndb,i-2\n"; string(out) != want {
+		t.Errorf("Render() = %q, want %q", out, want)
+	}
+	if pulled != 2 {
+		t.Errorf("pulled %d records, want 2", pulled)
+	}
+}
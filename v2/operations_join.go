@@ -0,0 +1,459 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// JoinType selects which unmatched rows a JoinOp keeps
+type JoinType int
+
+const (
+	// InnerJoin keeps only rows with a match in both tables
+	InnerJoin JoinType = iota
+	// LeftJoin keeps every row of the transformed table
+	LeftJoin
+	// RightJoin keeps every row of the joined table
+	RightJoin
+	// FullJoin keeps every row of both tables
+	FullJoin
+)
+
+// String returns the string representation of the join type
+func (t JoinType) String() string {
+	switch t {
+	case InnerJoin:
+		return "inner"
+	case LeftJoin:
+		return "left"
+	case RightJoin:
+		return "right"
+	case FullJoin:
+		return "full"
+	default:
+		return unknownValue
+	}
+}
+
+// defaultJoinRightSuffix is appended to colliding columns of the joined
+// table when no JoinOption configures collision handling.
+const defaultJoinRightSuffix = "_right"
+
+// JoinOp joins the table it transforms (the left table) with a second table
+// (the right table) on one or more key columns present in both.
+//
+// Rows match when every key column holds the same value on both sides, with
+// values compared by their formatted representation as in GroupByOp. A row
+// whose key is missing or nil never matches, but outer joins still keep it.
+// Output rows follow the left table's order, each followed by its matches in
+// the right table's order; unmatched right rows kept by a right or full join
+// come last.
+//
+// The key columns appear once, at their position in the left table. Other
+// columns present in both tables are renamed with the configured prefixes or
+// suffixes (by default the right column gets a "_right" suffix). The right
+// table's records are used as they are: transformations attached to it are
+// not applied.
+type JoinOp struct {
+	right       *TableContent
+	joinType    JoinType
+	keys        []string
+	leftAffix   string
+	rightAffix  string
+	affixPrefix bool // affixes are prefixes rather than suffixes
+}
+
+// JoinOption configures a JoinOp
+type JoinOption func(*JoinOp)
+
+// WithJoinSuffixes resolves column name collisions by appending left to the
+// left table's column and right to the right table's column. Either may be
+// empty, but not both.
+func WithJoinSuffixes(left, right string) JoinOption {
+	return func(o *JoinOp) {
+		o.leftAffix = left
+		o.rightAffix = right
+		o.affixPrefix = false
+	}
+}
+
+// WithJoinPrefixes resolves column name collisions by prepending left to the
+// left table's column and right to the right table's column. Either may be
+// empty, but not both.
+func WithJoinPrefixes(left, right string) JoinOption {
+	return func(o *JoinOp) {
+		o.leftAffix = left
+		o.rightAffix = right
+		o.affixPrefix = true
+	}
+}
+
+// NewJoinOp creates a join with right on the given key columns. The right
+// table is copied, so later changes to it do not affect the operation.
+func NewJoinOp(right *TableContent, joinType JoinType, keys []string, opts ...JoinOption) *JoinOp {
+	op := &JoinOp{
+		joinType:   joinType,
+		keys:       slices.Clone(keys),
+		rightAffix: defaultJoinRightSuffix,
+	}
+	if right != nil {
+		op.right = right.Clone().(*TableContent)
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(op)
+		}
+	}
+	return op
+}
+
+// Name returns the operation name
+func (o *JoinOp) Name() string {
+	return "Join"
+}
+
+// Apply joins table records with the right table.
+// It returns a validation error if the operation's configuration is invalid.
+func (o *JoinOp) Apply(ctx context.Context, content Content) (Content, error) {
+	// Validate configuration first: Apply is public API and callable outside
+	// the renderer pipeline, which performs this check before applying.
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Guard against nil content before dereferencing it for the error message
+	if content == nil {
+		return nil, NewValidationError("content_type", nil,
+			"join operation requires table content")
+	}
+
+	// Streaming tables are joined as rows are pulled; the right table is
+	// already in memory
+	if streaming, ok := content.(*StreamingTableContent); ok {
+		return o.applyStreaming(streaming)
+	}
+
+	// Type check
+	tableContent, ok := content.(*TableContent)
+	if !ok {
+		return nil, NewValidationError("content_type", content.Type().String(),
+			"join operation requires table content")
+	}
+
+	plan, err := o.plan(tableContent.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clone the content to preserve immutability
+	cloned := tableContent.Clone().(*TableContent)
+	// The footer aggregates the columns this operation replaces
+	cloned.footer = nil
+
+	index := o.indexRight()
+	matched := make([]bool, len(o.right.records))
+	joined := make([]Record, 0, len(cloned.records))
+	for _, record := range cloned.records {
+		// Check context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		joined = append(joined, o.joinLeftRecord(record, plan, index, matched)...)
+	}
+	joined = append(joined, o.unmatchedRight(plan, matched)...)
+
+	cloned.records = joined
+	cloned.schema = plan.schema
+	return cloned, nil
+}
+
+// applyStreaming joins each left row as it is pulled. Unmatched right rows
+// for right and full joins are yielded after the left sequence ends.
+func (o *JoinOp) applyStreaming(content *StreamingTableContent) (*StreamingTableContent, error) {
+	plan, err := o.plan(content.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := content.All()
+	return content.withRows(func(yield func(Record, error) bool) {
+		index := o.indexRight()
+		matched := make([]bool, len(o.right.records))
+		for record, err := range rows {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, joined := range o.joinLeftRecord(record, plan, index, matched) {
+				if !yield(joined, nil) {
+					return
+				}
+			}
+		}
+		for _, joined := range o.unmatchedRight(plan, matched) {
+			if !yield(joined, nil) {
+				return
+			}
+		}
+	}, plan.schema), nil
+}
+
+// joinPlan maps the columns of both tables onto the joined schema.
+type joinPlan struct {
+	schema     *Schema
+	leftNames  map[string]string // left column -> joined column
+	rightNames map[string]string // right non-key column -> joined column
+}
+
+// plan builds the joined schema for a left table schema. It fails when a key
+// column is missing from the left table or when renaming cannot make every
+// column name unique.
+func (o *JoinOp) plan(leftSchema *Schema) (*joinPlan, error) {
+	leftColumns := joinSchemaColumns(leftSchema)
+	rightColumns := joinSchemaColumns(o.right.schema)
+
+	for _, key := range o.keys {
+		if !slices.Contains(leftColumns, key) {
+			return nil, NewValidationError("join_key", key,
+				fmt.Sprintf("join key column %q does not exist in the table being joined", key))
+		}
+	}
+
+	shared := make(map[string]bool)
+	for _, column := range rightColumns {
+		if slices.Contains(leftColumns, column) && !slices.Contains(o.keys, column) {
+			shared[column] = true
+		}
+	}
+
+	plan := &joinPlan{
+		leftNames:  make(map[string]string, len(leftColumns)),
+		rightNames: make(map[string]string, len(rightColumns)),
+	}
+	used := make(map[string]bool, len(leftColumns)+len(rightColumns))
+	var fields []Field
+	var keyOrder []string
+
+	addColumn := func(schema *Schema, column, name string, names map[string]string) error {
+		if used[name] {
+			return NewValidationError("column_name", name,
+				fmt.Sprintf("join produces duplicate column %q; configure different prefixes or suffixes", name))
+		}
+		used[name] = true
+		names[column] = name
+
+		field := Field{Name: column}
+		if existing := findSchemaField(schema, column); existing != nil {
+			field = *existing
+		}
+		field.Name = name
+		fields = append(fields, field)
+		return nil
+	}
+
+	for _, column := range leftColumns {
+		name := column
+		if shared[column] {
+			name = o.rename(column, o.leftAffix)
+		}
+		if err := addColumn(leftSchema, column, name, plan.leftNames); err != nil {
+			return nil, err
+		}
+	}
+	for _, column := range rightColumns {
+		if slices.Contains(o.keys, column) {
+			continue
+		}
+		name := column
+		if shared[column] {
+			name = o.rename(column, o.rightAffix)
+		}
+		if err := addColumn(o.right.schema, column, name, plan.rightNames); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, column := range leftSchema.GetKeyOrder() {
+		keyOrder = append(keyOrder, plan.leftNames[column])
+	}
+	for _, column := range o.right.schema.GetKeyOrder() {
+		if name, ok := plan.rightNames[column]; ok {
+			keyOrder = append(keyOrder, name)
+		}
+	}
+
+	plan.schema = &Schema{Fields: fields, keyOrder: keyOrder}
+	return plan, nil
+}
+
+// rename applies a collision prefix or suffix to column.
+func (o *JoinOp) rename(column, affix string) string {
+	if o.affixPrefix {
+		return affix + column
+	}
+	return column + affix
+}
+
+// joinSchemaColumns returns the columns of schema: the key order followed by
+// any fields outside it, such as hidden fields.
+func joinSchemaColumns(schema *Schema) []string {
+	if schema == nil {
+		return nil
+	}
+	columns := schema.GetKeyOrder()
+	for _, field := range schema.Fields {
+		if !slices.Contains(columns, field.Name) {
+			columns = append(columns, field.Name)
+		}
+	}
+	return columns
+}
+
+// findSchemaField looks up a field in a possibly nil schema.
+func findSchemaField(schema *Schema, name string) *Field {
+	if schema == nil {
+		return nil
+	}
+	return schema.FindField(name)
+}
+
+// indexRight maps each join key to the positions of the right records
+// holding it. Records with a missing or nil key column are not indexed.
+func (o *JoinOp) indexRight() map[string][]int {
+	index := make(map[string][]int, len(o.right.records))
+	for i, record := range o.right.records {
+		if key, ok := o.joinKey(record); ok {
+			index[key] = append(index[key], i)
+		}
+	}
+	return index
+}
+
+// joinKey encodes the key column values of record, using the same
+// length-prefixed encoding as GroupByOp.createGroupKey. It reports false when
+// any key column is missing or nil.
+func (o *JoinOp) joinKey(record Record) (string, bool) {
+	var key strings.Builder
+	for _, column := range o.keys {
+		val, exists := record[column]
+		if !exists || val == nil {
+			return "", false
+		}
+		s := fmt.Sprintf("%v", val)
+		fmt.Fprintf(&key, "%d:%s", len(s), s)
+	}
+	return key.String(), true
+}
+
+// joinLeftRecord returns the joined rows for one left record and marks the
+// right records it matched.
+func (o *JoinOp) joinLeftRecord(left Record, plan *joinPlan, index map[string][]int, matched []bool) []Record {
+	var positions []int
+	if key, ok := o.joinKey(left); ok {
+		positions = index[key]
+	}
+
+	if len(positions) == 0 {
+		if o.joinType == LeftJoin || o.joinType == FullJoin {
+			return []Record{o.combine(left, nil, plan)}
+		}
+		return nil
+	}
+
+	joined := make([]Record, 0, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+		joined = append(joined, o.combine(left, o.right.records[pos], plan))
+	}
+	return joined
+}
+
+// unmatchedRight returns the right records that matched no left record, for
+// right and full joins.
+func (o *JoinOp) unmatchedRight(plan *joinPlan, matched []bool) []Record {
+	if o.joinType != RightJoin && o.joinType != FullJoin {
+		return nil
+	}
+	var joined []Record
+	for i, record := range o.right.records {
+		if !matched[i] {
+			joined = append(joined, o.combine(nil, record, plan))
+		}
+	}
+	return joined
+}
+
+// combine builds a joined record. Either side may be nil for an unmatched
+// row; the key columns are then taken from the other side.
+func (o *JoinOp) combine(left, right Record, plan *joinPlan) Record {
+	record := make(Record, len(plan.leftNames)+len(plan.rightNames))
+	for column, name := range plan.leftNames {
+		if val, ok := left[column]; ok {
+			record[name] = val
+		}
+	}
+	for column, name := range plan.rightNames {
+		if val, ok := right[column]; ok {
+			record[name] = val
+		}
+	}
+	if left == nil {
+		for _, key := range o.keys {
+			if val, ok := right[key]; ok {
+				record[plan.leftNames[key]] = val
+			}
+		}
+	}
+	return record
+}
+
+// CanOptimize returns true if this join can be optimized with another operation
+func (o *JoinOp) CanOptimize(with Operation) bool {
+	// Join cannot be combined with other operations
+	return false
+}
+
+// Validate checks if the join operation is valid
+func (o *JoinOp) Validate() error {
+	if o.right == nil {
+		return NewValidationError("right_table", nil, "join operation requires a table to join with and cannot be nil")
+	}
+	if o.joinType < InnerJoin || o.joinType > FullJoin {
+		return NewValidationError("join_type", int(o.joinType), "join type must be InnerJoin, LeftJoin, RightJoin or FullJoin")
+	}
+	if len(o.keys) == 0 {
+		return NewValidationError("join_keys", o.keys, "join operation requires at least one key column")
+	}
+	rightColumns := joinSchemaColumns(o.right.schema)
+	for i, key := range o.keys {
+		if key == "" {
+			return NewValidationError("join_key", key, fmt.Sprintf("join key column at index %d cannot be empty", i))
+		}
+		if !slices.Contains(rightColumns, key) {
+			return NewValidationError("join_key", key,
+				fmt.Sprintf("join key column %q does not exist in the right table", key))
+		}
+	}
+	if o.leftAffix == o.rightAffix {
+		return NewValidationError("join_affixes", o.leftAffix,
+			"join prefixes or suffixes for the two tables must differ to keep column names unique")
+	}
+	return nil
+}
+
+// ApplyWithFormat applies the join operation with format context
+func (o *JoinOp) ApplyWithFormat(ctx context.Context, content Content, format string) (Content, error) {
+	// Join operations are format-agnostic, so delegate to Apply
+	return o.Apply(ctx, content)
+}
+
+// CanTransform checks if join operation applies to the given content and format
+func (o *JoinOp) CanTransform(content Content, format string) bool {
+	// Join works with table content in any format
+	return content != nil && content.Type() == ContentTypeTable
+}
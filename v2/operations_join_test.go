@@ -0,0 +1,294 @@
+package output
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func joinTestInstances(t *testing.T) *TableContent {
+	t.Helper()
+	table, err := NewTableContent("Instances", []Record{
+		{"InstanceId": "i-1", "Name": "web", "State": "running"},
+		{"InstanceId": "i-2", "Name": "db", "State": "stopped"},
+		{"InstanceId": "i-3", "Name": "cache", "State": "running"},
+	}, WithKeys("InstanceId", "Name", "State"))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	return table
+}
+
+func joinTestCosts(t *testing.T) *TableContent {
+	t.Helper()
+	table, err := NewTableContent("Costs", []Record{
+		{"InstanceId": "i-1", "Cost": 12.5, "State": "billed"},
+		{"InstanceId": "i-3", "Cost": 3.0, "State": "billed"},
+		{"InstanceId": "i-4", "Cost": 7.25, "State": "terminated"},
+	}, WithKeys("InstanceId", "Cost", "State"))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	return table
+}
+
+// joinedColumn returns the values of column across records, with "" for a
+// missing value.
+func joinedColumn(records []Record, column string) []string {
+	values := make([]string, len(records))
+	for i, record := range records {
+		if val, ok := record[column]; ok {
+			values[i] = formatValue(val)
+		}
+	}
+	return values
+}
+
+func TestJoinOpApply_JoinTypes(t *testing.T) {
+	tests := map[string]struct {
+		joinType JoinType
+		wantIDs  []string
+		wantCost []string
+		wantName []string
+	}{
+		"inner": {
+			joinType: InnerJoin,
+			wantIDs:  []string{"i-1", "i-3"},
+			wantCost: []string{"12.5", "3"},
+			wantName: []string{"web", "cache"},
+		},
+		"left": {
+			joinType: LeftJoin,
+			wantIDs:  []string{"i-1", "i-2", "i-3"},
+			wantCost: []string{"12.5", "", "3"},
+			wantName: []string{"web", "db", "cache"},
+		},
+		"right": {
+			joinType: RightJoin,
+			wantIDs:  []string{"i-1", "i-3", "i-4"},
+			wantCost: []string{"12.5", "3", "7.25"},
+			wantName: []string{"web", "cache", ""},
+		},
+		"full": {
+			joinType: FullJoin,
+			wantIDs:  []string{"i-1", "i-2", "i-3", "i-4"},
+			wantCost: []string{"12.5", "", "3", "7.25"},
+			wantName: []string{"web", "db", "cache", ""},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			op := NewJoinOp(joinTestCosts(t), tt.joinType, []string{"InstanceId"})
+			result, err := op.Apply(context.Background(), joinTestInstances(t))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			records := result.(*TableContent).Records()
+
+			if got := joinedColumn(records, "InstanceId"); !slices.Equal(got, tt.wantIDs) {
+				t.Errorf("InstanceId = %v, want %v", got, tt.wantIDs)
+			}
+			if got := joinedColumn(records, "Cost"); !slices.Equal(got, tt.wantCost) {
+				t.Errorf("Cost = %v, want %v", got, tt.wantCost)
+			}
+			if got := joinedColumn(records, "Name"); !slices.Equal(got, tt.wantName) {
+				t.Errorf("Name = %v, want %v", got, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestJoinOpApply_ColumnCollisions(t *testing.T) {
+	tests := map[string]struct {
+		opts     []JoinOption
+		wantKeys []string
+	}{
+		"default right suffix": {
+			wantKeys: []string{"InstanceId", "Name", "State", "Cost", "State_right"},
+		},
+		"suffixes": {
+			opts:     []JoinOption{WithJoinSuffixes("_ec2", "_cost")},
+			wantKeys: []string{"InstanceId", "Name", "State_ec2", "Cost", "State_cost"},
+		},
+		"prefixes": {
+			opts:     []JoinOption{WithJoinPrefixes("", "cost.")},
+			wantKeys: []string{"InstanceId", "Name", "State", "Cost", "cost.State"},
+		},
+		"nil option ignored": {
+			opts:     []JoinOption{nil},
+			wantKeys: []string{"InstanceId", "Name", "State", "Cost", "State_right"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			op := NewJoinOp(joinTestCosts(t), InnerJoin, []string{"InstanceId"}, tt.opts...)
+			result, err := op.Apply(context.Background(), joinTestInstances(t))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			table := result.(*TableContent)
+			if got := table.Schema().GetKeyOrder(); !slices.Equal(got, tt.wantKeys) {
+				t.Errorf("key order = %v, want %v", got, tt.wantKeys)
+			}
+			record := table.Records()[0]
+			if record[tt.wantKeys[2]] != "running" || record[tt.wantKeys[4]] != "billed" {
+				t.Errorf("record = %v, want both State values kept", record)
+			}
+		})
+	}
+
+	t.Run("renamed column clashing with an existing one", func(t *testing.T) {
+		left, err := NewTableContent("Left", []Record{{"id": 1, "a": 1, "a_right": 2}}, WithKeys("id", "a", "a_right"))
+		if err != nil {
+			t.Fatalf("NewTableContent() error = %v", err)
+		}
+		right, err := NewTableContent("Right", []Record{{"id": 1, "a": 3}}, WithKeys("id", "a"))
+		if err != nil {
+			t.Fatalf("NewTableContent() error = %v", err)
+		}
+
+		_, err = NewJoinOp(right, InnerJoin, []string{"id"}).Apply(context.Background(), left)
+		if err == nil || !strings.Contains(err.Error(), `duplicate column "a_right"`) {
+			t.Errorf("Apply() error = %v, want duplicate column error", err)
+		}
+	})
+}
+
+func TestJoinOpApply_MultipleKeys(t *testing.T) {
+	left, err := NewTableContent("Usage", []Record{
+		{"account": "a", "region": "eu", "hours": 10},
+		{"account": "a", "region": "us", "hours": 5},
+		{"account": "b", "region": "eu", "hours": 1},
+		{"account": "b", "region": nil, "hours": 2},
+	}, WithKeys("account", "region", "hours"))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	right, err := NewTableContent("Rates", []Record{
+		{"account": "a", "region": "us", "rate": 2},
+		{"account": "b", "region": "eu", "rate": 3},
+		{"account": "b", "region": "eu", "rate": 4},
+	}, WithKeys("account", "region", "rate"))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+
+	result, err := NewJoinOp(right, LeftJoin, []string{"account", "region"}).Apply(context.Background(), left)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	table := result.(*TableContent)
+
+	if got, want := table.Schema().GetKeyOrder(), []string{"account", "region", "hours", "rate"}; !slices.Equal(got, want) {
+		t.Errorf("key order = %v, want %v", got, want)
+	}
+	// b/eu matches twice; a nil key never matches but a left join keeps the row
+	if got, want := joinedColumn(table.Records(), "rate"), []string{"", "2", "3", "4", ""}; !slices.Equal(got, want) {
+		t.Errorf("rate = %v, want %v", got, want)
+	}
+}
+
+func TestJoinOpValidation(t *testing.T) {
+	costs := joinTestCosts(t)
+
+	tests := map[string]struct {
+		op      *JoinOp
+		wantErr string
+	}{
+		"nil right table": {
+			op:      NewJoinOp(nil, InnerJoin, []string{"InstanceId"}),
+			wantErr: "requires a table to join with",
+		},
+		"no keys": {
+			op:      NewJoinOp(costs, InnerJoin, nil),
+			wantErr: "at least one key column",
+		},
+		"empty key": {
+			op:      NewJoinOp(costs, InnerJoin, []string{""}),
+			wantErr: "cannot be empty",
+		},
+		"key missing from right table": {
+			op:      NewJoinOp(costs, InnerJoin, []string{"Name"}),
+			wantErr: `"Name" does not exist in the right table`,
+		},
+		"invalid join type": {
+			op:      NewJoinOp(costs, JoinType(9), []string{"InstanceId"}),
+			wantErr: "join type must be",
+		},
+		"identical affixes": {
+			op:      NewJoinOp(costs, InnerJoin, []string{"InstanceId"}, WithJoinSuffixes("_x", "_x")),
+			wantErr: "must differ",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.op.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("key missing from left table", func(t *testing.T) {
+		left, err := NewTableContent("Left", []Record{{"id": 1}}, WithKeys("id"))
+		if err != nil {
+			t.Fatalf("NewTableContent() error = %v", err)
+		}
+		_, err = NewJoinOp(costs, InnerJoin, []string{"InstanceId"}).Apply(context.Background(), left)
+		if err == nil || !strings.Contains(err.Error(), "does not exist in the table being joined") {
+			t.Errorf("Apply() error = %v, want missing key error", err)
+		}
+	})
+
+	t.Run("nil content", func(t *testing.T) {
+		_, err := NewJoinOp(costs, InnerJoin, []string{"InstanceId"}).Apply(context.Background(), nil)
+		if err == nil || !strings.Contains(err.Error(), "join operation requires table content") {
+			t.Errorf("Apply() error = %v, want table content error", err)
+		}
+	})
+}
+
+func TestJoinOp_WithTransformations(t *testing.T) {
+	instances := joinTestInstances(t)
+	doc := New().
+		Table("Instances", instances.Records(),
+			WithKeys("InstanceId", "Name", "State"),
+			WithTransformations(NewJoinOp(joinTestCosts(t), InnerJoin, []string{"InstanceId"}))).
+		Build()
+
+	out, err := CSV().Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "InstanceId,Name,State,Cost,State_right\ni-1,web,running,12.5,billed\ni-3,cache,running,3,billed\n"
+	if string(out) != want {
+		t.Errorf("Render() = %q, want %q", out, want)
+	}
+}
+
+func TestJoinOp_StreamingTable(t *testing.T) {
+	instances := joinTestInstances(t)
+	table, err := NewStreamingTableContent("Instances", countingSeq(instances.Records(), new(int)),
+		WithKeys("InstanceId", "Name", "State"),
+		WithTransformations(NewJoinOp(joinTestCosts(t), FullJoin, []string{"InstanceId"})))
+	if err != nil {
+		t.Fatalf("NewStreamingTableContent() error = %v", err)
+	}
+
+	out, err := CSV().Renderer.Render(context.Background(), New().AddContent(table).Build())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "InstanceId,Name,State,Cost,State_right\n" +
+		"i-1,web,running,12.5,billed\n" +
+		"i-2,db,stopped,,\n" +
+		"i-3,cache,running,3,billed\n" +
+		"i-4,,,7.25,terminated\n"
+	if string(out) != want {
+		t.Errorf("Render() = %q, want %q", out, want)
+	}
+}
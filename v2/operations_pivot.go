@@ -0,0 +1,506 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// PivotOp turns the distinct values of one column into columns of their own.
+// Records are grouped by the index columns, and each (group, column value)
+// cell holds the aggregate of the value column over the matching records.
+//
+// Generated columns appear in the order their values are first seen in the
+// data, unless WithPivotColumns fixes the set and order. Records whose pivot
+// column is missing or nil are ignored. Cells without any matching records
+// hold the fill value, which is left empty unless WithPivotFill sets one.
+type PivotOp struct {
+	index   []string      // Columns identifying an output row
+	column  string        // Column whose values become columns
+	value   string        // Column passed to the aggregate function
+	agg     AggregateFunc // Aggregate applied per cell
+	fill    any           // Value for cells without records (nil = empty)
+	columns []string      // Fixed output columns (nil = discovered)
+	schema  *Schema       // Schema bound for validating columns (see bindSchema)
+}
+
+// PivotOption configures a PivotOp
+type PivotOption func(*PivotOp)
+
+// WithPivotFill sets the value used for cells that have no records
+func WithPivotFill(value any) PivotOption {
+	return func(o *PivotOp) {
+		o.fill = value
+	}
+}
+
+// WithPivotColumns fixes the generated columns and their order. Values of the
+// pivot column not listed are dropped, and listed values that never occur
+// produce a column of fill values.
+func WithPivotColumns(columns ...string) PivotOption {
+	return func(o *PivotOp) {
+		o.columns = slices.Clone(columns)
+	}
+}
+
+// NewPivotOp creates a pivot that groups by index, creates a column per
+// distinct value of column, and fills each cell with agg applied to the
+// matching records and the value column name.
+func NewPivotOp(index []string, column string, value string, agg AggregateFunc, opts ...PivotOption) *PivotOp {
+	op := &PivotOp{
+		index:  slices.Clone(index),
+		column: column,
+		value:  value,
+		agg:    agg,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(op)
+		}
+	}
+	return op
+}
+
+// Name returns the operation name
+func (o *PivotOp) Name() string {
+	return "Pivot"
+}
+
+// Apply pivots table records.
+// It returns a validation error if the operation's configuration is invalid.
+func (o *PivotOp) Apply(ctx context.Context, content Content) (Content, error) {
+	// Validate configuration first: Apply is public API and callable outside
+	// the renderer pipeline, which performs this check before applying.
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Guard against nil content before dereferencing it for the error message
+	if content == nil {
+		return nil, NewValidationError("content_type", nil,
+			"pivot operation requires table content")
+	}
+
+	// Pivoting needs every record; streaming tables must be collected first
+	if _, ok := content.(*StreamingTableContent); ok {
+		return nil, errStreamingNeedsFullSet("pivot")
+	}
+
+	// Type check
+	tableContent, ok := content.(*TableContent)
+	if !ok {
+		return nil, NewValidationError("content_type", content.Type().String(),
+			"pivot operation requires table content")
+	}
+
+	if err := o.validateColumns(tableContent.schema); err != nil {
+		return nil, err
+	}
+
+	// Clone the content to preserve immutability
+	cloned := tableContent.Clone().(*TableContent)
+	// The footer aggregates the columns this operation replaces
+	cloned.footer = nil
+
+	// Group records by index, then by pivot column value, preserving the
+	// order in which rows and columns are first seen
+	rowKeys := make([]string, 0)
+	rowFirst := make(map[string]Record)
+	cells := make(map[string]map[string][]Record)
+	discovered := make([]string, 0)
+	seenColumns := make(map[string]bool)
+	indexKey := &GroupByOp{groupBy: o.index}
+
+	for _, record := range cloned.records {
+		// Check context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		pivotVal, exists := record[o.column]
+		if !exists || pivotVal == nil {
+			continue
+		}
+		columnName := fmt.Sprintf("%v", pivotVal)
+		if !seenColumns[columnName] {
+			seenColumns[columnName] = true
+			discovered = append(discovered, columnName)
+		}
+
+		rowKey := indexKey.createGroupKey(record)
+		if _, exists := cells[rowKey]; !exists {
+			rowKeys = append(rowKeys, rowKey)
+			rowFirst[rowKey] = record
+			cells[rowKey] = make(map[string][]Record)
+		}
+		cells[rowKey][columnName] = append(cells[rowKey][columnName], record)
+	}
+
+	pivotColumns := discovered
+	if o.columns != nil {
+		pivotColumns = o.columns
+	}
+	for _, name := range pivotColumns {
+		if slices.Contains(o.index, name) {
+			return nil, NewValidationError("pivot_column", name,
+				fmt.Sprintf("pivot produces column %q, which is already an index column", name))
+		}
+	}
+
+	resultRecords := make([]Record, 0, len(rowKeys))
+	for _, rowKey := range rowKeys {
+		resultRecord := make(Record, len(o.index)+len(pivotColumns))
+
+		// Index values come from the first record of the row
+		for _, column := range o.index {
+			if val, exists := rowFirst[rowKey][column]; exists {
+				resultRecord[column] = val
+			}
+		}
+
+		for _, name := range pivotColumns {
+			if records := cells[rowKey][name]; len(records) > 0 {
+				resultRecord[name] = o.agg(records, o.value)
+			} else if o.fill != nil {
+				resultRecord[name] = o.fill
+			}
+		}
+
+		resultRecords = append(resultRecords, resultRecord)
+	}
+
+	cloned.records = resultRecords
+	cloned.schema = o.evolveSchema(cloned.schema, pivotColumns)
+	return cloned, nil
+}
+
+// evolveSchema creates the pivoted schema: the index columns, keeping their
+// original field definitions, followed by the generated columns.
+func (o *PivotOp) evolveSchema(originalSchema *Schema, pivotColumns []string) *Schema {
+	keyOrder := make([]string, 0, len(o.index)+len(pivotColumns))
+	keyOrder = append(keyOrder, o.index...)
+	keyOrder = append(keyOrder, pivotColumns...)
+
+	fields := make([]Field, 0, len(keyOrder))
+	for _, column := range o.index {
+		if originalField := findSchemaField(originalSchema, column); originalField != nil {
+			fields = append(fields, *originalField)
+			continue
+		}
+		fields = append(fields, Field{Name: column})
+	}
+	for _, name := range pivotColumns {
+		fields = append(fields, Field{Name: name})
+	}
+
+	return &Schema{
+		Fields:   fields,
+		keyOrder: keyOrder,
+	}
+}
+
+// CanOptimize returns true if this pivot can be optimized with another operation
+func (o *PivotOp) CanOptimize(with Operation) bool {
+	// Pivot cannot be combined with other operations
+	return false
+}
+
+// Validate checks if the pivot operation is valid
+func (o *PivotOp) Validate() error {
+	if o.column == "" {
+		return NewValidationError("pivot_column", o.column, "pivot operation requires a non-empty pivot column")
+	}
+	if o.agg == nil {
+		return NewValidationError("aggregate_function", nil, "pivot aggregate function is required and cannot be nil")
+	}
+	for i, column := range o.index {
+		if column == "" {
+			return NewValidationError("index_column", column, fmt.Sprintf("pivot index column at index %d cannot be empty", i))
+		}
+		if column == o.column {
+			return NewValidationError("index_column", column,
+				fmt.Sprintf("pivot column %q cannot also be an index column", column))
+		}
+	}
+	for i, name := range o.columns {
+		if name == "" {
+			return NewValidationError("pivot_columns", name, fmt.Sprintf("pivot output column at index %d cannot be empty", i))
+		}
+		if slices.Contains(o.columns[:i], name) {
+			return NewValidationError("pivot_columns", name, fmt.Sprintf("pivot output column %q is listed more than once", name))
+		}
+	}
+	return o.validateColumns(o.schema)
+}
+
+// bindSchema returns a copy of the pivot that validates against schema
+func (o *PivotOp) bindSchema(schema *Schema) Operation {
+	bound := *o
+	bound.schema = schema
+	return &bound
+}
+
+// validateColumns checks that the index, pivot and value columns exist in
+// schema. It does nothing when schema is nil.
+func (o *PivotOp) validateColumns(schema *Schema) error {
+	if schema == nil {
+		return nil
+	}
+	columns := append(slices.Clone(o.index), o.column)
+	if o.value != "" {
+		columns = append(columns, o.value)
+	}
+	return requireColumns("pivot", columns, joinSchemaColumns(schema))
+}
+
+// ApplyWithFormat applies the pivot operation with format context
+func (o *PivotOp) ApplyWithFormat(ctx context.Context, content Content, format string) (Content, error) {
+	// Pivot operations are format-agnostic, so delegate to Apply
+	return o.Apply(ctx, content)
+}
+
+// CanTransform checks if pivot operation applies to the given content and format
+func (o *PivotOp) CanTransform(content Content, format string) bool {
+	// Pivot works with table content in any format
+	return content != nil && content.Type() == ContentTypeTable
+}
+
+// UnpivotOp is the inverse of PivotOp, also known as melt: each listed column
+// becomes a row holding the column name and its value, alongside the
+// remaining (identifier) columns. Columns missing from a record produce no
+// row for it.
+type UnpivotOp struct {
+	columns     []string // Wide columns to turn into rows
+	nameColumn  string   // Output column holding the original column name
+	valueColumn string   // Output column holding the original value
+	schema      *Schema  // Schema bound for validating columns (see bindSchema)
+}
+
+// NewUnpivotOp creates an unpivot of columns into nameColumn/valueColumn
+// pairs.
+func NewUnpivotOp(columns []string, nameColumn, valueColumn string) *UnpivotOp {
+	return &UnpivotOp{
+		columns:     slices.Clone(columns),
+		nameColumn:  nameColumn,
+		valueColumn: valueColumn,
+	}
+}
+
+// Name returns the operation name
+func (o *UnpivotOp) Name() string {
+	return "Unpivot"
+}
+
+// Apply unpivots table records.
+// It returns a validation error if the operation's configuration is invalid.
+func (o *UnpivotOp) Apply(ctx context.Context, content Content) (Content, error) {
+	// Validate configuration first: Apply is public API and callable outside
+	// the renderer pipeline, which performs this check before applying.
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Guard against nil content before dereferencing it for the error message
+	if content == nil {
+		return nil, NewValidationError("content_type", nil,
+			"unpivot operation requires table content")
+	}
+
+	// Each record is unpivoted independently, so streaming tables stay lazy
+	if streaming, ok := content.(*StreamingTableContent); ok {
+		return o.applyStreaming(streaming)
+	}
+
+	// Type check
+	tableContent, ok := content.(*TableContent)
+	if !ok {
+		return nil, NewValidationError("content_type", content.Type().String(),
+			"unpivot operation requires table content")
+	}
+
+	schema, idColumns, err := o.evolveSchema(tableContent.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clone the content to preserve immutability
+	cloned := tableContent.Clone().(*TableContent)
+	// The footer aggregates the columns this operation replaces
+	cloned.footer = nil
+
+	resultRecords := make([]Record, 0, len(cloned.records)*len(o.columns))
+	for _, record := range cloned.records {
+		// Check context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		resultRecords = append(resultRecords, o.unpivotRecord(record, idColumns)...)
+	}
+
+	cloned.records = resultRecords
+	cloned.schema = schema
+	return cloned, nil
+}
+
+// applyStreaming unpivots each record as it is pulled.
+func (o *UnpivotOp) applyStreaming(content *StreamingTableContent) (*StreamingTableContent, error) {
+	schema, idColumns, err := o.evolveSchema(content.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := content.All()
+	return content.withRows(func(yield func(Record, error) bool) {
+		for record, err := range rows {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, unpivoted := range o.unpivotRecord(record, idColumns) {
+				if !yield(unpivoted, nil) {
+					return
+				}
+			}
+		}
+	}, schema), nil
+}
+
+// unpivotRecord returns one record per listed column present in record.
+func (o *UnpivotOp) unpivotRecord(record Record, idColumns []string) []Record {
+	unpivoted := make([]Record, 0, len(o.columns))
+	for _, column := range o.columns {
+		val, exists := record[column]
+		if !exists {
+			continue
+		}
+		row := make(Record, len(idColumns)+2)
+		for _, id := range idColumns {
+			if idVal, ok := record[id]; ok {
+				row[id] = idVal
+			}
+		}
+		row[o.nameColumn] = column
+		row[o.valueColumn] = val
+		unpivoted = append(unpivoted, row)
+	}
+	return unpivoted
+}
+
+// evolveSchema creates the unpivoted schema and returns it with the
+// identifier columns: every original column not being unpivoted, in key
+// order, followed by the name and value columns. The value column keeps the
+// unpivoted fields' Type when they all agree.
+func (o *UnpivotOp) evolveSchema(originalSchema *Schema) (*Schema, []string, error) {
+	columns := joinSchemaColumns(originalSchema)
+	if err := requireColumns("unpivot", o.columns, columns); err != nil {
+		return nil, nil, err
+	}
+
+	var idColumns []string
+	for _, column := range columns {
+		if slices.Contains(o.columns, column) {
+			continue
+		}
+		if column == o.nameColumn || column == o.valueColumn {
+			return nil, nil, NewValidationError("column_name", column,
+				fmt.Sprintf("unpivot output column %q already exists in the table schema", column))
+		}
+		idColumns = append(idColumns, column)
+	}
+
+	valueType := ""
+	for i, column := range o.columns {
+		fieldType := ""
+		if field := findSchemaField(originalSchema, column); field != nil {
+			fieldType = field.Type
+		}
+		if i == 0 {
+			valueType = fieldType
+		} else if fieldType != valueType {
+			valueType = ""
+			break
+		}
+	}
+
+	keyOrder := make([]string, 0, len(idColumns)+2)
+	fields := make([]Field, 0, len(idColumns)+2)
+	if originalSchema != nil {
+		for _, column := range originalSchema.GetKeyOrder() {
+			if !slices.Contains(o.columns, column) {
+				keyOrder = append(keyOrder, column)
+			}
+		}
+	}
+	for _, column := range idColumns {
+		if field := findSchemaField(originalSchema, column); field != nil {
+			fields = append(fields, *field)
+		} else {
+			fields = append(fields, Field{Name: column})
+		}
+	}
+	keyOrder = append(keyOrder, o.nameColumn, o.valueColumn)
+	fields = append(fields,
+		Field{Name: o.nameColumn, Type: fieldTypeString},
+		Field{Name: o.valueColumn, Type: valueType},
+	)
+
+	return &Schema{
+		Fields:   fields,
+		keyOrder: keyOrder,
+	}, idColumns, nil
+}
+
+// CanOptimize returns true if this unpivot can be optimized with another operation
+func (o *UnpivotOp) CanOptimize(with Operation) bool {
+	// Unpivot cannot be combined with other operations
+	return false
+}
+
+// Validate checks if the unpivot operation is valid
+func (o *UnpivotOp) Validate() error {
+	if len(o.columns) == 0 {
+		return NewValidationError("unpivot_columns", o.columns, "unpivot operation requires at least one column")
+	}
+	for i, column := range o.columns {
+		if column == "" {
+			return NewValidationError("unpivot_column", column, fmt.Sprintf("unpivot column at index %d cannot be empty", i))
+		}
+	}
+	if o.nameColumn == "" {
+		return NewValidationError("name_column", o.nameColumn, "unpivot operation requires a non-empty name column")
+	}
+	if o.valueColumn == "" {
+		return NewValidationError("value_column", o.valueColumn, "unpivot operation requires a non-empty value column")
+	}
+	if o.nameColumn == o.valueColumn {
+		return NewValidationError("value_column", o.valueColumn, "unpivot name and value columns must differ")
+	}
+	if o.schema != nil {
+		return requireColumns("unpivot", o.columns, joinSchemaColumns(o.schema))
+	}
+	return nil
+}
+
+// bindSchema returns a copy of the unpivot that validates against schema
+func (o *UnpivotOp) bindSchema(schema *Schema) Operation {
+	bound := *o
+	bound.schema = schema
+	return &bound
+}
+
+// ApplyWithFormat applies the unpivot operation with format context
+func (o *UnpivotOp) ApplyWithFormat(ctx context.Context, content Content, format string) (Content, error) {
+	// Unpivot operations are format-agnostic, so delegate to Apply
+	return o.Apply(ctx, content)
+}
+
+// CanTransform checks if unpivot operation applies to the given content and format
+func (o *UnpivotOp) CanTransform(content Content, format string) bool {
+	// Unpivot works with table content in any format
+	return content != nil && content.Type() == ContentTypeTable
+}
@@ -0,0 +1,296 @@
+package output
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func pivotTestCosts(t *testing.T) *TableContent {
+	t.Helper()
+	table, err := NewTableContent("Costs", []Record{
+		{"service": "EC2", "month": "2024-02", "cost": 10.0},
+		{"service": "EC2", "month": "2024-01", "cost": 8.0},
+		{"service": "S3", "month": "2024-01", "cost": 1.5},
+		{"service": "EC2", "month": "2024-02", "cost": 2.0},
+		{"service": "RDS", "month": nil, "cost": 99.0},
+	}, WithSchema(
+		Field{Name: "service", Type: fieldTypeString},
+		Field{Name: "month", Type: fieldTypeString},
+		Field{Name: "cost", Type: fieldTypeFloat},
+	))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	return table
+}
+
+func TestPivotOpApply(t *testing.T) {
+	tests := map[string]struct {
+		opts     []PivotOption
+		wantKeys []string
+		want     []Record
+	}{
+		"columns in first-seen order": {
+			wantKeys: []string{"service", "2024-02", "2024-01"},
+			want: []Record{
+				{"service": "EC2", "2024-02": 12.0, "2024-01": 8.0},
+				{"service": "S3", "2024-01": 1.5},
+			},
+		},
+		"fill value": {
+			opts:     []PivotOption{WithPivotFill(0.0)},
+			wantKeys: []string{"service", "2024-02", "2024-01"},
+			want: []Record{
+				{"service": "EC2", "2024-02": 12.0, "2024-01": 8.0},
+				{"service": "S3", "2024-02": 0.0, "2024-01": 1.5},
+			},
+		},
+		"fixed columns": {
+			opts:     []PivotOption{WithPivotColumns("2024-01", "2024-03"), WithPivotFill("-")},
+			wantKeys: []string{"service", "2024-01", "2024-03"},
+			want: []Record{
+				{"service": "EC2", "2024-01": 8.0, "2024-03": "-"},
+				{"service": "S3", "2024-01": 1.5, "2024-03": "-"},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			op := NewPivotOp([]string{"service"}, "month", "cost", SumAggregate("cost"), tt.opts...)
+			result, err := op.Apply(context.Background(), pivotTestCosts(t))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			table := result.(*TableContent)
+
+			if got := table.Schema().GetKeyOrder(); !slices.Equal(got, tt.wantKeys) {
+				t.Errorf("key order = %v, want %v", got, tt.wantKeys)
+			}
+			if field := table.Schema().FindField("service"); field == nil || field.Type != fieldTypeString {
+				t.Errorf("service field = %+v, want original definition kept", field)
+			}
+
+			records := table.Records()
+			if len(records) != len(tt.want) {
+				t.Fatalf("records = %v, want %v", records, tt.want)
+			}
+			for i, want := range tt.want {
+				if len(records[i]) != len(want) {
+					t.Errorf("records[%d] = %v, want %v", i, records[i], want)
+					continue
+				}
+				for key, val := range want {
+					if records[i][key] != val {
+						t.Errorf("records[%d][%q] = %v, want %v", i, key, records[i][key], val)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPivotOpApply_CountWithoutIndex(t *testing.T) {
+	op := NewPivotOp(nil, "service", "", CountAggregate())
+	result, err := op.Apply(context.Background(), pivotTestCosts(t))
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	records := result.(*TableContent).Records()
+	if len(records) != 1 || records[0]["EC2"] != 3 || records[0]["S3"] != 1 || records[0]["RDS"] != 1 {
+		t.Errorf("records = %v, want a single row of counts", records)
+	}
+}
+
+func TestPivotOpValidation(t *testing.T) {
+	sum := SumAggregate("cost")
+
+	tests := map[string]struct {
+		op      *PivotOp
+		wantErr string
+	}{
+		"empty pivot column":  {op: NewPivotOp([]string{"service"}, "", "cost", sum), wantErr: "non-empty pivot column"},
+		"nil aggregate":       {op: NewPivotOp([]string{"service"}, "month", "cost", nil), wantErr: "aggregate function is required"},
+		"empty index column":  {op: NewPivotOp([]string{""}, "month", "cost", sum), wantErr: "cannot be empty"},
+		"index is pivot":      {op: NewPivotOp([]string{"month"}, "month", "cost", sum), wantErr: "cannot also be an index column"},
+		"duplicate columns":   {op: NewPivotOp(nil, "month", "cost", sum, WithPivotColumns("a", "a")), wantErr: "listed more than once"},
+		"empty output column": {op: NewPivotOp(nil, "month", "cost", sum, WithPivotColumns("")), wantErr: "cannot be empty"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.op.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("generated column collides with index", func(t *testing.T) {
+		op := NewPivotOp([]string{"service"}, "month", "cost", sum, WithPivotColumns("service"))
+		_, err := op.Apply(context.Background(), pivotTestCosts(t))
+		if err == nil || !strings.Contains(err.Error(), "already an index column") {
+			t.Errorf("Apply() error = %v, want index collision error", err)
+		}
+	})
+
+	unknownTests := map[string]struct {
+		op      *PivotOp
+		wantErr string
+	}{
+		"unknown index column": {op: NewPivotOp([]string{"servce"}, "month", "cost", sum), wantErr: `"servce"`},
+		"unknown pivot column": {op: NewPivotOp([]string{"service"}, "mnth", "cost", sum), wantErr: `"mnth"`},
+		"unknown value column": {op: NewPivotOp([]string{"service"}, "month", "cst", sum), wantErr: `"cst"`},
+	}
+	for name, tt := range unknownTests {
+		t.Run(name, func(t *testing.T) {
+			wantErr := "pivot column " + tt.wantErr + " does not exist in the table schema. Available columns: [service month cost]"
+			if _, err := tt.op.Apply(context.Background(), pivotTestCosts(t)); err == nil || !strings.Contains(err.Error(), wantErr) {
+				t.Errorf("Apply() error = %v, want containing %q", err, wantErr)
+			}
+			bound := tt.op.bindSchema(pivotTestCosts(t).Schema())
+			if err := bound.Validate(); err == nil || !strings.Contains(err.Error(), wantErr) {
+				t.Errorf("bound Validate() error = %v, want containing %q", err, wantErr)
+			}
+		})
+	}
+
+	t.Run("unknown column in transformations", func(t *testing.T) {
+		table, err := NewTableContent("Costs", pivotTestCosts(t).Records(), WithKeys("service", "month", "cost"),
+			WithTransformations(NewPivotOp([]string{"service"}, "mnth", "cost", sum)))
+		if err != nil {
+			t.Fatalf("NewTableContent() error = %v", err)
+		}
+		_, err = CSV().Renderer.Render(context.Background(), New().AddContent(table).Build())
+		if err == nil || !strings.Contains(err.Error(), "invalid") || !strings.Contains(err.Error(), `"mnth"`) {
+			t.Errorf("Render() error = %v, want validation error naming \"mnth\"", err)
+		}
+	})
+
+	t.Run("streaming content", func(t *testing.T) {
+		table, err := NewStreamingTableContent("Costs", countingSeq(pivotTestCosts(t).Records(), new(int)), WithKeys("service", "month", "cost"))
+		if err != nil {
+			t.Fatalf("NewStreamingTableContent() error = %v", err)
+		}
+		_, err = NewPivotOp([]string{"service"}, "month", "cost", sum).Apply(context.Background(), table)
+		if err == nil || !strings.Contains(err.Error(), "Collect") {
+			t.Errorf("Apply() error = %v, want error pointing to Collect", err)
+		}
+	})
+}
+
+func TestUnpivotOpApply(t *testing.T) {
+	wide, err := NewTableContent("Wide", []Record{
+		{"service": "EC2", "jan": 8.0, "feb": 12.0},
+		{"service": "S3", "jan": 1.5},
+	}, WithSchema(
+		Field{Name: "service", Type: fieldTypeString},
+		Field{Name: "jan", Type: fieldTypeFloat},
+		Field{Name: "feb", Type: fieldTypeFloat},
+	))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+
+	result, err := NewUnpivotOp([]string{"jan", "feb"}, "month", "cost").Apply(context.Background(), wide)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	table := result.(*TableContent)
+
+	if got, want := table.Schema().GetKeyOrder(), []string{"service", "month", "cost"}; !slices.Equal(got, want) {
+		t.Errorf("key order = %v, want %v", got, want)
+	}
+	if field := table.Schema().FindField("cost"); field == nil || field.Type != fieldTypeFloat {
+		t.Errorf("cost field = %+v, want shared type float", field)
+	}
+
+	want := []Record{
+		{"service": "EC2", "month": "jan", "cost": 8.0},
+		{"service": "EC2", "month": "feb", "cost": 12.0},
+		{"service": "S3", "month": "jan", "cost": 1.5},
+	}
+	records := table.Records()
+	if len(records) != len(want) {
+		t.Fatalf("records = %v, want %v", records, want)
+	}
+	for i := range want {
+		for key, val := range want[i] {
+			if records[i][key] != val {
+				t.Errorf("records[%d][%q] = %v, want %v", i, key, records[i][key], val)
+			}
+		}
+	}
+
+	t.Run("round trip through pivot", func(t *testing.T) {
+		pivoted, err := NewPivotOp([]string{"service"}, "month", "cost", SumAggregate("cost")).Apply(context.Background(), table)
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		got := pivoted.(*TableContent).Records()
+		if got[0]["jan"] != 8.0 || got[0]["feb"] != 12.0 || got[1]["jan"] != 1.5 {
+			t.Errorf("round trip records = %v", got)
+		}
+	})
+}
+
+func TestUnpivotOpValidation(t *testing.T) {
+	tests := map[string]struct {
+		op      *UnpivotOp
+		wantErr string
+	}{
+		"no columns":        {op: NewUnpivotOp(nil, "k", "v"), wantErr: "at least one column"},
+		"empty column":      {op: NewUnpivotOp([]string{""}, "k", "v"), wantErr: "cannot be empty"},
+		"empty name column": {op: NewUnpivotOp([]string{"a"}, "", "v"), wantErr: "non-empty name column"},
+		"same output names": {op: NewUnpivotOp([]string{"a"}, "k", "k"), wantErr: "must differ"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.op.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	table, err := NewTableContent("T", []Record{{"id": 1, "a": 2}}, WithKeys("id", "a"))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	applyTests := map[string]struct {
+		op      *UnpivotOp
+		wantErr string
+	}{
+		"unknown column":        {op: NewUnpivotOp([]string{"b"}, "k", "v"), wantErr: `"b" does not exist in the table schema. Available columns: [id a]`},
+		"output column clashes": {op: NewUnpivotOp([]string{"a"}, "id", "v"), wantErr: `"id" already exists`},
+	}
+	for name, tt := range applyTests {
+		t.Run(name, func(t *testing.T) {
+			_, err := tt.op.Apply(context.Background(), table)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Apply() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnpivotOp_StreamingTable(t *testing.T) {
+	table, err := NewStreamingTableContent("Wide", countingSeq([]Record{
+		{"service": "EC2", "jan": 8, "feb": 12},
+	}, new(int)), WithKeys("service", "jan", "feb"),
+		WithTransformations(NewUnpivotOp([]string{"jan", "feb"}, "month", "cost")))
+	if err != nil {
+		t.Fatalf("NewStreamingTableContent() error = %v", err)
+	}
+
+	out, err := CSV().Renderer.Render(context.Background(), New().AddContent(table).Build())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := "service,month,cost\nEC2,jan,8\nEC2,feb,12\n"; string(out) != want {
+		t.Errorf("Render() = %q, want %q", out, want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
 	"sync"
 )
@@ -15,6 +16,8 @@ type Output struct {
 	writers      []Writer
 	progress     Progress
 
+	csvFormulaPolicy CSVFormulaPolicy
+
 	// v1 compatibility features
 	tableStyle  string
 	hasTOC      bool
@@ -152,7 +155,9 @@ func (o *Output) Render(ctx context.Context, doc *Document) error {
 
 		o.mu.RLock()
 		formats := make([]Format, len(o.formats))
-		copy(formats, o.formats)
+		for i, format := range o.formats {
+			formats[i] = applyCSVFormulaPolicy(format, o.csvFormulaPolicy)
+		}
 		writers := make([]Writer, len(o.writers))
 		copy(writers, o.writers)
 		transformers := make([]Transformer, len(o.transformers))
@@ -241,10 +246,13 @@ func (o *Output) renderWithConfig(ctx context.Context, doc *Document, formats []
 	GlobalTrace("render", "starting concurrent rendering of %d format(s)", len(formats))
 
 	// Phase 1: render and transform each format concurrently. Each goroutine
-	// writes only to its own slot of results/renderErrs, so no synchronization
-	// beyond the WaitGroup is needed.
+	// writes only to its own slot of results/terminalResults/renderErrs, so
+	// no synchronization beyond the WaitGroup is needed. Formats whose output
+	// differs on a terminal are rendered a second time for terminal writers.
 	results := make([][]byte, len(formats))
+	terminalResults := make([][]byte, len(formats))
 	renderErrs := make([]error, len(formats))
+	toTerminal := slices.ContainsFunc(writers, writesToTerminal)
 
 	var wg sync.WaitGroup
 	for i, format := range formats {
@@ -262,25 +270,20 @@ func (o *Output) renderWithConfig(ctx context.Context, doc *Document, formats []
 				GlobalTrace("render", "starting render for format: %s", f.Name)
 				progress.SetStatus(fmt.Sprintf("Rendering %s format", f.Name))
 
-				// Render the document in this format
-				data, err := f.Renderer.Render(ctx, doc)
-				if err != nil {
-					// Create a detailed render error with enhanced context
-					renderErr := NewRenderErrorWithDetails(f.Name, fmt.Sprintf("%T", f.Renderer), "render", nil, err)
-					renderErr.AddContext("renderer_type", fmt.Sprintf("%T", f.Renderer))
-					if data != nil {
-						renderErr.AddContext("data_size", len(data))
-					}
-					return renderErr
-				}
-
-				GlobalTrace("render", "rendered %s format successfully, %d bytes", f.Name, len(data))
-
-				transformed, err := o.transformFormatData(ctx, f, data, transformers, progress)
+				data, err := o.renderFormatData(ctx, doc, f, f.Renderer, transformers, progress)
 				if err != nil {
 					return err
 				}
-				results[idx] = transformed
+				results[idx] = data
+
+				if tr, ok := f.Renderer.(terminalRenderer); ok && toTerminal {
+					if terminal := tr.forTerminal(); terminal != nil {
+						GlobalTrace("render", "rendering %s format for terminal writers", f.Name)
+						if terminalResults[idx], err = o.renderFormatData(ctx, doc, f, terminal, transformers, progress); err != nil {
+							return err
+						}
+					}
+				}
 				return nil
 			})
 		}(i, format)
@@ -303,10 +306,10 @@ func (o *Output) renderWithConfig(ctx context.Context, doc *Document, formats []
 
 		// Release the slot so earlier formats' buffers can be collected while
 		// later formats are still being written (relevant for slow writers).
-		data := results[i]
-		results[i] = nil
+		data, terminalData := results[i], terminalResults[i]
+		results[i], terminalResults[i] = nil, nil
 		err := SafeExecuteWithTracer(GetGlobalDebugTracer(), fmt.Sprintf("write-%s", format.Name), func() error {
-			return o.writeFormatData(ctx, format, data, writers, progress, &workDone)
+			return o.writeFormatData(ctx, format, data, terminalData, writers, progress, &workDone)
 		})
 		if err != nil {
 			errs = append(errs, err)
@@ -364,6 +367,25 @@ func (o *Output) renderWithConfig(ctx context.Context, doc *Document, formats []
 	return nil
 }
 
+// renderFormatData renders the document with renderer, the renderer of
+// format or its terminal variant, and applies the transformers to the result
+func (o *Output) renderFormatData(ctx context.Context, doc *Document, format Format, renderer Renderer, transformers []Transformer, progress Progress) ([]byte, error) {
+	data, err := renderer.Render(ctx, doc)
+	if err != nil {
+		// Create a detailed render error with enhanced context
+		renderErr := NewRenderErrorWithDetails(format.Name, fmt.Sprintf("%T", renderer), "render", nil, err)
+		renderErr.AddContext("renderer_type", fmt.Sprintf("%T", renderer))
+		if data != nil {
+			renderErr.AddContext("data_size", len(data))
+		}
+		return nil, renderErr
+	}
+
+	GlobalTrace("render", "rendered %s format successfully, %d bytes", format.Name, len(data))
+
+	return o.transformFormatData(ctx, format, data, transformers, progress)
+}
+
 // transformFormatData applies transformers to the rendered data and returns
 // the transformed bytes. It runs inside the per-format render goroutines.
 func (o *Output) transformFormatData(ctx context.Context, format Format, data []byte, transformers []Transformer, progress Progress) ([]byte, error) {
@@ -404,9 +426,15 @@ func (o *Output) transformFormatData(ctx context.Context, format Format, data []
 // declared writer order. It is called sequentially per format (in declared
 // format order) after all renders have completed, so no synchronization is
 // needed for the workDone progress counter. A write failure stops the
-// remaining writers for this format only.
-func (o *Output) writeFormatData(ctx context.Context, format Format, data []byte, writers []Writer, progress Progress, workDone *int) error {
+// remaining writers for this format only. Writers that write to a terminal
+// get terminalData instead of data when the format rendered it.
+func (o *Output) writeFormatData(ctx context.Context, format Format, data, terminalData []byte, writers []Writer, progress Progress, workDone *int) error {
 	for _, writer := range writers {
+		data := data
+		if terminalData != nil && writesToTerminal(writer) {
+			data = terminalData
+		}
+
 		// Check for cancellation before each write
 		if IsCancelled(ctx.Err()) {
 			return NewCancelledError(fmt.Sprintf("write-%s", format.Name), ctx.Err())
@@ -433,6 +461,12 @@ func (o *Output) writeFormatData(ctx context.Context, format Format, data []byte
 	return nil
 }
 
+// writesToTerminal reports whether writer writes to a terminal
+func writesToTerminal(writer Writer) bool {
+	tw, ok := writer.(terminalWriter)
+	return ok && tw.writesToTerminal()
+}
+
 // RenderTo processes a document and writes all formats to their respective writers
 // This is a convenience method that calls Render with a background context
 func (o *Output) RenderTo(doc *Document) error {
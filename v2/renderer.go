@@ -18,6 +18,8 @@ const (
 	FormatDOT      = "dot"
 	FormatMermaid  = "mermaid"
 	FormatDrawIO   = "drawio"
+	FormatXLSX     = "xlsx"
+	FormatSVG      = "svg"
 )
 
 // Renderer converts a document to a specific format
@@ -35,6 +37,13 @@ type Renderer interface {
 	SupportsStreaming() bool
 }
 
+// terminalRenderer is implemented by renderers whose output differs when it
+// is written to a terminal. forTerminal returns the renderer to use for a
+// terminal, or nil when the output is the same either way.
+type terminalRenderer interface {
+	forTerminal() Renderer
+}
+
 // Format represents an output format configuration
 type Format struct {
 	Name     string
@@ -99,6 +108,28 @@ func DrawIO() Format {
 	return Format{Name: FormatDrawIO, Renderer: &drawioRenderer{}}
 }
 
+// XLSX returns a Format configured for Excel workbook output, with one
+// worksheet per table
+func XLSX() Format {
+	return Format{Name: FormatXLSX, Renderer: &xlsxRenderer{}}
+}
+
+// SVG returns a Format configured for SVG image output: the charts and
+// graphs of a document drawn in Go, without Mermaid.js or other tools
+func SVG() Format {
+	return Format{Name: FormatSVG, Renderer: &svgRenderer{theme: newSVGTheme(nil)}}
+}
+
+// SVGWithTheme returns an SVG Format whose colours and font are overridden
+// by the CSS custom properties in overrides, as used by
+// HTMLTemplate.ThemeOverrides: "--color-primary", "--color-text",
+// "--color-text-muted", "--color-border", "--color-background",
+// "--color-surface", "--color-error" and "--font-family", plus
+// "--chart-color-1", "--chart-color-2" and so on for the series palette
+func SVGWithTheme(overrides map[string]string) Format {
+	return Format{Name: FormatSVG, Renderer: &svgRenderer{theme: newSVGTheme(overrides)}}
+}
+
 // Table style format constructors for v1 compatibility
 
 // TableDefault returns a Format configured for terminal table output with Default style
@@ -150,6 +181,68 @@ func TableWithStyleAndMaxColumnWidth(styleName string, maxColumnWidth int) Forma
 	}
 }
 
+// TableWithAutoFit creates a table format that fits tables to the terminal
+// width, taken from $COLUMNS or the terminal stdout is attached to. When the
+// table is too wide, long cells are wrapped on word boundaries, with widths
+// shared in proportion to each column's content, and columns are dropped
+// when even narrow columns do not fit: lowest Field.Priority first, and the
+// rightmost first among equal priorities. Dropped columns are listed below
+// the table. When the width cannot be detected tables are not fitted.
+func TableWithAutoFit(styleName string) Format {
+	return TableWithAutoFitWidth(styleName, 0)
+}
+
+// TableWithAutoFitWidth creates a table format that fits tables to width
+// columns, as TableWithAutoFit does for the terminal width. A width of 0
+// detects the terminal width.
+func TableWithAutoFitWidth(styleName string, width int) Format {
+	return Format{
+		Name:     FormatTable,
+		Renderer: NewTableRendererWithAutoFit(styleName, width),
+	}
+}
+
+// TableExpanded creates a table format that prints each record as a block of
+// "Key | Value" lines under a "-[ RECORD n ]" separator, like psql's expanded
+// display. Fields follow the schema order, hidden fields are left out, and
+// CollapsibleValues show their summary and details.
+func TableExpanded(styleName string) Format {
+	return Format{
+		Name:     FormatTable,
+		Renderer: NewTableRendererExpanded(styleName),
+	}
+}
+
+// TableWithAutoExpand creates a table format that renders tables in the given
+// style and switches to expanded records (see TableExpanded) for tables wider
+// than the terminal, taken from $COLUMNS or the terminal stdout is attached
+// to. When the width cannot be detected tables are not expanded.
+func TableWithAutoExpand(styleName string) Format {
+	return TableWithAutoExpandWidth(styleName, 0)
+}
+
+// TableWithAutoExpandWidth creates a table format that switches to expanded
+// records for tables wider than width columns. A width of 0 detects the
+// terminal width.
+func TableWithAutoExpandWidth(styleName string, width int) Format {
+	return Format{
+		Name:     FormatTable,
+		Renderer: NewTableRendererWithAutoExpand(styleName, width),
+	}
+}
+
+// TableWithCharts creates a table format that draws charts with the given
+// chart style. Unicode charts use block characters coloured with scheme;
+// ASCII charts use plain characters without colour. ChartStyleAuto, which
+// Table uses, picks Unicode when RenderTo or Output writes to a terminal and
+// ASCII otherwise, including from Render.
+func TableWithCharts(styleName string, chartStyle ChartStyle, scheme ColorScheme) Format {
+	return Format{
+		Name:     FormatTable,
+		Renderer: NewTableRendererWithCharts(styleName, chartStyle, scheme),
+	}
+}
+
 // MarkdownWithToC creates a markdown format with table of contents for v1 compatibility
 func MarkdownWithToC(enabled bool) Format {
 	return Format{
@@ -200,6 +293,14 @@ func applyContentTransformations(ctx context.Context, content Content) (Content,
 				content.ID(), err)
 		}
 
+		// Expression-based operations validate their column references
+		// against the content as transformed so far
+		if binder, ok := op.(schemaBinder); ok {
+			if schema := contentSchema(current); schema != nil {
+				op = binder.bindSchema(schema)
+			}
+		}
+
 		// Validate operation configuration
 		if err := op.Validate(); err != nil {
 			return nil, fmt.Errorf("content %s transformation %d (%s) invalid: %w",
@@ -235,3 +336,12 @@ func HTMLWithTemplate(template *HTMLTemplate) Format {
 		Renderer: &htmlRenderer{useTemplate: template != nil, template: template},
 	}
 }
+
+// HTMLOffline returns a Format configured for complete HTML documents with
+// the default template and charts drawn as inline SVG (see
+// HTMLTemplate.InlineCharts), so the page needs no network access
+func HTMLOffline() Format {
+	tmpl := *DefaultHTMLTemplate
+	tmpl.InlineCharts = true
+	return HTMLWithTemplate(&tmpl)
+}
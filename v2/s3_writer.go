@@ -71,6 +71,7 @@ type S3Writer struct {
 	contentTypes  map[string]string // format to content-type mapping
 	appendMode    bool              // enable append mode (download-modify-upload pattern)
 	maxAppendSize int64             // maximum object size for append operations (default 100MB)
+	csvDialect    csvDialect        // dialect of appended CSV data
 }
 
 // NewS3Writer creates a new S3Writer that works with AWS SDK v2 s3.Client.
@@ -265,6 +266,10 @@ func (sw *S3Writer) combineData(format string, existing, new []byte) ([]byte, er
 		return sw.combineHTMLData(existing, new)
 	case FormatCSV:
 		return sw.combineCSVData(existing, new)
+	case FormatXLSX, FormatSVG:
+		// An xlsx object is a zip archive and an SVG image has a single root
+		// element; appended bytes would corrupt either
+		return nil, fmt.Errorf("append to %s objects is not supported", format)
 	default:
 		// Byte-level append
 		return append(existing, new...), nil
@@ -289,23 +294,20 @@ func (sw *S3Writer) combineHTMLData(existing, new []byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// combineCSVData combines CSV content by stripping headers from new data
+// combineCSVData combines CSV content by stripping headers from new data.
+// The header, byte order mark and line endings are handled per the
+// WithS3CSVDialect dialect.
 func (sw *S3Writer) combineCSVData(existing, new []byte) ([]byte, error) {
-	// Normalize line endings (handle both LF and CRLF)
-	new = bytes.ReplaceAll(new, []byte("\r\n"), []byte("\n"))
-
-	// Strip first line (header) from new data
-	lines := bytes.SplitN(new, []byte("\n"), 2)
-	if len(lines) < 2 {
-		// Only one line (or empty) - nothing to append after removing header
+	// Strip the header line (and byte order mark) from new data
+	dataWithoutHeader := sw.csvDialect.appendRows(new)
+	if len(dataWithoutHeader) == 0 {
+		// Nothing to append after removing the header
 		return existing, nil
 	}
 
-	dataWithoutHeader := lines[1]
-
 	// Ensure existing data ends with newline before appending
 	if len(existing) > 0 && existing[len(existing)-1] != '\n' {
-		return append(append(existing, '\n'), dataWithoutHeader...), nil
+		existing = append(existing, sw.csvDialect.lineEnding()...)
 	}
 
 	return append(existing, dataWithoutHeader...), nil
@@ -364,6 +366,8 @@ func defaultContentTypes() map[string]string {
 		FormatDOT:      "text/vnd.graphviz",
 		FormatMermaid:  "text/plain",
 		FormatDrawIO:   "text/csv",
+		FormatXLSX:     "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		FormatSVG:      "image/svg+xml",
 	}
 }
 
@@ -410,6 +414,15 @@ func WithContentTypes(contentTypes map[string]string) S3WriterOption {
 	}
 }
 
+// WithS3CSVDialect sets the CSV dialect of data appended in append mode, so
+// appending matches output rendered with CSVWithOptions and the same options.
+// See WithCSVDialect for how the dialect affects appended data.
+func WithS3CSVDialect(opts ...CSVOption) S3WriterOption {
+	return func(sw *S3Writer) {
+		sw.csvDialect = newCSVDialect(opts...)
+	}
+}
+
 // NewS3WriterWithOptions creates an S3Writer with options.
 // Nil options are ignored.
 func NewS3WriterWithOptions(client S3PutObjectAPI, bucket, keyPattern string, opts ...S3WriterOption) *S3Writer {
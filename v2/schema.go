@@ -4,7 +4,9 @@ import "slices"
 
 // Schema defines table structure with explicit key ordering
 type Schema struct {
-	Fields   []Field
+	Fields []Field
+	// Groups place columns under shared headers (see ColumnGroup)
+	Groups   []ColumnGroup
 	keyOrder []string // Preserves exact key order
 }
 
@@ -14,6 +16,15 @@ type Field struct {
 	Type      string
 	Formatter func(any) any // Enhanced to support CollapsibleValue returns
 	Hidden    bool
+	Priority  int // Auto-fit table output drops lower priority columns first
+	// StyleRules conditionally style the field's cells; the first match wins
+	StyleRules []StyleRule
+	// Align sets the column alignment in table, Markdown and HTML output
+	Align Alignment
+	// MinWidth and MaxWidth bound the column width in characters in table
+	// and HTML output; 0 leaves the width unbounded
+	MinWidth int
+	MaxWidth int
 }
 
 // GetKeyOrder returns a copy of the preserved key order for the schema.
@@ -97,13 +108,15 @@ func (s *Schema) GetFieldNames() []string {
 }
 
 // clone returns a defensive copy of the schema with independent slices.
-// Field values are copied (formatters are shared, as functions are immutable).
+// Field values are copied (formatters are shared, as functions are immutable)
+// and column groups are copied deeply.
 func (s *Schema) clone() *Schema {
 	if s == nil {
 		return nil
 	}
 	return &Schema{
 		Fields:   slices.Clone(s.Fields),
+		Groups:   cloneColumnGroups(s.Groups),
 		keyOrder: slices.Clone(s.keyOrder),
 	}
 }
@@ -64,6 +64,13 @@ func (sw *StdoutWriter) Write(ctx context.Context, format string, data []byte) e
 	return nil
 }
 
+// writesToTerminal implements terminalWriter
+func (sw *StdoutWriter) writesToTerminal() bool {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return isTerminal(sw.writer)
+}
+
 // SetWriter sets a custom writer (useful for testing).
 // A nil writer — untyped or a typed nil such as (*bytes.Buffer)(nil) — is
 // ignored so the existing writer is kept, preventing a nil pointer
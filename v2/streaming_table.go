@@ -0,0 +1,327 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// StreamingTableContent is tabular content whose records are pulled from an
+// iterator at render time instead of being held in memory. It is intended for
+// datasets too large to materialize as a []Record.
+//
+// The CSV, JSON, Markdown and table renderers write rows as they arrive when
+// used through Renderer.RenderTo. Output.Render buffers each rendered format
+// before handing it to writers, so for unbounded data call the renderer's
+// RenderTo directly with the destination writer. Other renderers collect the
+// records into a TableContent before rendering.
+//
+// The row sequence is iterated once per render. A sequence that can only be
+// consumed once (for example one reading from a network cursor) therefore
+// supports a single render of a single format.
+//
+// Operations that stream — FilterOp, LimitOp and AddColumnOp — are applied
+// lazily as rows are pulled. SortOp and GroupByOp need the full record set and
+// reject streaming content; call Collect to materialize the table first.
+type StreamingTableContent struct {
+	id              string
+	title           string
+	schema          *Schema
+	rows            iter.Seq2[Record, error]
+	transformations []Operation
+}
+
+// NewStreamingTableContent creates streaming table content from a record
+// sequence.
+//
+// The schema cannot be detected without consuming the sequence, so WithSchema
+// or WithKeys (or WithAutoSchemaOrdered) is required. Records are copied as
+// they are pulled, so the sequence may reuse its maps between iterations.
+func NewStreamingTableContent(title string, rows iter.Seq[Record], opts ...TableOption) (*StreamingTableContent, error) {
+	if rows == nil {
+		return nil, fmt.Errorf("streaming table %q requires a non-nil record sequence", title)
+	}
+	return NewStreamingTableContentWithErrors(title, func(yield func(Record, error) bool) {
+		for record := range rows {
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}, opts...)
+}
+
+// NewStreamingTableContentWithErrors creates streaming table content from a
+// sequence that can fail part-way through. A non-nil error from the sequence
+// stops rendering and is returned by the renderer; rows already written stay
+// written.
+func NewStreamingTableContentWithErrors(title string, rows iter.Seq2[Record, error], opts ...TableOption) (*StreamingTableContent, error) {
+	if rows == nil {
+		return nil, fmt.Errorf("streaming table %q requires a non-nil record sequence", title)
+	}
+
+	tc := ApplyTableOptions(opts...)
+	if tc.footer != nil {
+		return nil, fmt.Errorf("streaming table %q does not support WithFooter: footer aggregates need every record", title)
+	}
+
+	var schema *Schema
+	switch {
+	case tc.schema != nil:
+		schema = tc.schema
+	case len(tc.keys) > 0:
+		schema = NewSchemaFromKeys(tc.keys)
+	default:
+		return nil, fmt.Errorf("streaming table %q requires WithSchema or WithKeys: records cannot be inspected before rendering", title)
+	}
+	if err := applyStyleRules(schema, tc.styleRules); err != nil {
+		return nil, err
+	}
+	if err := applyColumnGroups(schema, tc.columnGroups); err != nil {
+		return nil, err
+	}
+
+	return &StreamingTableContent{
+		id:              GenerateID(),
+		title:           title,
+		schema:          schema,
+		rows:            rows,
+		transformations: tc.transformations,
+	}, nil
+}
+
+// Type returns the content type. Streaming tables are tables, so operations
+// and transformers that target ContentTypeTable apply to them.
+func (s *StreamingTableContent) Type() ContentType {
+	return ContentTypeTable
+}
+
+// ID returns the unique identifier for this content
+func (s *StreamingTableContent) ID() string {
+	return s.id
+}
+
+// Title returns the table title
+func (s *StreamingTableContent) Title() string {
+	return s.title
+}
+
+// Schema returns a defensive copy of the table schema.
+func (s *StreamingTableContent) Schema() *Schema {
+	return s.schema.clone()
+}
+
+// getSchema returns the schema without copying, for renderers.
+func (s *StreamingTableContent) getSchema() *Schema {
+	return s.schema
+}
+
+// All returns the record sequence. Each record is a copy, so callers may
+// modify it freely. Iteration stops at the first error from the underlying
+// sequence.
+func (s *StreamingTableContent) All() iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		for record, err := range s.rows {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(copyRecord(record), nil) {
+				return
+			}
+		}
+	}
+}
+
+// Collect consumes the sequence and returns a TableContent holding every
+// record, with the same ID, title, schema and transformations. Use it before
+// applying operations that need the full record set, such as SortOp.
+func (s *StreamingTableContent) Collect(ctx context.Context) (*TableContent, error) {
+	var records []Record
+	for record, err := range s.All() {
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return &TableContent{
+		id:              s.id,
+		title:           s.title,
+		schema:          s.schema.clone(),
+		records:         records,
+		transformations: s.cloneTransformations(),
+	}, nil
+}
+
+// Clone creates a copy of the content. The record sequence is shared: it is
+// a function and is never modified.
+func (s *StreamingTableContent) Clone() Content {
+	return &StreamingTableContent{
+		id:              s.id,
+		title:           s.title,
+		schema:          s.schema.clone(),
+		rows:            s.rows,
+		transformations: s.cloneTransformations(),
+	}
+}
+
+// withRows returns a copy of the content reading from rows, used by
+// operations that transform the sequence lazily.
+func (s *StreamingTableContent) withRows(rows iter.Seq2[Record, error], schema *Schema) *StreamingTableContent {
+	return &StreamingTableContent{
+		id:              s.id,
+		title:           s.title,
+		schema:          schema,
+		rows:            rows,
+		transformations: s.cloneTransformations(),
+	}
+}
+
+// cloneTransformations shallow-copies the transformation slice (operations
+// are shared, matching TableContent.Clone).
+func (s *StreamingTableContent) cloneTransformations() []Operation {
+	if len(s.transformations) == 0 {
+		return nil
+	}
+	transformations := make([]Operation, len(s.transformations))
+	copy(transformations, s.transformations)
+	return transformations
+}
+
+// GetTransformations returns the transformations attached to this table
+func (s *StreamingTableContent) GetTransformations() []Operation {
+	if s.transformations == nil {
+		return []Operation{}
+	}
+	return s.transformations
+}
+
+// AppendText implements encoding.TextAppender using the same tab-separated
+// layout as TableContent. It consumes the sequence.
+func (s *StreamingTableContent) AppendText(b []byte) ([]byte, error) {
+	keyOrder := s.schema.GetKeyOrder()
+
+	if s.title != "" {
+		b = append(b, s.title...)
+		b = append(b, '\n')
+	}
+
+	for i, key := range keyOrder {
+		if i > 0 {
+			b = append(b, '\t')
+		}
+		b = append(b, key...)
+	}
+	b = append(b, '\n')
+
+	for record, err := range s.All() {
+		if err != nil {
+			return b, err
+		}
+		for i, key := range keyOrder {
+			if i > 0 {
+				b = append(b, '\t')
+			}
+			if val, ok := record[key]; ok {
+				if field := s.schema.FindField(key); field != nil && field.Formatter != nil {
+					val = field.Formatter(val)
+				}
+				b = append(b, formatValue(val)...)
+			}
+		}
+		b = append(b, '\n')
+	}
+
+	return b, nil
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (s *StreamingTableContent) AppendBinary(b []byte) ([]byte, error) {
+	return s.AppendText(b)
+}
+
+// errStreamingNeedsFullSet builds the error returned by operations that need
+// every record before producing output.
+func errStreamingNeedsFullSet(operation string) error {
+	return NewValidationError("content_type", "streaming table",
+		operation+" operation requires the full record set and cannot be applied to streaming table content; call StreamingTableContent.Collect first")
+}
+
+// applyStreaming filters the sequence lazily.
+func (o *FilterOp) applyStreaming(content *StreamingTableContent) *StreamingTableContent {
+	rows := content.All()
+	return content.withRows(func(yield func(Record, error) bool) {
+		for record, err := range rows {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			keep, err := o.matches(record)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if keep && !yield(record, nil) {
+				return
+			}
+		}
+	}, content.schema.clone())
+}
+
+// applyStreaming stops pulling from the sequence once count rows have been
+// yielded, so a limit on an unbounded sequence terminates.
+func (o *LimitOp) applyStreaming(content *StreamingTableContent) *StreamingTableContent {
+	rows := content.All()
+	return content.withRows(func(yield func(Record, error) bool) {
+		if o.count == 0 {
+			return
+		}
+		yielded := 0
+		for record, err := range rows {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(record, nil) {
+				return
+			}
+			yielded++
+			if yielded >= o.count {
+				return
+			}
+		}
+	}, content.schema.clone())
+}
+
+// applyStreaming computes the new column as each row is pulled.
+func (o *AddColumnOp) applyStreaming(content *StreamingTableContent) (*StreamingTableContent, error) {
+	if content.schema != nil && content.schema.HasField(o.name) {
+		return nil, NewValidationError("column_name", o.name,
+			fmt.Sprintf("addColumn cannot add column %q because it already exists in the table schema", o.name))
+	}
+	if err := validateExpressionFields("column_expression", content.schema, o.expr); err != nil {
+		return nil, err
+	}
+
+	rows := content.All()
+	return content.withRows(func(yield func(Record, error) bool) {
+		for record, err := range rows {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			value, err := o.compute(record)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			record[o.name] = value
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}, o.evolveSchema(content.schema)), nil
+}
@@ -0,0 +1,270 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+)
+
+// streamingTestRecords is the fixture shared by the streaming table tests.
+func streamingTestRecords() []Record {
+	return []Record{
+		{"name": "alpha", "count": 1, "active": true},
+		{"name": "beta", "count": 2, "active": false},
+		{"name": "gamma", "count": 3, "active": true},
+	}
+}
+
+func streamingTestKeys() TableOption {
+	return WithKeys("name", "count", "active")
+}
+
+// countingSeq yields records and reports how many were pulled.
+func countingSeq(records []Record, pulled *int) iter.Seq[Record] {
+	return func(yield func(Record) bool) {
+		for _, record := range records {
+			*pulled++
+			if !yield(record) {
+				return
+			}
+		}
+	}
+}
+
+// infiniteSeq yields numbered records forever.
+func infiniteSeq() iter.Seq[Record] {
+	return func(yield func(Record) bool) {
+		for i := 0; ; i++ {
+			if !yield(Record{"n": i}) {
+				return
+			}
+		}
+	}
+}
+
+func TestNewStreamingTableContent_Validation(t *testing.T) {
+	rows := countingSeq(streamingTestRecords(), new(int))
+
+	tests := map[string]struct {
+		rows    iter.Seq[Record]
+		opts    []TableOption
+		wantErr string
+	}{
+		"requires schema or keys": {rows: rows, wantErr: "requires WithSchema or WithKeys"},
+		"requires sequence":       {rows: nil, opts: []TableOption{streamingTestKeys()}, wantErr: "non-nil record sequence"},
+		"keys accepted":           {rows: rows, opts: []TableOption{streamingTestKeys()}},
+		"schema accepted":         {rows: rows, opts: []TableOption{WithSchema(Field{Name: "name"})}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewStreamingTableContent("Items", tt.rows, tt.opts...)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("NewStreamingTableContent() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewStreamingTableContent() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestStreamingTable_MatchesTableContent verifies each streaming renderer
+// produces the same bytes as the materialized table, through both Render and
+// RenderTo.
+func TestStreamingTable_MatchesTableContent(t *testing.T) {
+	formats := map[string]Format{
+		"csv":      CSV(),
+		"json":     JSON(),
+		"markdown": Markdown(),
+		"table":    Table(),
+		"yaml":     YAML(),
+		"html":     HTMLFragment(),
+	}
+
+	for name, format := range formats {
+		t.Run(name, func(t *testing.T) {
+			want, err := format.Renderer.Render(context.Background(), New().
+				Text("Inventory").
+				Table("Items", streamingTestRecords(), streamingTestKeys()).
+				Build())
+			if err != nil {
+				t.Fatalf("Render(TableContent) error = %v", err)
+			}
+
+			doc := New().
+				Text("Inventory").
+				StreamingTable("Items", countingSeq(streamingTestRecords(), new(int)), streamingTestKeys()).
+				Build()
+
+			got, err := format.Renderer.Render(context.Background(), doc)
+			if err != nil {
+				t.Fatalf("Render(StreamingTableContent) error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+			}
+
+			var buf bytes.Buffer
+			if err := format.Renderer.RenderTo(context.Background(), doc, &buf); err != nil {
+				t.Fatalf("RenderTo() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("RenderTo() =\n%s\nwant\n%s", buf.Bytes(), want)
+			}
+		})
+	}
+}
+
+// TestStreamingTable_MarkdownWritesRowsAsTheyArrive checks that a row is on
+// the writer before the next one is pulled from the sequence.
+func TestStreamingTable_MarkdownWritesRowsAsTheyArrive(t *testing.T) {
+	var buf bytes.Buffer
+	rows := func(yield func(Record) bool) {
+		if !yield(Record{"name": "first"}) {
+			return
+		}
+		if !strings.Contains(buf.String(), "| first |") {
+			t.Errorf("first row not written before the second was pulled; output so far:\n%s", buf.String())
+		}
+		yield(Record{"name": "second"})
+	}
+
+	doc := New().StreamingTable("", rows, WithKeys("name")).Build()
+	if err := Markdown().Renderer.RenderTo(context.Background(), doc, &buf); err != nil {
+		t.Fatalf("RenderTo() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "| second |") {
+		t.Errorf("output missing second row:\n%s", buf.String())
+	}
+}
+
+func TestStreamingTable_SequenceError(t *testing.T) {
+	failure := errors.New("scanner failed")
+	rows := func(yield func(Record, error) bool) {
+		if !yield(Record{"name": "ok"}, nil) {
+			return
+		}
+		yield(nil, failure)
+	}
+
+	table, err := NewStreamingTableContentWithErrors("Items", rows, WithKeys("name"))
+	if err != nil {
+		t.Fatalf("NewStreamingTableContentWithErrors() error = %v", err)
+	}
+	doc := New().AddContent(table).Build()
+
+	for _, format := range []Format{CSV(), JSON(), Markdown(), Table(), YAML()} {
+		t.Run(format.Name, func(t *testing.T) {
+			err := format.Renderer.RenderTo(context.Background(), doc, &bytes.Buffer{})
+			if !errors.Is(err, failure) {
+				t.Errorf("RenderTo() error = %v, want %v", err, failure)
+			}
+		})
+	}
+}
+
+func TestStreamingTable_Operations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("filter and limit stop an unbounded sequence", func(t *testing.T) {
+		table, err := NewStreamingTableContent("Numbers", infiniteSeq(), WithKeys("n"),
+			WithTransformations(
+				NewFilterOp(func(r Record) bool { return r["n"].(int)%2 == 0 }),
+				NewLimitOp(3),
+			))
+		if err != nil {
+			t.Fatalf("NewStreamingTableContent() error = %v", err)
+		}
+
+		out, err := CSV().Renderer.Render(ctx, New().AddContent(table).Build())
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if want := "n\n0\n2\n4\n"; string(out) != want {
+			t.Errorf("Render() = %q, want %q", out, want)
+		}
+	})
+
+	t.Run("add column computes values lazily", func(t *testing.T) {
+		pulled := 0
+		table, err := NewStreamingTableContent("Items", countingSeq(streamingTestRecords(), &pulled), WithKeys("name"),
+			WithTransformations(NewAddColumnOp("upper", func(r Record) any {
+				return strings.ToUpper(r["name"].(string))
+			}, nil)))
+		if err != nil {
+			t.Fatalf("NewStreamingTableContent() error = %v", err)
+		}
+
+		if _, err := applyContentTransformations(ctx, table); err != nil {
+			t.Fatalf("applyContentTransformations() error = %v", err)
+		}
+		if pulled != 0 {
+			t.Errorf("pulled %d rows while applying operations, want 0", pulled)
+		}
+
+		out, err := CSV().Renderer.Render(ctx, New().AddContent(table).Build())
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if want := "name,upper\nalpha,ALPHA\nbeta,BETA\ngamma,GAMMA\n"; string(out) != want {
+			t.Errorf("Render() = %q, want %q", out, want)
+		}
+	})
+
+	t.Run("operations needing the full set reject streaming content", func(t *testing.T) {
+		table, err := NewStreamingTableContent("Items", countingSeq(streamingTestRecords(), new(int)), streamingTestKeys())
+		if err != nil {
+			t.Fatalf("NewStreamingTableContent() error = %v", err)
+		}
+
+		ops := []Operation{
+			NewSortOp(SortKey{Column: "name", Direction: Ascending}),
+			NewGroupByOp([]string{"active"}, map[string]AggregateFunc{"total": CountAggregate()}),
+		}
+		for _, op := range ops {
+			_, err := op.Apply(ctx, table)
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), "Collect") {
+				t.Errorf("%s.Apply() error = %v, want validation error pointing to Collect", op.Name(), err)
+			}
+		}
+	})
+
+	t.Run("collect allows sorting", func(t *testing.T) {
+		table, err := NewStreamingTableContent("Items", countingSeq(streamingTestRecords(), new(int)), streamingTestKeys())
+		if err != nil {
+			t.Fatalf("NewStreamingTableContent() error = %v", err)
+		}
+		collected, err := table.Collect(ctx)
+		if err != nil {
+			t.Fatalf("Collect() error = %v", err)
+		}
+
+		sorted, err := NewSortOp(SortKey{Column: "count", Direction: Descending}).Apply(ctx, collected)
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if first := sorted.(*TableContent).Records()[0]["name"]; first != "gamma" {
+			t.Errorf("first record name = %v, want gamma", first)
+		}
+	})
+}
+
+func TestBuilderStreamingTable_RecordsError(t *testing.T) {
+	builder := New()
+	builder.StreamingTable("Items", countingSeq(streamingTestRecords(), new(int)))
+
+	if !builder.HasErrors() {
+		t.Fatal("expected an error for a streaming table without a schema")
+	}
+	if got := len(builder.Build().GetContents()); got != 0 {
+		t.Errorf("len(GetContents()) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,481 @@
+package output
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// structTagName is the struct tag key read by NewTableContent for struct data.
+const structTagName = "output"
+
+// Field type names derived from Go types for struct data. DetectType uses the
+// same vocabulary for map values; "time" is only produced for time.Time.
+const (
+	fieldTypeString    = "string"
+	fieldTypeInt       = "int"
+	fieldTypeUint      = "uint"
+	fieldTypeFloat     = "float"
+	fieldTypeBool      = "bool"
+	fieldTypeTime      = "time"
+	fieldTypeInterface = "interface"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// structField describes how one column is read from a struct value. index is
+// the field index path from the outer struct, which crosses embedded structs
+// for promoted fields.
+type structField struct {
+	index     []int
+	field     Field
+	format    string // named formatter from the tag, resolved per table
+	order     int
+	hasOrder  bool
+	depth     int
+	marshaler bool // value is converted to string via encoding.TextMarshaler
+}
+
+// structPlan is the reflected column layout for a struct type. Plans only
+// depend on the type, so they are computed once and cached.
+type structPlan struct {
+	fields []structField
+}
+
+// structPlans caches a *structPlan (or the error building it) per struct type.
+var structPlans sync.Map // map[reflect.Type]structPlanResult
+
+type structPlanResult struct {
+	plan *structPlan
+	err  error
+}
+
+// structElemType reports the struct type of a []T or []*T value, or nil when
+// data is not a slice of structs. time.Time is a struct but is a scalar for
+// table purposes, so []time.Time is not treated as struct data.
+func structElemType(data any) reflect.Type {
+	if data == nil {
+		return nil
+	}
+	t := reflect.TypeOf(data)
+	if t.Kind() != reflect.Slice {
+		return nil
+	}
+	elem := t.Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct || elem == timeType {
+		return nil
+	}
+	return elem
+}
+
+// planForStruct returns the cached column plan for a struct type.
+func planForStruct(t reflect.Type) (*structPlan, error) {
+	if cached, ok := structPlans.Load(t); ok {
+		result := cached.(structPlanResult)
+		return result.plan, result.err
+	}
+	plan, err := buildStructPlan(t)
+	structPlans.Store(t, structPlanResult{plan: plan, err: err})
+	return plan, err
+}
+
+// buildStructPlan walks the exported fields of t and resolves the columns.
+//
+// Embedded structs without a tag name are flattened, and their fields are
+// promoted the way encoding/json promotes them: a shallower field shadows a
+// deeper one with the same name, and among fields at the same depth the first
+// declared wins. Columns with an explicit order= come first, sorted by order;
+// the rest follow in declaration order.
+func buildStructPlan(t reflect.Type) (*structPlan, error) {
+	var collected []structField
+	if err := collectStructFields(t, nil, 0, &collected, map[reflect.Type]bool{}); err != nil {
+		return nil, err
+	}
+
+	// Resolve name collisions: the shallowest field wins, then the first
+	// declared. The winner keeps its own declaration position.
+	dominant := make(map[string]int, len(collected))
+	for i, sf := range collected {
+		if pos, exists := dominant[sf.field.Name]; !exists || sf.depth < collected[pos].depth {
+			dominant[sf.field.Name] = i
+		}
+	}
+	kept := make([]structField, 0, len(dominant))
+	for i, sf := range collected {
+		if dominant[sf.field.Name] == i {
+			kept = append(kept, sf)
+		}
+	}
+
+	slices.SortStableFunc(kept, func(a, b structField) int {
+		switch {
+		case a.hasOrder && b.hasOrder:
+			return a.order - b.order
+		case a.hasOrder:
+			return -1
+		case b.hasOrder:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return &structPlan{fields: kept}, nil
+}
+
+// collectStructFields appends the columns of t to out. visiting guards
+// against embedding cycles through pointers.
+func collectStructFields(t reflect.Type, prefix []int, depth int, out *[]structField, visiting map[reflect.Type]bool) error {
+	if visiting[t] {
+		return nil
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get(structTagName)
+		if tag == "-" {
+			continue
+		}
+
+		index := append(slices.Clone(prefix), i)
+		opts, err := parseStructTag(tag)
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", t.Name(), sf.Name, err)
+		}
+
+		if sf.Anonymous {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			// Flatten embedded structs unless the tag names them, in which
+			// case they are a regular column holding the struct value.
+			if embedded.Kind() == reflect.Struct && embedded != timeType && opts.name == "" {
+				if err := collectStructFields(embedded, index, depth+1, out, visiting); err != nil {
+					return err
+				}
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+		} else if !sf.IsExported() {
+			continue
+		}
+
+		name := opts.name
+		if name == "" {
+			name = sf.Name
+		}
+
+		fieldType := opts.fieldType
+		marshaler := isTextMarshaler(sf.Type)
+		if fieldType == "" {
+			fieldType = structFieldType(sf.Type, marshaler)
+		}
+
+		*out = append(*out, structField{
+			index: index,
+			field: Field{
+				Name:     name,
+				Type:     fieldType,
+				Hidden:   opts.hidden,
+				Priority: opts.priority,
+				Align:    opts.align,
+				MinWidth: opts.minWidth,
+				MaxWidth: opts.maxWidth,
+			},
+			format:    opts.format,
+			order:     opts.order,
+			hasOrder:  opts.hasOrder,
+			depth:     depth,
+			marshaler: marshaler,
+		})
+	}
+	return nil
+}
+
+// structTagOptions holds the parsed contents of an `output:"..."` tag.
+type structTagOptions struct {
+	name      string
+	hidden    bool
+	order     int
+	hasOrder  bool
+	format    string
+	fieldType string
+	priority  int
+	align     Alignment
+	minWidth  int
+	maxWidth  int
+}
+
+// parseStructTag parses `output:"name,hidden,order=3,format=bytes,type=int,priority=-1"`,
+// along with `align=right`, `minwidth=8` and `maxwidth=40`.
+// The first element is the column name (empty keeps the Go field name);
+// the remaining elements are options in any order.
+func parseStructTag(tag string) (structTagOptions, error) {
+	var opts structTagOptions
+	if tag == "" {
+		return opts, nil
+	}
+
+	parts := strings.Split(tag, ",")
+	opts.name = strings.TrimSpace(parts[0])
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		key, value, hasValue := strings.Cut(part, "=")
+		switch key {
+		case "":
+			continue
+		case "hidden":
+			if hasValue {
+				return opts, fmt.Errorf("tag option %q takes no value", key)
+			}
+			opts.hidden = true
+		case "order":
+			order, err := strconv.Atoi(value)
+			if !hasValue || err != nil {
+				return opts, fmt.Errorf("tag option order requires an integer value, got %q", value)
+			}
+			opts.order = order
+			opts.hasOrder = true
+		case "priority":
+			priority, err := strconv.Atoi(value)
+			if !hasValue || err != nil {
+				return opts, fmt.Errorf("tag option priority requires an integer value, got %q", value)
+			}
+			opts.priority = priority
+		case "align":
+			if !slices.Contains(validAlignments, Alignment(value)) {
+				return opts, fmt.Errorf("tag option align requires left, center, right or auto, got %q", value)
+			}
+			opts.align = Alignment(value)
+		case "minwidth", "maxwidth":
+			width, err := strconv.Atoi(value)
+			if !hasValue || err != nil || width < 0 {
+				return opts, fmt.Errorf("tag option %s requires a non-negative integer value, got %q", key, value)
+			}
+			if key == "minwidth" {
+				opts.minWidth = width
+			} else {
+				opts.maxWidth = width
+			}
+		case "format":
+			if value == "" {
+				return opts, fmt.Errorf("tag option format requires a formatter name")
+			}
+			opts.format = value
+		case "type":
+			if value == "" {
+				return opts, fmt.Errorf("tag option type requires a type name")
+			}
+			opts.fieldType = value
+		default:
+			return opts, fmt.Errorf("unknown tag option %q", part)
+		}
+	}
+	return opts, nil
+}
+
+// isTextMarshaler reports whether values of t are rendered through
+// encoding.TextMarshaler. time.Time is excluded: it stays a time.Time so
+// renderers and SortOp can treat it as a timestamp.
+func isTextMarshaler(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return false
+	}
+	return t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// structFieldType maps a Go field type to the Field.Type vocabulary used by
+// DetectType. Pointer fields take the type of their element.
+func structFieldType(t reflect.Type, marshaler bool) string {
+	if marshaler {
+		return fieldTypeString
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return fieldTypeTime
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return fieldTypeString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fieldTypeInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fieldTypeUint
+	case reflect.Float32, reflect.Float64:
+		return fieldTypeFloat
+	case reflect.Bool:
+		return fieldTypeBool
+	default:
+		return fieldTypeInterface
+	}
+}
+
+// schema builds the table schema for the plan, resolving named formatters
+// against the table's formatter set.
+func (p *structPlan) schema(formatters map[string]func(any) any) (*Schema, error) {
+	fields := p.fieldsWithoutFormatters()
+	for i, sf := range p.fields {
+		if sf.format == "" {
+			continue
+		}
+		formatter, ok := lookupFieldFormatter(sf.format, formatters)
+		if !ok {
+			return nil, fmt.Errorf("field %q: unknown formatter %q", sf.field.Name, sf.format)
+		}
+		fields[i].Formatter = formatter
+	}
+	return NewSchemaFromFields(fields), nil
+}
+
+// fieldsWithoutFormatters returns the plan's fields with named formatters
+// left unresolved.
+func (p *structPlan) fieldsWithoutFormatters() []Field {
+	fields := make([]Field, len(p.fields))
+	for i, sf := range p.fields {
+		fields[i] = sf.field
+	}
+	return fields
+}
+
+// records converts a []T or []*T value into records. Nil elements of a []*T
+// slice are skipped.
+func (p *structPlan) records(data reflect.Value) ([]Record, error) {
+	records := make([]Record, 0, data.Len())
+	for i := range data.Len() {
+		elem := data.Index(i)
+		if elem.Kind() == reflect.Pointer {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+
+		record := make(Record, len(p.fields))
+		for _, sf := range p.fields {
+			value, err := sf.value(elem)
+			if err != nil {
+				return nil, fmt.Errorf("row %d, field %q: %w", i, sf.field.Name, err)
+			}
+			record[sf.field.Name] = value
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// value reads the column from a struct value. A nil pointer anywhere on the
+// path — an unset pointer field or a nil embedded struct pointer — yields nil.
+// Non-nil pointers are dereferenced so renderers see the value, not an
+// address, and TextMarshaler values are converted to their text form.
+func (sf *structField) value(v reflect.Value) (any, error) {
+	for _, idx := range sf.index {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil, nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+
+	if sf.marshaler {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return nil, nil
+		}
+		var marshaler encoding.TextMarshaler
+		switch {
+		case v.Type().Implements(textMarshalerType):
+			marshaler = v.Interface().(encoding.TextMarshaler)
+		case v.CanAddr():
+			marshaler = v.Addr().Interface().(encoding.TextMarshaler)
+		default:
+			// Pointer-receiver marshaler on a non-addressable value: copy
+			// it into an addressable one.
+			ptr := reflect.New(v.Type())
+			ptr.Elem().Set(v)
+			marshaler = ptr.Interface().(encoding.TextMarshaler)
+		}
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(text), nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface(), nil
+}
+
+// structTableData resolves the records and schema for struct slice input.
+// ok is false when data is not a slice of structs.
+func structTableData(data any, formatters map[string]func(any) any) (records []Record, schema *Schema, ok bool, err error) {
+	elemType := structElemType(data)
+	if elemType == nil {
+		return nil, nil, false, nil
+	}
+
+	plan, err := planForStruct(elemType)
+	if err != nil {
+		return nil, nil, true, err
+	}
+	schema, err = plan.schema(formatters)
+	if err != nil {
+		return nil, nil, true, err
+	}
+	records, err = plan.records(reflect.ValueOf(data))
+	if err != nil {
+		return nil, nil, true, err
+	}
+	return records, schema, true, nil
+}
+
+// lookupFieldFormatter resolves a formatter name from a struct tag. Table
+// formatters supplied with WithFieldFormatters take precedence over the
+// built-in set.
+func lookupFieldFormatter(name string, formatters map[string]func(any) any) (func(any) any, bool) {
+	if fn, ok := formatters[name]; ok && fn != nil {
+		return fn, true
+	}
+	fn, ok := builtinFieldFormatters[name]
+	return fn, ok
+}
+
+// builtinFieldFormatters holds the formatters that struct tags can reference
+// by name without registering them through WithFieldFormatters. The map is
+// never modified at runtime.
+var builtinFieldFormatters = map[string]func(any) any{
+	"number":        NumberFormatter(-1, true),
+	"percent":       PercentFormatter(1),
+	"bytes":         BytesFormatter(BytesIEC),
+	"bytes-si":      BytesFormatter(BytesSI),
+	"duration":      DurationFormatter(),
+	"datetime":      TimeFormatter(time.DateTime, nil),
+	"date":          TimeFormatter(time.DateOnly, nil),
+	"relative-time": RelativeTimeFormatter(),
+}
@@ -0,0 +1,297 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type structTestServer struct {
+	Name     string `output:"name"`
+	CPU      int    `output:"cpu,order=2"`
+	ID       string `output:"id,order=1"`
+	Password string `output:"password,hidden"`
+	Ignored  string `output:"-"`
+	Region   string
+	internal string
+}
+
+type structTestLevel string
+
+func (l structTestLevel) MarshalText() ([]byte, error) {
+	if l == "" {
+		return nil, errors.New("empty level")
+	}
+	return []byte(strings.ToUpper(string(l))), nil
+}
+
+type structTestPtrMarshaler struct{ code int }
+
+func (m *structTestPtrMarshaler) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "code-%d", m.code), nil
+}
+
+type structTestBase struct {
+	ID      string `output:"id"`
+	Created time.Time
+}
+
+type structTestAudit struct {
+	Owner string
+}
+
+type structTestEmbedding struct {
+	structTestBase
+	*structTestAudit
+	ID    int    `output:"id"` // shadows structTestBase.ID
+	Label string `output:"label"`
+}
+
+func TestNewTableContent_StructSlice(t *testing.T) {
+	servers := []structTestServer{
+		{Name: "web", CPU: 4, ID: "i-1", Password: "x", Ignored: "y", Region: "eu", internal: "z"},
+		{Name: "db", CPU: 8, ID: "i-2", Region: "us"},
+	}
+
+	table, err := NewTableContent("Servers", servers)
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+
+	wantKeys := []string{"id", "cpu", "name", "Region"}
+	if got := table.Schema().GetKeyOrder(); !slices.Equal(got, wantKeys) {
+		t.Errorf("key order = %v, want %v", got, wantKeys)
+	}
+
+	wantFields := []string{"id", "cpu", "name", "password", "Region"}
+	var gotFields []string
+	for _, field := range table.Schema().Fields {
+		gotFields = append(gotFields, field.Name)
+	}
+	if !slices.Equal(gotFields, wantFields) {
+		t.Errorf("field names = %v, want %v", gotFields, wantFields)
+	}
+
+	if field := table.Schema().FindField("password"); field == nil || !field.Hidden {
+		t.Errorf("password field = %+v, want hidden", field)
+	}
+	if field := table.Schema().FindField("cpu"); field == nil || field.Type != "int" {
+		t.Errorf("cpu field = %+v, want type int", field)
+	}
+
+	records := table.Records()
+	if len(records) != 2 {
+		t.Fatalf("len(records) = %d, want 2", len(records))
+	}
+	if records[0]["cpu"] != 4 || records[0]["id"] != "i-1" || records[0]["password"] != "x" {
+		t.Errorf("records[0] = %v", records[0])
+	}
+	if _, ok := records[0]["Ignored"]; ok {
+		t.Error("field tagged with \"-\" must not become a column")
+	}
+	if _, ok := records[0]["internal"]; ok {
+		t.Error("unexported field must not become a column")
+	}
+}
+
+func TestNewTableContent_StructPointers(t *testing.T) {
+	type row struct {
+		Name  string  `output:"name"`
+		Count *int    `output:"count"`
+		Note  *string `output:"note"`
+	}
+	count := 3
+
+	table, err := NewTableContent("Rows", []*row{
+		{Name: "a", Count: &count},
+		nil,
+		{Name: "b"},
+	})
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+
+	records := table.Records()
+	if len(records) != 2 {
+		t.Fatalf("len(records) = %d, want 2 (nil elements are skipped)", len(records))
+	}
+	if records[0]["count"] != 3 {
+		t.Errorf("count = %#v, want dereferenced 3", records[0]["count"])
+	}
+	if records[1]["count"] != nil || records[1]["note"] != nil {
+		t.Errorf("nil pointer fields = %v, want nil values", records[1])
+	}
+	if field := table.Schema().FindField("count"); field == nil || field.Type != "int" {
+		t.Errorf("count field = %+v, want type int from the pointer element", field)
+	}
+}
+
+func TestNewTableContent_StructEmbedded(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	table, err := NewTableContent("Embedded", []structTestEmbedding{
+		{
+			structTestBase:  structTestBase{ID: "base", Created: created},
+			structTestAudit: &structTestAudit{Owner: "ops"},
+			ID:              7,
+			Label:           "first",
+		},
+		{ID: 8, Label: "second"},
+	})
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+
+	wantKeys := []string{"Created", "Owner", "id", "label"}
+	if got := table.Schema().GetKeyOrder(); !slices.Equal(got, wantKeys) {
+		t.Errorf("key order = %v, want %v", got, wantKeys)
+	}
+
+	records := table.Records()
+	if records[0]["id"] != 7 {
+		t.Errorf("id = %v, want outer field 7 to shadow the promoted one", records[0]["id"])
+	}
+	if records[0]["Created"] != created {
+		t.Errorf("Created = %v, want time.Time kept as-is", records[0]["Created"])
+	}
+	if field := table.Schema().FindField("Created"); field == nil || field.Type != "time" {
+		t.Errorf("Created field = %+v, want type time", field)
+	}
+	if records[0]["Owner"] != "ops" {
+		t.Errorf("Owner = %v, want ops", records[0]["Owner"])
+	}
+	if records[1]["Owner"] != nil {
+		t.Errorf("Owner = %v, want nil for nil embedded pointer", records[1]["Owner"])
+	}
+}
+
+func TestNewTableContent_StructTextMarshaler(t *testing.T) {
+	type row struct {
+		Level  structTestLevel        `output:"level"`
+		Code   structTestPtrMarshaler `output:"code"`
+		Opt    *structTestLevel       `output:"opt"`
+		Forced int                    `output:"forced,type=float"`
+	}
+
+	table, err := NewTableContent("Levels", []row{{Level: "warn", Code: structTestPtrMarshaler{code: 42}}})
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+
+	record := table.Records()[0]
+	if record["level"] != "WARN" {
+		t.Errorf("level = %#v, want MarshalText output", record["level"])
+	}
+	if record["code"] != "code-42" {
+		t.Errorf("code = %#v, want pointer-receiver MarshalText output", record["code"])
+	}
+	if record["opt"] != nil {
+		t.Errorf("opt = %#v, want nil", record["opt"])
+	}
+	if field := table.Schema().FindField("level"); field == nil || field.Type != "string" {
+		t.Errorf("level field = %+v, want type string", field)
+	}
+	if field := table.Schema().FindField("forced"); field == nil || field.Type != "float" {
+		t.Errorf("forced field = %+v, want type override float", field)
+	}
+
+	if _, err := NewTableContent("Bad", []row{{}}); err == nil || !strings.Contains(err.Error(), "empty level") {
+		t.Errorf("NewTableContent() error = %v, want MarshalText error", err)
+	}
+}
+
+func TestNewTableContent_StructFormatters(t *testing.T) {
+	type row struct {
+		Size int `output:"size,format=kb"`
+	}
+	kb := func(v any) any { return fmt.Sprintf("%d KB", v.(int)/1024) }
+
+	table, err := NewTableContent("Sizes", []row{{Size: 2048}}, WithFieldFormatters(map[string]func(any) any{"kb": kb}))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	field := table.Schema().FindField("size")
+	if field == nil || field.Formatter == nil {
+		t.Fatalf("size field = %+v, want formatter", field)
+	}
+	if got := field.Formatter(2048); got != "2 KB" {
+		t.Errorf("Formatter(2048) = %v, want 2 KB", got)
+	}
+	if table.Records()[0]["size"] != 2048 {
+		t.Errorf("record keeps raw value, got %v", table.Records()[0]["size"])
+	}
+
+	if _, err := NewTableContent("Sizes", []row{{Size: 1}}); err == nil || !strings.Contains(err.Error(), `unknown formatter "kb"`) {
+		t.Errorf("NewTableContent() error = %v, want unknown formatter error", err)
+	}
+}
+
+func TestNewTableContent_StructTagErrors(t *testing.T) {
+	type badOrder struct {
+		A string `output:"a,order=first"`
+	}
+	type badOption struct {
+		A string `output:"a,bold"`
+	}
+
+	tests := map[string]struct {
+		data    any
+		wantErr string
+	}{
+		"non-integer order": {data: []badOrder{{}}, wantErr: "order requires an integer"},
+		"unknown option":    {data: []badOption{{}}, wantErr: `unknown tag option "bold"`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewTableContent("Bad", tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewTableContent() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewTableContent_StructOptions(t *testing.T) {
+	servers := []structTestServer{{Name: "web", CPU: 2, ID: "i-1"}}
+
+	t.Run("WithKeys reorders struct columns", func(t *testing.T) {
+		table, err := NewTableContent("Servers", servers, WithKeys("name", "id"))
+		if err != nil {
+			t.Fatalf("NewTableContent() error = %v", err)
+		}
+		if got := table.Schema().GetKeyOrder(); !slices.Equal(got, []string{"name", "id"}) {
+			t.Errorf("key order = %v, want [name id]", got)
+		}
+		if field := table.Schema().FindField("cpu"); field == nil || field.Type != "int" {
+			t.Errorf("cpu field = %+v, want struct-derived field kept", field)
+		}
+	})
+
+	t.Run("WithSchema overrides struct schema", func(t *testing.T) {
+		table, err := NewTableContent("Servers", servers, WithSchema(Field{Name: "cpu"}))
+		if err != nil {
+			t.Fatalf("NewTableContent() error = %v", err)
+		}
+		if got := table.Schema().GetKeyOrder(); !slices.Equal(got, []string{"cpu"}) {
+			t.Errorf("key order = %v, want [cpu]", got)
+		}
+	})
+
+	t.Run("Builder.Table does not warn about key order", func(t *testing.T) {
+		builder := New()
+		builder.Table("Servers", servers)
+		if warnings := keyOrderWarnings(builder.Errors()); len(warnings) > 0 {
+			t.Errorf("unexpected key order warnings: %v", warnings)
+		}
+	})
+
+	t.Run("DetectSchemaFromData follows struct layout", func(t *testing.T) {
+		schema := DetectSchemaFromData(servers)
+		if got := schema.GetKeyOrder(); !slices.Equal(got, []string{"id", "cpu", "name", "Region"}) {
+			t.Errorf("key order = %v", got)
+		}
+	})
+}
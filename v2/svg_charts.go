@@ -0,0 +1,1092 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Sizes used when drawing charts as SVG, in pixels. Text width is estimated
+// from the rune count, since the fonts available to the reader are unknown.
+const (
+	svgFontSize      = 12
+	svgTitleFontSize = 16
+	svgCharWidth     = 7
+	svgPadding       = 16
+	svgTitleHeight   = 32
+)
+
+// svgTheme holds the colours and font of charts drawn as SVG. The values are
+// written as attributes rather than CSS variables so the SVG looks the same
+// wherever it is embedded.
+type svgTheme struct {
+	palette    []string // Series and slice colours, used in turn
+	text       string
+	muted      string
+	border     string
+	background string
+	surface    string
+	primary    string
+	critical   string
+	font       string
+}
+
+// defaultSVGTheme matches the colours of the default HTML template
+var defaultSVGTheme = svgTheme{
+	palette:    []string{"#2563eb", "#f59e0b", "#10b981", "#ef4444", "#8b5cf6", "#06b6d4", "#ec4899", "#84cc16"},
+	text:       "#111827",
+	muted:      "#6b7280",
+	border:     "#e5e7eb",
+	background: "#ffffff",
+	surface:    "#f9fafb",
+	primary:    "#2563eb",
+	critical:   "#dc2626",
+	font:       "-apple-system, BlinkMacSystemFont, Segoe UI, Roboto, Arial, sans-serif",
+}
+
+// svgThemeProperties maps the CSS custom properties of the default HTML
+// template to the theme colours they override
+var svgThemeProperties = map[string]func(*svgTheme) *string{
+	"--color-primary":    func(t *svgTheme) *string { return &t.primary },
+	"--color-text":       func(t *svgTheme) *string { return &t.text },
+	"--color-text-muted": func(t *svgTheme) *string { return &t.muted },
+	"--color-border":     func(t *svgTheme) *string { return &t.border },
+	"--color-background": func(t *svgTheme) *string { return &t.background },
+	"--color-surface":    func(t *svgTheme) *string { return &t.surface },
+	"--color-error":      func(t *svgTheme) *string { return &t.critical },
+	"--font-family":      func(t *svgTheme) *string { return &t.font },
+}
+
+// newSVGTheme returns the default theme with the colours and font set in
+// overrides, which uses the custom properties of HTMLTemplate.ThemeOverrides.
+// "--chart-color-1", "--chart-color-2" and so on replace or extend the
+// series palette, whose first colour otherwise follows "--color-primary".
+// Values are escaped here, so the drawing code can write them as is.
+func newSVGTheme(overrides map[string]string) svgTheme {
+	theme := defaultSVGTheme
+	theme.palette = slices.Clone(defaultSVGTheme.palette)
+	for property, field := range svgThemeProperties {
+		if value := strings.TrimSpace(overrides[property]); value != "" {
+			*field(&theme) = svgEscape(value)
+		}
+	}
+	if _, ok := overrides["--color-primary"]; ok {
+		theme.palette[0] = theme.primary
+	}
+	for i := 1; ; i++ {
+		value := strings.TrimSpace(overrides["--chart-color-"+strconv.Itoa(i)])
+		switch {
+		case i <= len(theme.palette):
+			if value != "" {
+				theme.palette[i-1] = svgEscape(value)
+			}
+		case value != "":
+			theme.palette = append(theme.palette, svgEscape(value))
+		default:
+			return theme
+		}
+	}
+}
+
+// color returns the palette colour for the i-th series or slice
+func (t *svgTheme) color(i int) string {
+	return t.palette[i%len(t.palette)]
+}
+
+// svgNum formats a coordinate with at most two decimals, so output is stable
+// and compact
+func svgNum(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100+0, 'f', -1, 64)
+}
+
+// svgTextWidth estimates the width of s in the chart font
+func svgTextWidth(s string) float64 {
+	return float64(utf8.RuneCountInString(s) * svgCharWidth)
+}
+
+// svgEscape escapes text for SVG content and attribute values
+func svgEscape(s string) string {
+	return html.EscapeString(s)
+}
+
+// writeSVGStart writes the opening svg element, its background and, when
+// title is set, the title both as accessible name and as a heading
+func writeSVGStart(buf *bytes.Buffer, theme *svgTheme, width, height float64, title string) {
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" role="img" font-family="%s" font-size="%d"`,
+		svgNum(width), svgNum(height), svgNum(width), svgNum(height), theme.font, svgFontSize)
+	if title != "" {
+		fmt.Fprintf(buf, ` aria-label="%s"`, svgEscape(title))
+	}
+	buf.WriteString(">\n")
+	if title != "" {
+		fmt.Fprintf(buf, "<title>%s</title>\n", svgEscape(title))
+	}
+	fmt.Fprintf(buf, `<rect width="%s" height="%s" fill="%s"/>`+"\n", svgNum(width), svgNum(height), theme.background)
+	if title != "" {
+		fmt.Fprintf(buf, `<text x="%s" y="%d" text-anchor="middle" font-size="%d" font-weight="bold" fill="%s">%s</text>`+"\n",
+			svgNum(width/2), svgPadding+svgTitleFontSize/2+4, svgTitleFontSize, theme.text, svgEscape(title))
+	}
+}
+
+// svgTitleOffset returns the vertical space taken by a chart title
+func svgTitleOffset(title string) float64 {
+	if title == "" {
+		return 0
+	}
+	return svgTitleHeight
+}
+
+// renderChartSVG draws a chart as SVG and returns its width and height. It
+// reports false for chart types and data it cannot draw.
+func renderChartSVG(buf *bytes.Buffer, chart *ChartContent, theme *svgTheme) (float64, float64, bool) {
+	var width, height float64
+	switch data := chart.GetData().(type) {
+	case *PieData:
+		if chart.GetChartType() != ChartTypePie || data == nil {
+			return 0, 0, false
+		}
+		width, height = renderPieSVG(buf, chart.GetTitle(), data, theme)
+	case *GanttData:
+		if chart.GetChartType() != ChartTypeGantt || data == nil {
+			return 0, 0, false
+		}
+		width, height = renderGanttSVG(buf, chart.GetTitle(), data, theme)
+	case *BarData:
+		if chart.GetChartType() != ChartTypeBar || data == nil {
+			return 0, 0, false
+		}
+		width, height = renderSeriesSVG(buf, seriesChart{
+			title:      chart.GetTitle(),
+			categories: data.Categories,
+			series:     data.Series,
+			stacked:    data.Stacked,
+		}, theme)
+	case *LineData:
+		if chart.GetChartType() != ChartTypeLine || data == nil {
+			return 0, 0, false
+		}
+		width, height = renderSeriesSVG(buf, seriesChart{
+			title:      chart.GetTitle(),
+			categories: data.Categories,
+			series:     data.Series,
+			line:       true,
+			times:      data.Times,
+		}, theme)
+	case *ScatterData:
+		if chart.GetChartType() != ChartTypeScatter || data == nil {
+			return 0, 0, false
+		}
+		width, height = renderScatterSVG(buf, chart.GetTitle(), data, theme)
+	default:
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+// drawnPieSlice reports whether a slice takes up part of the pie
+func drawnPieSlice(slice PieSlice) bool {
+	return slice.Value > 0 && finiteValue(slice.Value)
+}
+
+// renderPieSVG draws a pie chart with a legend to its right and returns its
+// size. Slices are drawn clockwise from the top; slices without a positive
+// finite value are left out of the pie but kept in the legend.
+func renderPieSVG(buf *bytes.Buffer, title string, data *PieData, theme *svgTheme) (float64, float64) {
+	const radius = 120.0
+	total := 0.0
+	legendWidth := 0.0
+	labels := make([]string, len(data.Slices))
+	for i, slice := range data.Slices {
+		if drawnPieSlice(slice) {
+			total += slice.Value
+		}
+		labels[i] = slice.Label
+		if data.ShowData {
+			labels[i] += " [" + formatDecimal(slice.Value, -1, false) + "]"
+		}
+		legendWidth = max(legendWidth, svgTextWidth(labels[i]))
+	}
+
+	top := svgPadding + svgTitleOffset(title)
+	legendHeight := float64(len(data.Slices) * 20)
+	width := svgPadding*3 + 2*radius + 18 + legendWidth
+	width = max(width, svgTextWidth(title)*svgTitleFontSize/svgFontSize+2*svgPadding)
+	height := top + max(2*radius, legendHeight) + svgPadding
+	cx, cy := svgPadding+radius, top+radius
+
+	writeSVGStart(buf, theme, width, height, title)
+
+	if total == 0 {
+		fmt.Fprintf(buf, `<circle cx="%s" cy="%s" r="%s" fill="%s" stroke="%s"/>`+"\n",
+			svgNum(cx), svgNum(cy), svgNum(radius), theme.surface, theme.border)
+	}
+	angle := -math.Pi / 2
+	for i, slice := range data.Slices {
+		if !drawnPieSlice(slice) || total == 0 {
+			continue
+		}
+		share := slice.Value / total
+		sweep := share * 2 * math.Pi
+		if share >= 1 {
+			fmt.Fprintf(buf, `<circle cx="%s" cy="%s" r="%s" fill="%s"/>`+"\n",
+				svgNum(cx), svgNum(cy), svgNum(radius), theme.color(i))
+		} else {
+			largeArc := 0
+			if sweep > math.Pi {
+				largeArc = 1
+			}
+			x1, y1 := cx+radius*math.Cos(angle), cy+radius*math.Sin(angle)
+			x2, y2 := cx+radius*math.Cos(angle+sweep), cy+radius*math.Sin(angle+sweep)
+			fmt.Fprintf(buf, `<path d="M%s %sL%s %sA%s %s 0 %d 1 %s %sZ" fill="%s" stroke="%s"/>`+"\n",
+				svgNum(cx), svgNum(cy), svgNum(x1), svgNum(y1), svgNum(radius), svgNum(radius),
+				largeArc, svgNum(x2), svgNum(y2), theme.color(i), theme.background)
+		}
+		// Label slices large enough to hold their share
+		if sweep >= 0.3 {
+			mid := angle + sweep/2
+			lx, ly := cx+radius*0.65*math.Cos(mid), cy+radius*0.65*math.Sin(mid)
+			fmt.Fprintf(buf, `<text x="%s" y="%s" text-anchor="middle" dominant-baseline="middle" fill="#ffffff">%s%%</text>`+"\n",
+				svgNum(lx), svgNum(ly), formatDecimal(share*100, 1, false))
+		}
+		angle += sweep
+	}
+
+	legendX := svgPadding*2 + 2*radius
+	legendY := cy - legendHeight/2
+	for i := range data.Slices {
+		y := legendY + float64(i*20)
+		fmt.Fprintf(buf, `<rect x="%s" y="%s" width="12" height="12" fill="%s"/>`+"\n",
+			svgNum(legendX), svgNum(y+2), theme.color(i))
+		fmt.Fprintf(buf, `<text x="%s" y="%s" fill="%s">%s</text>`+"\n",
+			svgNum(legendX+18), svgNum(y+12), theme.text, svgEscape(labels[i]))
+	}
+	buf.WriteString("</svg>\n")
+	return width, height
+}
+
+// ganttBar is a Gantt task with its resolved start and end
+type ganttBar struct {
+	task       GanttTask
+	start, end time.Time
+}
+
+// mermaidDateLayout converts a Mermaid (day.js) date format such as
+// "YYYY-MM-DD" to a Go time layout
+var mermaidDateLayout = strings.NewReplacer(
+	"YYYY", "2006", "YY", "06", "MM", "01", "DD", "02", "HH", "15", "mm", "04", "ss", "05",
+)
+
+// strftimeLayouts maps the strftime verbs Mermaid axis formats use to Go
+// time layout elements
+var strftimeLayouts = map[byte]string{
+	'Y': "2006", 'y': "06", 'm': "01", 'd': "02", 'e': "_2", 'b': "Jan", 'B': "January",
+	'a': "Mon", 'A': "Monday", 'H': "15", 'I': "03", 'p': "PM", 'M': "04", 'S': "05", '%': "%",
+}
+
+// formatStrftime formats t with a strftime format such as "%Y-%m-%d".
+// Unsupported verbs are written as is.
+func formatStrftime(t time.Time, format string) string {
+	var out strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] == '%' && i+1 < len(format) {
+			if elem, ok := strftimeLayouts[format[i+1]]; ok {
+				out.WriteString(t.Format(elem))
+				i++
+				continue
+			}
+		}
+		out.WriteByte(format[i])
+	}
+	return out.String()
+}
+
+// parseGanttDuration parses Mermaid task durations such as "3d", "1.5w",
+// "12h" or "30m"
+func parseGanttDuration(s string) (time.Duration, bool) {
+	units := []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"ms", time.Millisecond},
+		{"s", time.Second},
+		{"m", time.Minute},
+		{"h", time.Hour},
+		{"d", 24 * time.Hour},
+		{"w", 7 * 24 * time.Hour},
+	}
+	s = strings.TrimSpace(s)
+	for _, unit := range units {
+		number, ok := strings.CutSuffix(s, unit.suffix)
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseFloat(number, 64)
+		if err != nil || n < 0 {
+			continue
+		}
+		return time.Duration(n * float64(unit.size)), true
+	}
+	return 0, false
+}
+
+// ganttBars resolves the start and end of each task the way Mermaid does: a
+// start of "after a b" begins when the last of tasks a and b ends, and a
+// task without a start follows the previous task. Tasks without an end date
+// or duration last a day; tasks that cannot be placed at all are left out.
+func ganttBars(data *GanttData) []ganttBar {
+	layout := mermaidDateLayout.Replace(data.DateFormat)
+	if layout == "" {
+		layout = time.DateOnly
+	}
+	ends := make(map[string]time.Time)
+	bars := make([]ganttBar, 0, len(data.Tasks))
+	var previous time.Time
+	for _, task := range data.Tasks {
+		var start time.Time
+		if after, ok := strings.CutPrefix(strings.TrimSpace(task.StartDate), "after "); ok {
+			for id := range strings.FieldsSeq(after) {
+				if end, ok := ends[id]; ok && end.After(start) {
+					start = end
+				}
+			}
+		} else if t, err := time.Parse(layout, strings.TrimSpace(task.StartDate)); err == nil {
+			start = t
+		}
+		if start.IsZero() {
+			start = previous
+		}
+		if start.IsZero() {
+			continue
+		}
+
+		end := start.Add(24 * time.Hour)
+		if d, ok := parseGanttDuration(task.Duration); ok {
+			end = start.Add(d)
+		} else if t, err := time.Parse(layout, strings.TrimSpace(task.EndDate)); err == nil && !t.Before(start) {
+			end = t
+		} else if d, ok := parseGanttDuration(task.EndDate); ok {
+			end = start.Add(d)
+		}
+
+		if task.ID != "" {
+			ends[task.ID] = end
+		}
+		previous = end
+		bars = append(bars, ganttBar{task: task, start: start, end: end})
+	}
+	return bars
+}
+
+// groupGanttBars groups bars by section in first-seen order, as the Mermaid
+// output does, and reports whether the sections should be shown: they are
+// left out when no task has one
+func groupGanttBars(bars []ganttBar) ([]string, map[string][]ganttBar, bool) {
+	var order []string
+	sections := make(map[string][]ganttBar)
+	for _, bar := range bars {
+		section := bar.task.Section
+		if _, ok := sections[section]; !ok {
+			order = append(order, section)
+		}
+		sections[section] = append(sections[section], bar)
+	}
+	show := len(order) > 1 || (len(order) == 1 && order[0] != "")
+	return order, sections, show
+}
+
+// ganttSpan returns the earliest start and latest end of bars and the time
+// between them, which is at least a day
+func ganttSpan(bars []ganttBar) (time.Time, time.Time, time.Duration) {
+	var first, last time.Time
+	for i, bar := range bars {
+		if i == 0 || bar.start.Before(first) {
+			first = bar.start
+		}
+		if i == 0 || bar.end.After(last) {
+			last = bar.end
+		}
+	}
+	span := last.Sub(first)
+	if span <= 0 {
+		span = 24 * time.Hour
+	}
+	return first, last, span
+}
+
+// ganttTickStep picks the interval between axis ticks for a time span so
+// the axis has at most maxTicks intervals
+func ganttTickStep(span time.Duration, maxTicks int) time.Duration {
+	maxTicks = max(maxTicks, 1)
+	day := 24 * time.Hour
+	steps := []time.Duration{time.Hour, 6 * time.Hour, day, 2 * day, 7 * day, 14 * day, 30 * day, 91 * day, 365 * day}
+	for _, step := range steps {
+		if span/step <= time.Duration(maxTicks) {
+			return step
+		}
+	}
+	return span / time.Duration(maxTicks)
+}
+
+// renderGanttSVG draws a Gantt chart with one row per task, sections as
+// heading rows and a date axis along the top, and returns its size. Task status selects the bar
+// colour: done is muted, crit is red and anything else uses the primary
+// colour, with active tasks drawn solid and the rest lighter.
+func renderGanttSVG(buf *bytes.Buffer, title string, data *GanttData, theme *svgTheme) (float64, float64) {
+	const (
+		rowHeight  = 24.0
+		axisHeight = 24.0
+		chartWidth = 600.0
+	)
+	bars := ganttBars(data)
+
+	sectionOrder, sections, showSections := groupGanttBars(bars)
+	labelWidth := 0.0
+	for _, bar := range bars {
+		labelWidth = max(labelWidth, svgTextWidth(bar.task.Title), svgTextWidth(bar.task.Section))
+	}
+	rows := len(bars)
+	if showSections {
+		rows += len(sectionOrder)
+	}
+
+	minStart, maxEnd, span := ganttSpan(bars)
+	axisFormat := data.AxisFormat
+	if axisFormat == "" {
+		axisFormat = "%Y-%m-%d"
+	}
+	tickWidth := svgTextWidth(formatStrftime(minStart, axisFormat))
+
+	top := svgPadding + svgTitleOffset(title)
+	left := svgPadding + max(labelWidth, tickWidth/2) + svgPadding
+	width := max(left+chartWidth+tickWidth/2+svgPadding, svgTextWidth(title)*svgTitleFontSize/svgFontSize+2*svgPadding)
+	height := top + axisHeight + float64(rows)*rowHeight + svgPadding
+
+	writeSVGStart(buf, theme, width, height, title)
+	if len(bars) == 0 {
+		buf.WriteString("</svg>\n")
+		return width, height
+	}
+	x := func(t time.Time) float64 {
+		return left + chartWidth*float64(t.Sub(minStart))/float64(span)
+	}
+
+	// Axis ticks, spaced so their labels do not overlap, with grid lines
+	// across all rows
+	step := ganttTickStep(span, int(chartWidth/(tickWidth+svgPadding)))
+	bottom := top + axisHeight + float64(rows)*rowHeight
+	for tick := minStart; !tick.After(maxEnd); tick = tick.Add(step) {
+		tx := x(tick)
+		fmt.Fprintf(buf, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+			svgNum(tx), svgNum(top+axisHeight-4), svgNum(tx), svgNum(bottom), theme.border)
+		fmt.Fprintf(buf, `<text x="%s" y="%s" text-anchor="middle" fill="%s">%s</text>`+"\n",
+			svgNum(tx), svgNum(top+axisHeight-8), theme.muted, svgEscape(formatStrftime(tick, axisFormat)))
+	}
+
+	y := top + axisHeight
+	for _, section := range sectionOrder {
+		if showSections {
+			fmt.Fprintf(buf, `<rect x="0" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+				svgNum(y), svgNum(width), svgNum(rowHeight), theme.surface)
+			fmt.Fprintf(buf, `<text x="%d" y="%s" font-weight="bold" fill="%s">%s</text>`+"\n",
+				svgPadding, svgNum(y+rowHeight/2+4), theme.text, svgEscape(section))
+			y += rowHeight
+		}
+		for _, bar := range sections[section] {
+			fill, opacity := theme.primary, "0.6"
+			switch {
+			case strings.Contains(bar.task.Status, "crit"):
+				fill = theme.critical
+			case strings.Contains(bar.task.Status, "done"):
+				fill = theme.muted
+			}
+			if strings.Contains(bar.task.Status, "active") {
+				opacity = "1"
+			}
+			barWidth := max(x(bar.end)-x(bar.start), 2)
+			fmt.Fprintf(buf, `<text x="%d" y="%s" fill="%s">%s</text>`+"\n",
+				svgPadding, svgNum(y+rowHeight/2+4), theme.text, svgEscape(bar.task.Title))
+			fmt.Fprintf(buf, `<rect x="%s" y="%s" width="%s" height="%s" rx="3" fill="%s" fill-opacity="%s"><title>%s</title></rect>`+"\n",
+				svgNum(x(bar.start)), svgNum(y+4), svgNum(barWidth), svgNum(rowHeight-8), fill, opacity,
+				svgEscape(fmt.Sprintf("%s: %s – %s", bar.task.Title, bar.start.Format(time.DateOnly), bar.end.Format(time.DateOnly))))
+			y += rowHeight
+		}
+	}
+	buf.WriteString("</svg>\n")
+	return width, height
+}
+
+// svgNode is a placed graph node; x and y are its centre
+type svgNode struct {
+	name       string
+	x, y, w, h float64
+	rank       int
+}
+
+// graphRanks assigns each node the length of the longest path reaching it,
+// ignoring edges that close a cycle, so edges point down the layers
+func graphRanks(nodes []string, edges []Edge) map[string]int {
+	outgoing := make(map[string][]string)
+	for _, edge := range edges {
+		outgoing[edge.From] = append(outgoing[edge.From], edge.To)
+	}
+
+	// Find the edges that close a cycle with a depth-first search in node
+	// order, which keeps the result deterministic
+	type edgeKey struct{ from, to string }
+	backEdges := make(map[edgeKey]bool)
+	state := make(map[string]int) // 1 on the stack, 2 done
+	var visit func(string)
+	visit = func(node string) {
+		state[node] = 1
+		for _, to := range outgoing[node] {
+			switch state[to] {
+			case 0:
+				visit(to)
+			case 1:
+				backEdges[edgeKey{node, to}] = true
+			}
+		}
+		state[node] = 2
+	}
+	for _, node := range nodes {
+		if state[node] == 0 {
+			visit(node)
+		}
+	}
+
+	ranks := make(map[string]int, len(nodes))
+	for range nodes {
+		changed := false
+		for _, edge := range edges {
+			if edge.From == edge.To || backEdges[edgeKey{edge.From, edge.To}] {
+				continue
+			}
+			if ranks[edge.To] < ranks[edge.From]+1 {
+				ranks[edge.To] = ranks[edge.From] + 1
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return ranks
+}
+
+// clipToBox returns the point where the line from the centre of n towards
+// (tx, ty) leaves the box of n
+func (n *svgNode) clipToBox(tx, ty float64) (float64, float64) {
+	dx, dy := tx-n.x, ty-n.y
+	if dx == 0 && dy == 0 {
+		return n.x, n.y
+	}
+	scale := math.Inf(1)
+	if dx != 0 {
+		scale = min(scale, n.w/2/math.Abs(dx))
+	}
+	if dy != 0 {
+		scale = min(scale, n.h/2/math.Abs(dy))
+	}
+	return n.x + dx*scale, n.y + dy*scale
+}
+
+// renderGraphSVG draws a graph top-down as Mermaid's "graph TD" does and
+// returns its size. Nodes are placed in layers by longest path, in first-seen
+// order within a layer, and edges are arrows with their label at the
+// midpoint.
+func renderGraphSVG(buf *bytes.Buffer, graph *GraphContent, theme *svgTheme) (float64, float64) {
+	const (
+		nodeHeight  = 36.0
+		layerGap    = 64.0
+		nodeGap     = 32.0
+		arrowLength = 9.0
+	)
+	title := graph.GetTitle()
+	edges := graph.GetEdges()
+	names := graph.GetNodes()
+	ranks := graphRanks(names, edges)
+
+	var layers [][]*svgNode
+	nodes := make(map[string]*svgNode, len(names))
+	for _, name := range names {
+		rank := ranks[name]
+		for len(layers) <= rank {
+			layers = append(layers, nil)
+		}
+		node := &svgNode{name: name, w: max(svgTextWidth(name)+24, 60), h: nodeHeight, rank: rank}
+		layers[rank] = append(layers[rank], node)
+		nodes[name] = node
+	}
+
+	layerWidths := make([]float64, len(layers))
+	contentWidth := 0.0
+	for i, layer := range layers {
+		for j, node := range layer {
+			if j > 0 {
+				layerWidths[i] += nodeGap
+			}
+			layerWidths[i] += node.w
+		}
+		contentWidth = max(contentWidth, layerWidths[i])
+	}
+	top := svgPadding + svgTitleOffset(title)
+	height := top + float64(len(layers))*nodeHeight + float64(max(len(layers)-1, 0))*layerGap + svgPadding
+
+	for i, layer := range layers {
+		x := svgPadding + (contentWidth-layerWidths[i])/2
+		for _, node := range layer {
+			node.x = x + node.w/2
+			node.y = top + float64(i)*(nodeHeight+layerGap) + nodeHeight/2
+			x += node.w + nodeGap
+		}
+	}
+
+	// Edges are drawn first, so nodes cover their ends, and into a separate
+	// buffer, since bent edges can reach past the nodes and widen the chart
+	var edgeBuf bytes.Buffer
+	right := svgPadding + contentWidth
+	for _, edge := range edges {
+		from, to := nodes[edge.From], nodes[edge.To]
+		var lx, ly float64
+		if from == to {
+			// Loop around the right side of the node
+			x, y := from.x+from.w/2, from.y
+			fmt.Fprintf(&edgeBuf, `<path d="M%s %sC%s %s %s %s %s %s" fill="none" stroke="%s"/>`+"\n",
+				svgNum(x), svgNum(y-8), svgNum(x+30), svgNum(y-24), svgNum(x+30), svgNum(y+24),
+				svgNum(x+arrowLength), svgNum(y+8), theme.muted)
+			writeSVGArrowHead(&edgeBuf, x+arrowLength, y+8, x, y+8, arrowLength, theme.muted)
+			lx, ly = x+34+svgTextWidth(edge.Label)/2, y
+			right = max(right, x+30)
+		} else if to.rank == from.rank+1 {
+			x1, y1 := from.clipToBox(to.x, to.y)
+			x2, y2 := to.clipToBox(from.x, from.y)
+			fmt.Fprintf(&edgeBuf, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+				svgNum(x1), svgNum(y1), svgNum(x2), svgNum(y2), theme.muted)
+			writeSVGArrowHead(&edgeBuf, x1, y1, x2, y2, arrowLength, theme.muted)
+			lx, ly = (x1+x2)/2, (y1+y2)/2
+		} else {
+			// Edges that skip layers or point back up bend to the right, so
+			// they do not run through the nodes in between
+			distance := to.rank - from.rank
+			if distance < 0 {
+				distance = -distance
+			}
+			bend := float64(distance) * (nodeHeight + layerGap) / 2
+			cx, cy := max(from.x+from.w/2, to.x+to.w/2)+bend, (from.y+to.y)/2
+			x1, y1 := from.clipToBox(cx, cy)
+			x2, y2 := to.clipToBox(cx, cy)
+			fmt.Fprintf(&edgeBuf, `<path d="M%s %sQ%s %s %s %s" fill="none" stroke="%s"/>`+"\n",
+				svgNum(x1), svgNum(y1), svgNum(cx), svgNum(cy), svgNum(x2), svgNum(y2), theme.muted)
+			writeSVGArrowHead(&edgeBuf, cx, cy, x2, y2, arrowLength, theme.muted)
+			lx, ly = (x1+2*cx+x2)/4, (y1+2*cy+y2)/4
+			right = max(right, lx)
+		}
+		if edge.Label != "" {
+			lw := svgTextWidth(edge.Label) + 8
+			right = max(right, lx+lw/2)
+			fmt.Fprintf(&edgeBuf, `<rect x="%s" y="%s" width="%s" height="18" fill="%s"/>`+"\n",
+				svgNum(lx-lw/2), svgNum(ly-9), svgNum(lw), theme.background)
+			fmt.Fprintf(&edgeBuf, `<text x="%s" y="%s" text-anchor="middle" dominant-baseline="middle" fill="%s">%s</text>`+"\n",
+				svgNum(lx), svgNum(ly), theme.muted, svgEscape(edge.Label))
+		}
+	}
+
+	width := max(right+svgPadding, svgTextWidth(title)*svgTitleFontSize/svgFontSize+2*svgPadding)
+	writeSVGStart(buf, theme, width, height, title)
+	buf.Write(edgeBuf.Bytes())
+
+	for _, name := range names {
+		node := nodes[name]
+		fmt.Fprintf(buf, `<rect x="%s" y="%s" width="%s" height="%s" rx="4" fill="%s" stroke="%s"/>`+"\n",
+			svgNum(node.x-node.w/2), svgNum(node.y-node.h/2), svgNum(node.w), svgNum(node.h), theme.surface, theme.primary)
+		fmt.Fprintf(buf, `<text x="%s" y="%s" text-anchor="middle" dominant-baseline="middle" fill="%s">%s</text>`+"\n",
+			svgNum(node.x), svgNum(node.y), theme.text, svgEscape(node.name))
+	}
+	buf.WriteString("</svg>\n")
+	return width, height
+}
+
+// writeSVGArrowHead draws an arrow head at (x2, y2) for a line from (x1, y1).
+// Arrow heads are drawn as polygons rather than markers, since marker IDs
+// would have to be unique across every chart on a page.
+func writeSVGArrowHead(buf *bytes.Buffer, x1, y1, x2, y2, length float64, color string) {
+	angle := math.Atan2(y2-y1, x2-x1)
+	const spread = 0.45
+	ax, ay := x2-length*math.Cos(angle-spread), y2-length*math.Sin(angle-spread)
+	bx, by := x2-length*math.Cos(angle+spread), y2-length*math.Sin(angle+spread)
+	fmt.Fprintf(buf, `<polygon points="%s,%s %s,%s %s,%s" fill="%s"/>`+"\n",
+		svgNum(x2), svgNum(y2), svgNum(ax), svgNum(ay), svgNum(bx), svgNum(by), color)
+}
+
+// svgAxisTicks returns evenly spaced values at round numbers (multiples of 1,
+// 2 or 5 times a power of ten) covering lo to hi, and the number of decimals
+// their labels need
+func svgAxisTicks(lo, hi float64) ([]float64, int) {
+	// Stacked values can add up past the float range
+	lo, hi = max(lo, -math.MaxFloat64), min(hi, math.MaxFloat64)
+	if lo == hi {
+		pad := 1.0
+		if lo-pad == lo {
+			pad = math.Abs(lo) / 4
+		}
+		lo, hi = max(lo-pad, -math.MaxFloat64), min(hi+pad, math.MaxFloat64)
+	}
+	raw := (hi - lo) / 5
+	if math.IsInf(raw, 0) {
+		raw = hi/5 - lo/5
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := 10 * magnitude
+	for _, m := range []float64{1, 2, 5} {
+		if m*magnitude >= raw {
+			step = m * magnitude
+			break
+		}
+	}
+	// The tolerance keeps rounding errors from adding a tick at either end
+	first, last := math.Floor(lo/step+1e-9), math.Ceil(hi/step-1e-9)
+	ticks := make([]float64, 0, int(last-first)+1)
+	for n := first; n <= last; n++ {
+		ticks = append(ticks, min(max(n*step, -math.MaxFloat64), math.MaxFloat64)+0)
+	}
+	return ticks, max(0, int(-math.Floor(math.Log10(step))))
+}
+
+// svgScale returns where v lies between lo and hi, from 0 to 1. Values are
+// halved first so that ranges near ±math.MaxFloat64 do not overflow.
+func svgScale(v, lo, hi float64) float64 {
+	f := (v/2 - lo/2) / (hi/2 - lo/2)
+	if math.IsNaN(f) {
+		return 0
+	}
+	return min(1, max(0, f))
+}
+
+// seriesChart is a bar or line chart as renderSeriesSVG draws it
+type seriesChart struct {
+	title      string
+	categories []string
+	series     []ChartSeries
+	line       bool
+	stacked    bool
+	// times places the categories along a time axis when there is one
+	// time per category
+	times []time.Time
+}
+
+// renderSeriesSVG draws a bar or line chart with a value axis on the left,
+// the categories along the bottom and a legend of the series below, and
+// returns its size. Bars of the series are drawn side by side within each
+// category, or stacked with positive values above zero and negative values
+// below it; lines join one point per category. Missing and NaN values are
+// left out.
+func renderSeriesSVG(buf *bytes.Buffer, chart seriesChart, theme *svgTheme) (float64, float64) {
+	const (
+		plotHeight = 240.0
+		minBand    = 40.0
+		legendGap  = 16.0
+	)
+	title, categories, series := chart.title, chart.categories, chart.series
+	count := len(categories)
+	for _, s := range series {
+		count = max(count, len(s.Values))
+	}
+	// above and below are the tops and bottoms of the stacks drawn so far
+	above, below := make([]float64, count), make([]float64, count)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for i, v := range s.Values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			if chart.stacked {
+				if v >= 0 {
+					above[i] += v
+				} else {
+					below[i] += v
+				}
+				lo, hi = min(lo, below[i]), max(hi, above[i])
+				continue
+			}
+			lo, hi = min(lo, v), max(hi, v)
+		}
+	}
+	if lo > hi {
+		lo, hi = 0, 0
+	}
+	if !chart.line {
+		// Bars grow from zero, so zero is always on the axis
+		lo, hi = min(lo, 0), max(hi, 0)
+	}
+	ticks, decimals := svgAxisTicks(lo, hi)
+	tickLabels := make([]string, len(ticks))
+	labelWidth := 0.0
+	for i, tick := range ticks {
+		tickLabels[i] = formatDecimal(tick, decimals, true)
+		labelWidth = max(labelWidth, svgTextWidth(tickLabels[i]))
+	}
+
+	band := minBand
+	for _, category := range categories {
+		band = max(band, svgTextWidth(category)+8)
+	}
+	legend := len(series) > 1 || (len(series) == 1 && series[0].Name != "")
+	legendWidth := 0.0
+	for _, s := range series {
+		legendWidth += 18 + svgTextWidth(s.Name) + legendGap
+	}
+
+	top := svgPadding + svgTitleOffset(title)
+	left := svgPadding + labelWidth + 8
+	plotWidth := max(float64(count)*band, 320)
+	band = plotWidth / float64(max(count, 1))
+	plotBottom := top + plotHeight
+	height := plotBottom + 24 + svgPadding
+	if legend {
+		height += 24
+	}
+	width := max(left+plotWidth+svgPadding, legendWidth+2*svgPadding, svgTextWidth(title)*svgTitleFontSize/svgFontSize+2*svgPadding)
+	y := func(v float64) float64 {
+		return plotBottom - plotHeight*svgScale(v, ticks[0], ticks[len(ticks)-1])
+	}
+	// x is the centre of the i-th category, spaced by time for a line over
+	// time and evenly otherwise
+	x := func(i int) float64 {
+		return left + (float64(i)+0.5)*band
+	}
+	if first, last, ok := timeSpan(chart.times); ok && chart.line && len(chart.times) == count {
+		x = func(i int) float64 {
+			return left + band/2 + (plotWidth-band)*float64(chart.times[i].Sub(first))/float64(last.Sub(first))
+		}
+	}
+
+	writeSVGStart(buf, theme, width, height, title)
+
+	for i, tick := range ticks {
+		fmt.Fprintf(buf, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+			svgNum(left), svgNum(y(tick)), svgNum(left+plotWidth), svgNum(y(tick)), theme.border)
+		fmt.Fprintf(buf, `<text x="%s" y="%s" text-anchor="end" dominant-baseline="middle" fill="%s">%s</text>`+"\n",
+			svgNum(left-6), svgNum(y(tick)), theme.muted, tickLabels[i])
+	}
+	baseline := y(max(ticks[0], 0))
+	fmt.Fprintf(buf, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+		svgNum(left), svgNum(baseline), svgNum(left+plotWidth), svgNum(baseline), theme.muted)
+	// Labels that would overlap the previous one are left out, which only
+	// happens when times bunch points together
+	labelEnd := math.Inf(-1)
+	for i, category := range categories {
+		if i >= count || x(i)-svgTextWidth(category)/2 < labelEnd {
+			continue
+		}
+		labelEnd = x(i) + svgTextWidth(category)/2 + 4
+		fmt.Fprintf(buf, `<text x="%s" y="%s" text-anchor="middle" fill="%s">%s</text>`+"\n",
+			svgNum(x(i)), svgNum(plotBottom+18), theme.text, svgEscape(category))
+	}
+
+	// pointTitle is the tooltip of a bar or point
+	pointTitle := func(s ChartSeries, i int) string {
+		text := formatDecimal(s.Values[i], -1, true)
+		if i < len(categories) {
+			text = categories[i] + ": " + text
+		}
+		if s.Name != "" {
+			text = s.Name + ", " + text
+		}
+		return svgEscape(text)
+	}
+	barWidth := band * 0.8 / float64(max(len(series), 1))
+	if chart.stacked {
+		barWidth = band * 0.6
+		clear(above)
+		clear(below)
+	}
+	for j, s := range series {
+		var points []string
+		for i, v := range s.Values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			if chart.line {
+				points = append(points, svgNum(x(i))+","+svgNum(y(v)))
+				continue
+			}
+			bx, from, to := left+float64(i)*band+band*0.1+float64(j)*barWidth, 0.0, v
+			if chart.stacked {
+				bx = left + float64(i)*band + band*0.2
+				if v >= 0 {
+					from, to = above[i], above[i]+v
+					above[i] = to
+				} else {
+					from, to = below[i], below[i]+v
+					below[i] = to
+				}
+			}
+			fmt.Fprintf(buf, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"><title>%s</title></rect>`+"\n",
+				svgNum(bx), svgNum(min(y(from), y(to))), svgNum(barWidth), svgNum(math.Abs(y(to)-y(from))), theme.color(j), pointTitle(s, i))
+		}
+		if !chart.line {
+			continue
+		}
+		if len(points) > 1 {
+			fmt.Fprintf(buf, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n",
+				strings.Join(points, " "), theme.color(j))
+		}
+		// Points go on top of the line
+		for i, v := range s.Values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			fmt.Fprintf(buf, `<circle cx="%s" cy="%s" r="3" fill="%s"><title>%s</title></circle>`+"\n",
+				svgNum(x(i)), svgNum(y(v)), theme.color(j), pointTitle(s, i))
+		}
+	}
+
+	if legend {
+		writeSVGLegend(buf, theme, (width-legendWidth+legendGap)/2, plotBottom+36, seriesNames(series))
+	}
+	buf.WriteString("</svg>\n")
+	return width, height
+}
+
+// renderScatterSVG draws an XY scatter chart with a value axis on the left
+// and along the bottom and a legend of the series below, and returns its
+// size. Points with NaN or infinite coordinates are left out.
+func renderScatterSVG(buf *bytes.Buffer, title string, data *ScatterData, theme *svgTheme) (float64, float64) {
+	const (
+		plotWidth  = 360.0
+		plotHeight = 240.0
+		legendGap  = 16.0
+	)
+	finite := func(p XYPoint) bool {
+		return !math.IsNaN(p.X) && !math.IsInf(p.X, 0) && !math.IsNaN(p.Y) && !math.IsInf(p.Y, 0)
+	}
+	xlo, xhi, ylo, yhi := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	names := make([]string, len(data.Series))
+	legend := false
+	legendWidth := 0.0
+	for i, s := range data.Series {
+		names[i] = s.Name
+		legend = legend || s.Name != ""
+		legendWidth += 18 + svgTextWidth(s.Name) + legendGap
+		for _, p := range s.Points {
+			if finite(p) {
+				xlo, xhi = min(xlo, p.X), max(xhi, p.X)
+				ylo, yhi = min(ylo, p.Y), max(yhi, p.Y)
+			}
+		}
+	}
+	legend = legend || len(data.Series) > 1
+	if xlo > xhi {
+		xlo, xhi, ylo, yhi = 0, 0, 0, 0
+	}
+	xTicks, xDecimals := svgAxisTicks(xlo, xhi)
+	yTicks, yDecimals := svgAxisTicks(ylo, yhi)
+	yLabels := make([]string, len(yTicks))
+	labelWidth := 0.0
+	for i, tick := range yTicks {
+		yLabels[i] = formatDecimal(tick, yDecimals, true)
+		labelWidth = max(labelWidth, svgTextWidth(yLabels[i]))
+	}
+
+	top := svgPadding + svgTitleOffset(title)
+	left := svgPadding + labelWidth + 8
+	plotBottom := top + plotHeight
+	height := plotBottom + 24 + svgPadding
+	if legend {
+		height += 24
+	}
+	// The last x tick label is centred on the right edge of the plot
+	lastLabel := formatDecimal(xTicks[len(xTicks)-1], xDecimals, true)
+	width := max(left+plotWidth+svgTextWidth(lastLabel)/2+svgPadding, legendWidth+2*svgPadding, svgTextWidth(title)*svgTitleFontSize/svgFontSize+2*svgPadding)
+	x := func(v float64) float64 {
+		return left + plotWidth*svgScale(v, xTicks[0], xTicks[len(xTicks)-1])
+	}
+	y := func(v float64) float64 {
+		return plotBottom - plotHeight*svgScale(v, yTicks[0], yTicks[len(yTicks)-1])
+	}
+
+	writeSVGStart(buf, theme, width, height, title)
+
+	for i, tick := range yTicks {
+		fmt.Fprintf(buf, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+			svgNum(left), svgNum(y(tick)), svgNum(left+plotWidth), svgNum(y(tick)), theme.border)
+		fmt.Fprintf(buf, `<text x="%s" y="%s" text-anchor="end" dominant-baseline="middle" fill="%s">%s</text>`+"\n",
+			svgNum(left-6), svgNum(y(tick)), theme.muted, yLabels[i])
+	}
+	for _, tick := range xTicks {
+		fmt.Fprintf(buf, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+			svgNum(x(tick)), svgNum(top), svgNum(x(tick)), svgNum(plotBottom), theme.border)
+		fmt.Fprintf(buf, `<text x="%s" y="%s" text-anchor="middle" fill="%s">%s</text>`+"\n",
+			svgNum(x(tick)), svgNum(plotBottom+18), theme.muted, formatDecimal(tick, xDecimals, true))
+	}
+	fmt.Fprintf(buf, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+		svgNum(left), svgNum(plotBottom), svgNum(left+plotWidth), svgNum(plotBottom), theme.muted)
+	fmt.Fprintf(buf, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+		svgNum(left), svgNum(top), svgNum(left), svgNum(plotBottom), theme.muted)
+
+	for j, s := range data.Series {
+		for _, p := range s.Points {
+			if !finite(p) {
+				continue
+			}
+			text := "(" + formatDecimal(p.X, -1, true) + ", " + formatDecimal(p.Y, -1, true) + ")"
+			if s.Name != "" {
+				text = s.Name + ": " + text
+			}
+			fmt.Fprintf(buf, `<circle cx="%s" cy="%s" r="4" fill="%s" fill-opacity="0.8"><title>%s</title></circle>`+"\n",
+				svgNum(x(p.X)), svgNum(y(p.Y)), theme.color(j), svgEscape(text))
+		}
+	}
+
+	if legend {
+		writeSVGLegend(buf, theme, (width-legendWidth+legendGap)/2, plotBottom+36, names)
+	}
+	buf.WriteString("</svg>\n")
+	return width, height
+}
+
+// timeSpan returns the earliest and latest of times, and false when there
+// are fewer than two distinct times
+func timeSpan(times []time.Time) (time.Time, time.Time, bool) {
+	if len(times) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	first, last := times[0], times[0]
+	for _, t := range times[1:] {
+		if t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	return first, last, last.After(first)
+}
+
+// seriesNames returns the name of each series
+func seriesNames(series []ChartSeries) []string {
+	names := make([]string, len(series))
+	for i, s := range series {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// writeSVGLegend writes a row of coloured swatches with the names of the
+// series of a chart, starting at (x, y)
+func writeSVGLegend(buf *bytes.Buffer, theme *svgTheme, x, y float64, names []string) {
+	const legendGap = 16.0
+	for j, name := range names {
+		fmt.Fprintf(buf, `<rect x="%s" y="%s" width="12" height="12" fill="%s"/>`+"\n",
+			svgNum(x), svgNum(y), theme.color(j))
+		fmt.Fprintf(buf, `<text x="%s" y="%s" fill="%s">%s</text>`+"\n",
+			svgNum(x+18), svgNum(y+10), theme.text, svgEscape(name))
+		x += 18 + svgTextWidth(name) + legendGap
+	}
+}
@@ -0,0 +1,461 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderPieSVG(t *testing.T) {
+	tests := map[string]struct {
+		data     *PieData
+		want     []string
+		unwanted []string
+	}{
+		"two slices": {
+			data: &PieData{Slices: []PieSlice{{Label: "A", Value: 75}, {Label: "B", Value: 25}}},
+			want: []string{
+				`<path d="M136 168L136 48A120 120 0 1 1 16 168Z" fill="#2563eb"`,
+				`<path d="M136 168L16 168A120 120 0 0 1 136 48Z" fill="#f59e0b"`,
+				">75.0%</text>", ">25.0%</text>",
+				">A</text>", ">B</text>",
+			},
+		},
+		"show data": {
+			data: &PieData{ShowData: true, Slices: []PieSlice{{Label: "A", Value: 1.5}}},
+			want: []string{`<circle cx="136" cy="168" r="120" fill="#2563eb"/>`, ">A [1.5]</text>"},
+		},
+		"no positive values": {
+			data:     &PieData{Slices: []PieSlice{{Label: "A", Value: 0}}},
+			want:     []string{`fill="#f9fafb" stroke="#e5e7eb"`, ">A</text>"},
+			unwanted: []string{"<path", "%</text>"},
+		},
+		"labels are escaped": {
+			data:     &PieData{Slices: []PieSlice{{Label: "<b>&", Value: 1}}},
+			want:     []string{">&lt;b&gt;&amp;</text>"},
+			unwanted: []string{"<b>"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			renderPieSVG(&buf, "Share", tc.data, &defaultSVGTheme)
+			got := buf.String()
+			if !strings.HasPrefix(got, `<svg xmlns="http://www.w3.org/2000/svg"`) || !strings.HasSuffix(got, "</svg>\n") {
+				t.Errorf("output is not an svg element:\n%s", got)
+			}
+			for _, want := range append(tc.want, "<title>Share</title>") {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tc.unwanted {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("output contains %q\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderPieSVG_NonFinite(t *testing.T) {
+	doc := New().AddContent(NewPieChart("Share", []PieSlice{
+		{Label: "A", Value: 3},
+		{Label: "B", Value: math.Inf(1)},
+		{Label: "C", Value: math.NaN()},
+	}, false)).Build()
+
+	for name, format := range map[string]Format{"svg": SVG(), "html offline": HTMLOffline()} {
+		t.Run(name, func(t *testing.T) {
+			out, err := format.Renderer.Render(context.Background(), doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			got := string(out)
+			// A is the only drawable slice, so it fills the pie
+			for _, want := range []string{`<circle cx="136" cy="168" r="120" fill="#2563eb"/>`, ">100.0%</text>", ">B</text>", ">C</text>"} {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q\n%s", want, got)
+				}
+			}
+			for _, unwanted := range []string{"NaN", "Inf", "<path"} {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("output contains %q\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGanttBars(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	data := &GanttData{
+		DateFormat: "YYYY-MM-DD",
+		Tasks: []GanttTask{
+			{ID: "a", Title: "Design", StartDate: "2024-01-01", Duration: "5d"},
+			{ID: "b", Title: "Build", StartDate: "after a", Duration: "1w"},
+			{Title: "Test", StartDate: "2024-01-03", EndDate: "2024-01-20"},
+			{Title: "Release"},
+			{Title: "Review", StartDate: "after a b", Duration: "12h"},
+		},
+	}
+	want := []struct{ start, end time.Time }{
+		{date("2024-01-01"), date("2024-01-06")},
+		{date("2024-01-06"), date("2024-01-13")},
+		{date("2024-01-03"), date("2024-01-20")},
+		{date("2024-01-20"), date("2024-01-21")},
+		{date("2024-01-13"), date("2024-01-13").Add(12 * time.Hour)},
+	}
+	bars := ganttBars(data)
+	if len(bars) != len(want) {
+		t.Fatalf("ganttBars() returned %d bars, want %d", len(bars), len(want))
+	}
+	for i, bar := range bars {
+		if !bar.start.Equal(want[i].start) || !bar.end.Equal(want[i].end) {
+			t.Errorf("bar %q = %v – %v, want %v – %v", bar.task.Title, bar.start, bar.end, want[i].start, want[i].end)
+		}
+	}
+
+	if bars := ganttBars(&GanttData{DateFormat: "YYYY-MM-DD", Tasks: []GanttTask{{Title: "Unplaced", StartDate: "soon"}}}); len(bars) != 0 {
+		t.Errorf("ganttBars() placed a task without a start: %v", bars)
+	}
+}
+
+func TestFormatStrftime(t *testing.T) {
+	ts := time.Date(2024, time.March, 5, 14, 7, 0, 0, time.UTC)
+	tests := map[string]struct {
+		format string
+		want   string
+	}{
+		"date":        {format: "%Y-%m-%d", want: "2024-03-05"},
+		"month names": {format: "%a %e %b", want: "Tue  5 Mar"},
+		"time":        {format: "%H:%M", want: "14:07"},
+		"literal":     {format: "100%% %q", want: "100% %q"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := formatStrftime(ts, tc.format); got != tc.want {
+				t.Errorf("formatStrftime(%q) = %q, want %q", tc.format, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderGanttSVG(t *testing.T) {
+	chart := NewGanttChart("Plan", []GanttTask{
+		{ID: "a", Title: "Design", StartDate: "2024-01-01", Duration: "5d", Status: "done", Section: "Planning"},
+		{Title: "Build", StartDate: "after a", Duration: "5d", Status: "crit, active", Section: "Delivery"},
+	})
+	var buf bytes.Buffer
+	if _, _, ok := renderChartSVG(&buf, chart, &defaultSVGTheme); !ok {
+		t.Fatal("renderChartSVG() = false for a Gantt chart")
+	}
+	got := buf.String()
+	for _, want := range []string{
+		">Planning</text>", ">Delivery</text>", ">Design</text>", ">Build</text>",
+		">2024-01-01</text>", ">2024-01-11</text>",
+		`fill="#6b7280" fill-opacity="0.6"><title>Design: 2024-01-01 – 2024-01-06</title>`,
+		`fill="#dc2626" fill-opacity="1"><title>Build: 2024-01-06 – 2024-01-11</title>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestGraphRanks(t *testing.T) {
+	tests := map[string]struct {
+		edges []Edge
+		want  map[string]int
+	}{
+		"longest path": {
+			edges: []Edge{{From: "A", To: "B"}, {From: "B", To: "C"}, {From: "A", To: "C"}},
+			want:  map[string]int{"A": 0, "B": 1, "C": 2},
+		},
+		"cycle": {
+			edges: []Edge{{From: "A", To: "B"}, {From: "B", To: "C"}, {From: "C", To: "A"}},
+			want:  map[string]int{"A": 0, "B": 1, "C": 2},
+		},
+		"self loop": {
+			edges: []Edge{{From: "A", To: "A"}, {From: "A", To: "B"}},
+			want:  map[string]int{"A": 0, "B": 1},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			graph := NewGraphContent("", tc.edges)
+			got := graphRanks(graph.GetNodes(), tc.edges)
+			for node, rank := range tc.want {
+				if got[node] != rank {
+					t.Errorf("rank of %s = %d, want %d", node, got[node], rank)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderGraphSVG(t *testing.T) {
+	graph := NewGraphContent("Flow", []Edge{
+		{From: "Start", To: "Process", Label: "begin"},
+		{From: "Process", To: "End"},
+		{From: "Process", To: "Process"},
+		{From: "Start", To: "End"},
+	})
+	var buf bytes.Buffer
+	renderGraphSVG(&buf, graph, &defaultSVGTheme)
+	got := buf.String()
+	for _, want := range []string{">Start</text>", ">Process</text>", ">End</text>", ">begin</text>", "<polygon", "<path", "Q"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q\n%s", want, got)
+		}
+	}
+
+	var again bytes.Buffer
+	renderGraphSVG(&again, graph, &defaultSVGTheme)
+	if again.String() != got {
+		t.Error("renderGraphSVG() output is not deterministic")
+	}
+}
+
+func TestHTMLOffline(t *testing.T) {
+	doc := New().
+		AddContent(NewPieChart("Share", []PieSlice{{Label: "A", Value: 1}}, false)).
+		AddContent(NewGraphContent("Flow", []Edge{{From: "A", To: "B"}})).
+		AddContent(NewChartContent("Custom", "sankey", nil)).
+		Build()
+
+	tests := map[string]struct {
+		format   Format
+		want     []string
+		unwanted []string
+	}{
+		"offline": {
+			format:   HTMLOffline(),
+			want:     []string{`<figure class="chart">`, "<svg", ">Share</text>", ">Flow</text>", "<pre>Custom\nChart Type: sankey\n</pre>"},
+			unwanted: []string{"mermaid", "cdn.jsdelivr.net", "<script"},
+		},
+		"default loads mermaid": {
+			format:   HTML(),
+			want:     []string{`<pre class="mermaid">`, "cdn.jsdelivr.net"},
+			unwanted: []string{"<svg"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.format.Renderer.Render(context.Background(), doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			var streamed bytes.Buffer
+			if err := tc.format.Renderer.RenderTo(context.Background(), doc, &streamed); err != nil {
+				t.Fatalf("RenderTo() error = %v", err)
+			}
+			for _, got := range []string{string(out), streamed.String()} {
+				for _, want := range tc.want {
+					if !strings.Contains(got, want) {
+						t.Errorf("output missing %q", want)
+					}
+				}
+				for _, unwanted := range tc.unwanted {
+					if strings.Contains(got, unwanted) {
+						t.Errorf("output contains %q", unwanted)
+					}
+				}
+			}
+		})
+	}
+
+	first, _ := HTMLOffline().Renderer.Render(context.Background(), doc)
+	second, _ := HTMLOffline().Renderer.Render(context.Background(), doc)
+	if !bytes.Equal(first, second) {
+		t.Error("HTMLOffline() output is not deterministic")
+	}
+	if DefaultHTMLTemplate.InlineCharts {
+		t.Error("HTMLOffline() modified DefaultHTMLTemplate")
+	}
+}
+
+func TestSVGAxisTicks(t *testing.T) {
+	tests := map[string]struct {
+		lo, hi       float64
+		want         []float64
+		wantDecimals int
+	}{
+		"integers":  {lo: -5, hi: 30, want: []float64{-10, 0, 10, 20, 30}},
+		"fractions": {lo: 0.1, hi: 0.3, want: []float64{0.1, 0.15, 0.2, 0.25, 0.3}, wantDecimals: 2},
+		"flat":      {lo: 0, hi: 0, want: []float64{-1, -0.5, 0, 0.5, 1}, wantDecimals: 1},
+		"large":     {lo: 0, hi: 98765, want: []float64{0, 20000, 40000, 60000, 80000, 100000}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, decimals := svgAxisTicks(tc.lo, tc.hi)
+			if len(got) != len(tc.want) || decimals != tc.wantDecimals {
+				t.Fatalf("svgAxisTicks() = %v, %d; want %v, %d", got, decimals, tc.want, tc.wantDecimals)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tc.want[i]) > 1e-9 {
+					t.Errorf("tick %d = %v, want %v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSVG_ExtremeValues(t *testing.T) {
+	m := math.MaxFloat64
+	tests := map[string]*ChartContent{
+		"line":    NewLineChart("", []string{"a", "b"}, []ChartSeries{{Values: []float64{-m, m}}}),
+		"stacked": NewStackedBarChart("", []string{"a"}, []ChartSeries{{Values: []float64{m}}, {Values: []float64{m}}}),
+		"flat":    NewBarChart("", []string{"a"}, []ChartSeries{{Values: []float64{m}}}),
+		"scatter": NewScatterChart("", []ScatterSeries{{Points: []XYPoint{{X: -m, Y: -m}, {X: m, Y: m}}}}),
+	}
+	for name, chart := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := SVG().Renderer.Render(context.Background(), New().AddContent(chart).Build())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if strings.Contains(string(out), "NaN") || strings.Contains(string(out), "Inf") {
+				t.Errorf("Render() wrote non-finite coordinates\n%s", out)
+			}
+		})
+	}
+}
+
+func TestRenderSeriesSVG(t *testing.T) {
+	tests := map[string]struct {
+		chart    *ChartContent
+		want     []string
+		unwanted []string
+	}{
+		"grouped bars": {
+			chart: NewBarChart("Sales", []string{"Q1", "Q2"}, []ChartSeries{
+				{Name: "2023", Values: []float64{10, -5}},
+				{Name: "2024", Values: []float64{20}},
+			}),
+			want: []string{
+				">Q1</text>", ">Q2</text>", ">-5</text>", ">20</text>",
+				`fill="#2563eb"><title>2023, Q1: 10</title></rect>`,
+				`fill="#2563eb"><title>2023, Q2: -5</title></rect>`,
+				`fill="#f59e0b"><title>2024, Q1: 20</title></rect>`,
+				">2023</text>", ">2024</text>",
+			},
+			unwanted: []string{"<polyline", "2024, Q2"},
+		},
+		"line": {
+			chart: NewLineChart("", []string{"Jan", "Feb", "Mar"}, []ChartSeries{
+				{Values: []float64{1, math.NaN(), 3}},
+			}),
+			want:     []string{"<polyline", "<title>Jan: 1</title></circle>", "<title>Mar: 3</title></circle>"},
+			unwanted: []string{"<title>Feb", "<rect x"},
+		},
+		"stacked bars": {
+			chart: NewStackedBarChart("", []string{"Q1"}, []ChartSeries{
+				{Name: "EU", Values: []float64{10}},
+				{Name: "US", Values: []float64{-4}},
+				{Name: "APAC", Values: []float64{5}},
+			}),
+			want: []string{
+				// Positive values stack from zero upwards, negative values
+				// downwards: the axis runs from -5 to 15
+				`<rect x="102" y="76" width="192" height="120" fill="#2563eb"><title>EU, Q1: 10</title></rect>`,
+				`<rect x="102" y="196" width="192" height="48" fill="#f59e0b"><title>US, Q1: -4</title></rect>`,
+				`<rect x="102" y="16" width="192" height="60" fill="#10b981"><title>APAC, Q1: 5</title></rect>`,
+			},
+		},
+		"line over time": {
+			chart: NewTimeSeriesChart("", []time.Time{
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+			}, []ChartSeries{{Values: []float64{1, 2, 3}}}),
+			// Points are a tenth of the way apart, then nine tenths
+			want: []string{
+				`<circle cx="98.33" `,
+				`<circle cx="119.67" `,
+				`<circle cx="311.67" `,
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			width, height, ok := renderChartSVG(&buf, tc.chart, &defaultSVGTheme)
+			if !ok || width <= 0 || height <= 0 {
+				t.Fatalf("renderChartSVG() = %v, %v, %v", width, height, ok)
+			}
+			got := buf.String()
+			for _, want := range tc.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tc.unwanted {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("output contains %q\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderScatterSVG(t *testing.T) {
+	chart := NewScatterChart("Latency", []ScatterSeries{
+		{Name: "api", Points: []XYPoint{{X: 0, Y: 0}, {X: 10, Y: 100}, {X: math.NaN(), Y: 5}}},
+		{Name: "web", Points: []XYPoint{{X: 5, Y: 50}}},
+	})
+	var buf bytes.Buffer
+	width, height, ok := renderChartSVG(&buf, chart, &defaultSVGTheme)
+	if !ok || width <= 0 || height <= 0 {
+		t.Fatalf("renderChartSVG() = %v, %v, %v", width, height, ok)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		">Latency</text>",
+		`fill="#2563eb" fill-opacity="0.8"><title>api: (0, 0)</title></circle>`,
+		`fill="#2563eb" fill-opacity="0.8"><title>api: (10, 100)</title></circle>`,
+		`fill="#f59e0b" fill-opacity="0.8"><title>web: (5, 50)</title></circle>`,
+		">api</text>", ">web</text>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "NaN") {
+		t.Errorf("output contains NaN\n%s", got)
+	}
+	if n := strings.Count(got, "<circle"); n != 3 {
+		t.Errorf("output has %d points, want 3", n)
+	}
+}
+
+func TestNewSVGTheme(t *testing.T) {
+	theme := newSVGTheme(map[string]string{
+		"--color-primary":   "#ff0000",
+		"--color-text":      `red" onload="x`,
+		"--chart-color-2":   "#00ff00",
+		"--chart-color-9":   "#0000ff",
+		"--unrelated-color": "#123456",
+	})
+	if theme.primary != "#ff0000" || theme.palette[0] != "#ff0000" {
+		t.Errorf("primary = %q, palette[0] = %q, want #ff0000", theme.primary, theme.palette[0])
+	}
+	if theme.text != "red&#34; onload=&#34;x" {
+		t.Errorf("text = %q, want it escaped", theme.text)
+	}
+	if theme.palette[1] != "#00ff00" || len(theme.palette) != len(defaultSVGTheme.palette)+1 || theme.palette[8] != "#0000ff" {
+		t.Errorf("palette = %v", theme.palette)
+	}
+	if defaultSVGTheme.palette[0] != "#2563eb" {
+		t.Error("newSVGTheme() modified the default palette")
+	}
+}
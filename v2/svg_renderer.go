@@ -0,0 +1,133 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrSVGNothingToDraw is returned by SVG rendering when a document holds no
+// chart or graph that SVG can draw, since an empty image is never useful
+var ErrSVGNothingToDraw = errors.New("svg: document has no charts or graphs to draw")
+
+// svgRenderer implements SVG output: the charts and graphs of a document
+// drawn as a single image, stacked top to bottom. Other content is skipped,
+// and a document with nothing to draw is an ErrSVGNothingToDraw error.
+type svgRenderer struct {
+	baseRenderer
+	theme svgTheme
+}
+
+// svgDrawing is a drawn chart or graph with its size
+type svgDrawing struct {
+	data          []byte
+	width, height float64
+}
+
+func (s *svgRenderer) Format() string {
+	return FormatSVG
+}
+
+func (s *svgRenderer) Render(ctx context.Context, doc *Document) ([]byte, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("document cannot be nil")
+	}
+
+	drawings, err := s.draw(ctx, doc.GetContents(), nil)
+	if err != nil {
+		return nil, err
+	}
+	switch len(drawings) {
+	case 0:
+		return nil, ErrSVGNothingToDraw
+	case 1:
+		return drawings[0].data, nil
+	}
+
+	// Several drawings are placed below each other in one image, so the
+	// output is always a single valid SVG document
+	var width, height float64
+	for i, drawing := range drawings {
+		if i > 0 {
+			height += svgPadding
+		}
+		width = max(width, drawing.width)
+		height += drawing.height
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s">`+"\n",
+		svgNum(width), svgNum(height), svgNum(width), svgNum(height))
+	offset := 0.0
+	for _, drawing := range drawings {
+		fmt.Fprintf(&buf, "<g transform=\"translate(0 %s)\">\n", svgNum(offset))
+		buf.Write(drawing.data)
+		buf.WriteString("</g>\n")
+		offset += drawing.height + svgPadding
+	}
+	buf.WriteString("</svg>\n")
+	return buf.Bytes(), nil
+}
+
+// draw appends the drawings of the charts and graphs among contents,
+// including those nested in sections, to drawings
+func (s *svgRenderer) draw(ctx context.Context, contents []Content, drawings []svgDrawing) ([]svgDrawing, error) {
+	for _, content := range contents {
+		if content == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		transformed, err := applyContentTransformations(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		var width, height float64
+		switch c := transformed.(type) {
+		case *ChartContent:
+			var ok bool
+			if width, height, ok = renderChartSVG(&buf, c, &s.theme); !ok {
+				continue
+			}
+		case *GraphContent:
+			width, height = renderGraphSVG(&buf, c, &s.theme)
+		case *SectionContent:
+			if drawings, err = s.draw(ctx, c.Contents(), drawings); err != nil {
+				return nil, err
+			}
+			continue
+		case *DefaultCollapsibleSection:
+			if drawings, err = s.draw(ctx, c.Content(), drawings); err != nil {
+				return nil, err
+			}
+			continue
+		default:
+			continue
+		}
+		drawings = append(drawings, svgDrawing{data: buf.Bytes(), width: width, height: height})
+	}
+	return drawings, nil
+}
+
+func (s *svgRenderer) RenderTo(ctx context.Context, doc *Document, w io.Writer) error {
+	if w == nil {
+		return fmt.Errorf("writer cannot be nil")
+	}
+	data, err := s.Render(ctx, doc)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (s *svgRenderer) SupportsStreaming() bool {
+	return false
+}
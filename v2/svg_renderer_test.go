@@ -0,0 +1,132 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSVGRenderer(t *testing.T) {
+	pie := NewPieChart("Share", []PieSlice{{Label: "A", Value: 1}}, false)
+	bar := NewBarChart("Sales", []string{"Q1"}, []ChartSeries{{Name: "2024", Values: []float64{3}}})
+	table, err := NewTableContent("Hosts", []Record{{"Host": "web"}}, WithKeys("Host"))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+
+	section := NewSectionContent("Details")
+	section.AddContent(bar)
+
+	tests := map[string]struct {
+		format    Format
+		doc       *Document
+		wantRoots int
+		want      []string
+		unwanted  []string
+	}{
+		"single chart": {
+			format:    SVG(),
+			doc:       New().AddContent(pie).AddContent(table).Build(),
+			wantRoots: 1,
+			want:      []string{">Share</text>"},
+			unwanted:  []string{"<g transform", "web"},
+		},
+		"charts are stacked": {
+			format: SVG(),
+			doc: New().
+				AddContent(pie).
+				AddContent(section).
+				AddContent(NewGraphContent("Flow", []Edge{{From: "A", To: "B"}})).
+				Build(),
+			wantRoots: 4,
+			want:      []string{`<g transform="translate(0 0)">`, ">Share</text>", ">Sales</text>", ">Flow</text>"},
+		},
+		"theme": {
+			format:    SVGWithTheme(map[string]string{"--chart-color-1": "#123456"}),
+			doc:       New().AddContent(bar).Build(),
+			wantRoots: 1,
+			want:      []string{`fill="#123456"><title>2024, Q1: 3</title>`},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.format.Renderer.Render(context.Background(), tc.doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			got := string(out)
+			if !strings.HasPrefix(got, "<svg") || !strings.HasSuffix(got, "</svg>\n") {
+				t.Errorf("output is not one svg element:\n%s", got)
+			}
+			if roots := strings.Count(got, "<svg"); roots != tc.wantRoots {
+				t.Errorf("output has %d svg elements, want %d", roots, tc.wantRoots)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tc.unwanted {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("output contains %q\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSVGRenderer_NothingToDraw(t *testing.T) {
+	table, err := NewTableContent("Hosts", []Record{{"Host": "web"}}, WithKeys("Host"))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	docs := map[string]*Document{
+		"empty":             New().Build(),
+		"table only":        New().AddContent(table).Build(),
+		"unsupported chart": New().AddContent(NewChartContent("Custom", "sankey", nil)).Build(),
+		"section of text":   New().AddContent(NewSectionContent("Notes")).Text("no charts").Build(),
+	}
+	for name, doc := range docs {
+		t.Run(name, func(t *testing.T) {
+			out, err := SVG().Renderer.Render(context.Background(), doc)
+			if !errors.Is(err, ErrSVGNothingToDraw) {
+				t.Errorf("Render() = %q, %v; want ErrSVGNothingToDraw", out, err)
+			}
+			var buf strings.Builder
+			if err := SVG().Renderer.RenderTo(context.Background(), doc, &buf); !errors.Is(err, ErrSVGNothingToDraw) || buf.Len() != 0 {
+				t.Errorf("RenderTo() wrote %q, error = %v; want ErrSVGNothingToDraw", buf.String(), err)
+			}
+		})
+	}
+}
+
+func TestHTMLInlineCharts_ThemeOverrides(t *testing.T) {
+	tmpl := *DefaultHTMLTemplate
+	tmpl.InlineCharts = true
+	tmpl.ThemeOverrides = map[string]string{"--color-primary": "#ff0000"}
+	doc := New().AddContent(NewPieChart("Share", []PieSlice{{Label: "A", Value: 1}}, false)).Build()
+
+	out, err := HTMLWithTemplate(&tmpl).Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(string(out), `r="120" fill="#ff0000"`) {
+		t.Errorf("chart does not use the primary colour override\n%s", out)
+	}
+}
+
+func TestSVGAppendRejected(t *testing.T) {
+	dir := t.TempDir()
+	fw, err := NewFileWriterWithOptions(dir, "out.{ext}", WithAppendMode())
+	if err != nil {
+		t.Fatalf("NewFileWriterWithOptions() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := fw.Write(ctx, FormatSVG, []byte("<svg></svg>")); err != nil {
+		t.Fatalf("first Write() error = %v", err)
+	}
+	if err := fw.Write(ctx, FormatSVG, []byte("<svg></svg>")); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("second Write() error = %v, want append not supported", err)
+	}
+}
@@ -0,0 +1,134 @@
+package output
+
+import (
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/mattn/go-isatty"
+)
+
+// autoFitMinColumnWidth is the narrowest a column is wrapped to before
+// auto-fit drops columns instead
+const autoFitMinColumnWidth = 8
+
+// detectTerminalWidth returns the width available for table output: the
+// $COLUMNS environment variable when set, otherwise the width of stdout when
+// it is a terminal. It returns 0 when the width is unknown.
+func detectTerminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	fd := os.Stdout.Fd()
+	if !isatty.IsTerminal(fd) && !isatty.IsCygwinTerminal(fd) {
+		return 0
+	}
+	return terminalWidth(fd)
+}
+
+// autoFitLayout is the result of fitting a table to a width: the columns to
+// keep (as indexes into the key order), their maximum widths, and the names
+// of dropped columns.
+type autoFitLayout struct {
+	keep    []int
+	widths  []int
+	dropped []string
+}
+
+// fitColumns lays out columns within width. Columns keep their natural width
+// when everything fits. Otherwise the space left after the table borders is
+// shared in proportion to each column's natural width, with no column
+// narrower than autoFitMinColumnWidth (or its natural width, when smaller).
+// When even that does not fit, columns are dropped in order of ascending
+// Field.Priority, rightmost first among equal priorities.
+func fitColumns(style table.Style, fields []*Field, keys []string, natural []int, width int) autoFitLayout {
+	keep := make([]int, len(keys))
+	for i := range keep {
+		keep[i] = i
+	}
+
+	minWidth := func(i int) int {
+		return min(natural[i], autoFitMinColumnWidth)
+	}
+	priority := func(i int) int {
+		if fields[i] == nil {
+			return 0
+		}
+		return fields[i].Priority
+	}
+
+	var dropped []int
+	for len(keep) > 1 {
+		required := tableOverhead(style, len(keep))
+		for _, i := range keep {
+			required += minWidth(i)
+		}
+		if required <= width {
+			break
+		}
+		// Drop the lowest priority column, preferring the rightmost
+		drop := 0
+		for k := range keep {
+			if priority(keep[k]) <= priority(keep[drop]) {
+				drop = k
+			}
+		}
+		dropped = append(dropped, keep[drop])
+		keep = slices.Delete(keep, drop, drop+1)
+	}
+
+	available := width - tableOverhead(style, len(keep))
+	widths := make([]int, len(keep))
+	total, totalMin := 0, 0
+	for k, i := range keep {
+		widths[k] = natural[i]
+		total += natural[i]
+		totalMin += minWidth(i)
+	}
+	if total > available {
+		// Share the space above the minimums in proportion to the natural
+		// width each column needs beyond its minimum
+		extra := max(available-totalMin, 0)
+		need := total - totalMin
+		for k, i := range keep {
+			widths[k] = minWidth(i)
+			if need > 0 {
+				widths[k] += extra * (natural[i] - minWidth(i)) / need
+			}
+		}
+	}
+
+	slices.Sort(dropped)
+	layout := autoFitLayout{keep: keep, widths: widths}
+	for _, i := range dropped {
+		layout.dropped = append(layout.dropped, keys[i])
+	}
+	return layout
+}
+
+// tableOverhead returns the width a style adds around n columns: padding,
+// column separators and the left and right border
+func tableOverhead(style table.Style, n int) int {
+	overhead := n * (text.RuneWidthWithoutEscSequences(style.Box.PaddingLeft) +
+		text.RuneWidthWithoutEscSequences(style.Box.PaddingRight))
+	if style.Options.SeparateColumns && n > 1 {
+		overhead += (n - 1) * text.RuneWidthWithoutEscSequences(style.Box.MiddleVertical)
+	}
+	if style.Options.DrawBorder {
+		overhead += text.RuneWidthWithoutEscSequences(style.Box.Left) +
+			text.RuneWidthWithoutEscSequences(style.Box.Right)
+	}
+	return overhead
+}
+
+// cellWidth returns the display width of the widest line of a cell
+func cellWidth(cell string) int {
+	width := 0
+	for line := range strings.SplitSeq(cell, "\n") {
+		width = max(width, text.RuneWidthWithoutEscSequences(line))
+	}
+	return width
+}
@@ -0,0 +1,142 @@
+package output
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func maxLineWidth(s string) int {
+	width := 0
+	for line := range strings.SplitSeq(strings.TrimRight(s, "\n"), "\n") {
+		width = max(width, text.RuneWidthWithoutEscSequences(line))
+	}
+	return width
+}
+
+func TestTableAutoFit(t *testing.T) {
+	doc := New().Table("", []Record{
+		{"ID": "i-1", "Name": "web", "Region": "eu-west-1", "Notes": "serves the public website behind the load balancer", "Owner": "platform"},
+		{"ID": "i-2", "Name": "db", "Region": "us-east-1", "Notes": "primary database", "Owner": "data"},
+	}, WithSchema(
+		Field{Name: "ID", Priority: 10},
+		Field{Name: "Name", Priority: 10},
+		Field{Name: "Region", Priority: -1},
+		Field{Name: "Notes"},
+		Field{Name: "Owner"},
+	)).Build()
+
+	tests := map[string]struct {
+		width       int
+		wantDropped []string
+		wantKept    []string
+	}{
+		"wide enough keeps layout": {
+			width:    200,
+			wantKept: []string{"REGION", "NOTES", "OWNER", "serves the public website behind the load balancer"},
+		},
+		"wraps long cells": {
+			width:    70,
+			wantKept: []string{"REGION", "NOTES", "OWNER", "serves the"},
+		},
+		"drops lowest priority first": {
+			width:       45,
+			wantDropped: []string{"Region"},
+			wantKept:    []string{"ID", "NAME", "NOTES", "OWNER"},
+		},
+		"drops rightmost among equal priorities": {
+			width:       34,
+			wantDropped: []string{"Region", "Owner"},
+			wantKept:    []string{"ID", "NAME", "NOTES"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := TableWithAutoFitWidth("Default", tc.width).Renderer.Render(context.Background(), doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			got := string(out)
+
+			if width := maxLineWidth(got); width > tc.width {
+				t.Errorf("widest line = %d, want <= %d\n%s", width, tc.width, got)
+			}
+			for _, want := range tc.wantKept {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q\n%s", want, got)
+				}
+			}
+			if len(tc.wantDropped) == 0 {
+				if strings.Contains(got, "hidden") {
+					t.Errorf("output notes hidden columns, want none\n%s", got)
+				}
+				return
+			}
+			note := "Columns hidden to fit width: " + strings.Join(tc.wantDropped, ", ")
+			if !strings.Contains(strings.Join(strings.Fields(got), " "), note) {
+				t.Errorf("output missing note %q\n%s", note, got)
+			}
+			for _, dropped := range tc.wantDropped {
+				if strings.Contains(got, strings.ToUpper(dropped)) {
+					t.Errorf("output still has column %s\n%s", dropped, got)
+				}
+			}
+		})
+	}
+
+	t.Run("wide enough matches default", func(t *testing.T) {
+		ctx := context.Background()
+		fitted, err := TableWithAutoFitWidth("Default", 200).Renderer.Render(ctx, doc)
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		plain, err := TableWithStyle("Default").Renderer.Render(ctx, doc)
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if string(fitted) != string(plain) {
+			t.Errorf("auto-fit output differs when the table fits\nfitted:\n%s\nplain:\n%s", fitted, plain)
+		}
+	})
+
+	t.Run("detects COLUMNS", func(t *testing.T) {
+		t.Setenv("COLUMNS", "45")
+		if got := detectTerminalWidth(); got != 45 {
+			t.Fatalf("detectTerminalWidth() = %d, want 45", got)
+		}
+
+		out, err := TableWithAutoFit("Default").Renderer.Render(context.Background(), doc)
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if width := maxLineWidth(string(out)); width > 45 {
+			t.Errorf("widest line = %d, want <= 45\n%s", width, out)
+		}
+	})
+}
+
+func TestStructTagPriority(t *testing.T) {
+	type row struct {
+		Name string `output:"name,priority=5"`
+		Note string `output:"note,priority=-2"`
+	}
+	table, err := NewTableContent("", []row{{Name: "web", Note: "x"}})
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	if got := table.Schema().FindField("name").Priority; got != 5 {
+		t.Errorf("name priority = %d, want 5", got)
+	}
+	if got := table.Schema().FindField("note").Priority; got != -2 {
+		t.Errorf("note priority = %d, want -2", got)
+	}
+
+	if _, err := NewTableContent("", []struct {
+		A string `output:"a,priority=high"`
+	}{{}}); err == nil {
+		t.Error("NewTableContent() with priority=high error = nil, want error")
+	}
+}
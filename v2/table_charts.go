@@ -0,0 +1,575 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/mattn/go-isatty"
+)
+
+// ChartStyle selects the characters the table format draws charts with
+type ChartStyle int
+
+const (
+	// ChartStyleAuto draws charts with Unicode when the output goes to a
+	// terminal and with ASCII otherwise: RenderTo checks its writer, and
+	// Output checks each of its writers. Render cannot tell where its bytes
+	// go and draws ASCII.
+	ChartStyleAuto ChartStyle = iota
+	// ChartStyleASCII draws charts with ASCII characters and no colour
+	ChartStyleASCII
+	// ChartStyleUnicode draws charts with Unicode block characters, coloured
+	// with the renderer's ColorScheme
+	ChartStyleUnicode
+)
+
+// Sizes of charts drawn as text in table output, in characters
+const (
+	textChartWidth  = 40 // Width of the longest bar, a Gantt timeline or a scatter plot
+	textChartHeight = 12 // Height of a scatter plot
+)
+
+// textChartPen holds the characters and colours text charts are drawn with.
+// Series take the next mark, point and colour in turn, repeating when there
+// are more series.
+type textChartPen struct {
+	marks  []string // Bars of each series
+	points []string // Scatter points of each series
+	levels []string // Sparkline characters from lowest to highest
+	axis   string   // Vertical axis
+	rule   string   // Horizontal axis
+	corner string   // Where the axes meet
+	tick   string   // Tick on a horizontal axis
+	done   string   // Bars of done Gantt tasks
+	crit   string   // Bars of critical Gantt tasks
+	colors []string // Colour names of each series; none draws without colour
+	scheme ColorScheme
+}
+
+// asciiChartPen draws text charts with ASCII characters and no colour
+var asciiChartPen = textChartPen{
+	marks:  []string{"#", "=", "*", "+", "%", "@", "o", "~"},
+	points: []string{"#", "=", "*", "+", "%", "@", "o", "~"},
+	levels: []string{"_", ".", "-", "~", "=", "+", "*", "#"},
+	axis:   "|",
+	rule:   "-",
+	corner: "+",
+	tick:   "+",
+	done:   "=",
+	crit:   "!",
+}
+
+// unicodeChartPen returns a pen drawing text charts with Unicode block
+// characters, colouring series with the info, success, warning and error
+// colours of scheme in turn
+func unicodeChartPen(scheme ColorScheme) textChartPen {
+	return textChartPen{
+		marks:  []string{"█", "▓", "▒", "░"},
+		points: []string{"●", "■", "▲", "◆"},
+		levels: []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"},
+		axis:   "│",
+		rule:   "─",
+		corner: "└",
+		tick:   "┬",
+		done:   "▒",
+		crit:   "█",
+		colors: []string{scheme.Info, scheme.Success, scheme.Warning, scheme.Error},
+		scheme: scheme,
+	}
+}
+
+// chartPen returns the pen for the renderer's chart style. RenderTo and
+// Output resolve the automatic style for their writers first, so here it
+// draws ASCII.
+func (t *tableRenderer) chartPen() textChartPen {
+	if t.chartStyle != ChartStyleUnicode {
+		return asciiChartPen
+	}
+	if t.chartScheme != nil {
+		return unicodeChartPen(*t.chartScheme)
+	}
+	return unicodeChartPen(DefaultColorScheme())
+}
+
+// forTerminal implements terminalRenderer: for the automatic chart style it
+// returns a copy drawing Unicode charts
+func (t *tableRenderer) forTerminal() Renderer {
+	if t.chartStyle != ChartStyleAuto {
+		return nil
+	}
+	terminal := *t
+	terminal.chartStyle = ChartStyleUnicode
+	return &terminal
+}
+
+// forWriter returns the renderer to write to w with, which draws Unicode
+// charts for the automatic chart style when w is a terminal
+func (t *tableRenderer) forWriter(w io.Writer) *tableRenderer {
+	if terminal, ok := t.forTerminal().(*tableRenderer); ok && isTerminal(w) {
+		return terminal
+	}
+	return t
+}
+
+// isTerminal reports whether w is a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+// mark returns the bar character of the i-th series
+func (p textChartPen) mark(i int) string {
+	return p.marks[i%len(p.marks)]
+}
+
+// point returns the scatter point character of the i-th series
+func (p textChartPen) point(i int) string {
+	return p.points[i%len(p.points)]
+}
+
+// paint colours s with the colour of the i-th series, if the pen has colours
+func (p textChartPen) paint(s string, i int) string {
+	if len(p.colors) == 0 || s == "" {
+		return s
+	}
+	return applySchemeColor(s, p.colors[i%len(p.colors)], false)
+}
+
+// bar returns n bar characters of the i-th series
+func (p textChartPen) bar(n, i int) string {
+	return p.paint(strings.Repeat(p.mark(i), n), i)
+}
+
+// finiteValue reports whether v can be drawn
+func finiteValue(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// textChartCell maps a finite v in lo to hi onto one of n cells. Values are
+// halved first so that the range of values near ±math.MaxFloat64 does not
+// overflow to infinity; a range too small to measure maps to the middle.
+func textChartCell(v, lo, hi float64, n int) int {
+	span := hi/2 - lo/2
+	if !(span > 0) {
+		return n / 2
+	}
+	cell := math.Round((v/2 - lo/2) / span * float64(n-1))
+	return int(min(float64(n-1), max(0, cell)))
+}
+
+// renderChartText draws charts as text for table output: bars for pie and
+// bar charts, a sparkline per series for line charts, a character plot for
+// scatter charts and a timeline for Gantt charts. It reports false for other
+// chart types, which are written as their text representation.
+func (t *tableRenderer) renderChartText(chart *ChartContent) (string, bool) {
+	pen := t.chartPen()
+	var result strings.Builder
+	if title := chart.GetTitle(); title != "" {
+		result.WriteString(title)
+		result.WriteString("\n\n")
+	}
+
+	switch data := chart.GetData().(type) {
+	case *PieData:
+		if chart.GetChartType() != ChartTypePie || data == nil {
+			return "", false
+		}
+		writeTextPie(&result, pen, data)
+	case *BarData:
+		if chart.GetChartType() != ChartTypeBar || data == nil {
+			return "", false
+		}
+		writeTextBars(&result, pen, data.Categories, data.Series, data.Stacked)
+	case *LineData:
+		if chart.GetChartType() != ChartTypeLine || data == nil {
+			return "", false
+		}
+		writeTextSparklines(&result, pen, data.Series)
+	case *ScatterData:
+		if chart.GetChartType() != ChartTypeScatter || data == nil {
+			return "", false
+		}
+		writeTextScatter(&result, pen, data)
+	case *GanttData:
+		if chart.GetChartType() != ChartTypeGantt || data == nil {
+			return "", false
+		}
+		// Tasks without dates cannot be placed on the timeline
+		bars := ganttBars(data)
+		if len(bars) == 0 {
+			return "", false
+		}
+		writeTextGantt(&result, pen, data, bars)
+	default:
+		return "", false
+	}
+	return result.String(), true
+}
+
+// writeChart writes a chart drawn as text, or its text representation when
+// it cannot be drawn
+func (t *tableRenderer) writeChart(result *bytes.Buffer, chart *ChartContent) error {
+	if drawing, ok := t.renderChartText(chart); ok {
+		result.WriteString(drawing)
+		return nil
+	}
+	contentBytes, err := chart.AppendText(nil)
+	if err != nil {
+		return err
+	}
+	result.Write(contentBytes)
+	return nil
+}
+
+// textBarSegment is the part of a bar drawn for one series
+type textBarSegment struct {
+	series int
+	value  float64
+}
+
+// textBarRow is a bar made of segments, with a label before it and its
+// value after it
+type textBarRow struct {
+	label    string
+	segments []textBarSegment
+	value    string
+}
+
+// writeTextPie draws a bar for each slice, followed by its value and share
+// of the total. Slices without a positive value have no bar or share, and
+// NaN and infinite slices keep only their label, as in writeTextBars.
+func writeTextPie(result *strings.Builder, pen textChartPen, data *PieData) {
+	// Shares are worked out in units of the largest slice, so adding up
+	// slices near math.MaxFloat64 cannot overflow to infinity
+	unit, total := 0.0, 0.0
+	for _, slice := range data.Slices {
+		if finiteValue(slice.Value) && slice.Value > 0 {
+			unit = max(unit, slice.Value)
+		}
+	}
+	for _, slice := range data.Slices {
+		if finiteValue(slice.Value) && slice.Value > 0 {
+			total += slice.Value / unit
+		}
+	}
+	rows := make([]textBarRow, len(data.Slices))
+	for i, slice := range data.Slices {
+		rows[i] = textBarRow{label: slice.Label}
+		if !finiteValue(slice.Value) {
+			continue
+		}
+		rows[i].value = formatDecimal(slice.Value, -1, true)
+		if slice.Value > 0 {
+			rows[i].segments = []textBarSegment{{series: i, value: slice.Value}}
+			rows[i].value += fmt.Sprintf(" (%.1f%%)", slice.Value/unit/total*100)
+		}
+	}
+	writeTextBarRows(result, pen, rows)
+}
+
+// writeTextBars draws a horizontal bar for each series in each category, or
+// a single bar of all series per category when stacked, followed by a legend
+// of the series. Missing, NaN and infinite values are left out.
+func writeTextBars(result *strings.Builder, pen textChartPen, categories []string, series []ChartSeries, stacked bool) {
+	count := len(categories)
+	for _, s := range series {
+		count = max(count, len(s.Values))
+	}
+
+	// The category labels the first row of its bars
+	var rows []textBarRow
+	for i := range count {
+		start := len(rows)
+		total := 0.0
+		for j, s := range series {
+			if i >= len(s.Values) || !finiteValue(s.Values[i]) {
+				continue
+			}
+			segment := textBarSegment{series: j, value: s.Values[i]}
+			total += segment.value
+			if stacked && len(rows) > start {
+				rows[start].segments = append(rows[start].segments, segment)
+				rows[start].value = formatDecimal(total, -1, true)
+				continue
+			}
+			rows = append(rows, textBarRow{segments: []textBarSegment{segment}, value: formatDecimal(segment.value, -1, true)})
+		}
+		if len(rows) == start {
+			rows = append(rows, textBarRow{})
+		}
+		if i < len(categories) {
+			rows[start].label = categories[i]
+		}
+	}
+	writeTextBarRows(result, pen, rows)
+	writeTextLegend(result, pen, pen.mark, seriesNames(series))
+}
+
+// writeTextBarRows draws rows of horizontal bars scaled so the widest
+// reaches textChartWidth, with their values lined up after them. Bars of
+// negative values grow to the left of the axis.
+func writeTextBarRows(result *strings.Builder, pen textChartPen, rows []textBarRow) {
+	// Bars are measured in units of the largest segment, so adding up
+	// segments near ±math.MaxFloat64 cannot overflow to infinity
+	unit := 0.0
+	for _, r := range rows {
+		for _, segment := range r.segments {
+			unit = max(unit, math.Abs(segment.value))
+		}
+	}
+	if unit == 0 {
+		unit = 1
+	}
+
+	maxPositive, maxNegative := 0.0, 0.0
+	labelWidth := 0
+	for _, r := range rows {
+		positive, negative := 0.0, 0.0
+		for _, segment := range r.segments {
+			if segment.value >= 0 {
+				positive += segment.value / unit
+			} else {
+				negative -= segment.value / unit
+			}
+		}
+		maxPositive, maxNegative = max(maxPositive, positive), max(maxNegative, negative)
+		labelWidth = max(labelWidth, text.RuneWidthWithoutEscSequences(r.label))
+	}
+	scale, negativeWidth := 0.0, 0
+	if total := maxPositive + maxNegative; total > 0 {
+		scale = textChartWidth / total
+		negativeWidth = int(math.Round(maxNegative * scale))
+	}
+
+	for _, r := range rows {
+		var positive, negative []string
+		positiveTotal, negativeTotal := 0.0, 0.0
+		positiveWidth, negativeBarWidth := 0, 0
+		for _, segment := range r.segments {
+			// Segment lengths come from rounding the running total, so
+			// stacked segments add up to the length of the whole bar
+			if segment.value >= 0 {
+				from := int(math.Round(positiveTotal * scale))
+				positiveTotal += segment.value / unit
+				n := int(math.Round(positiveTotal*scale)) - from
+				positive = append(positive, pen.bar(n, segment.series))
+				positiveWidth += n
+			} else {
+				from := int(math.Round(negativeTotal * scale))
+				negativeTotal -= segment.value / unit
+				n := int(math.Round(negativeTotal*scale)) - from
+				// Negative bars grow leftwards from the axis, so the
+				// segment drawn first is the one next to it
+				negative = append([]string{pen.bar(n, segment.series)}, negative...)
+				negativeBarWidth += n
+			}
+		}
+
+		line := text.Pad(r.label, labelWidth, ' ') + " " +
+			strings.Repeat(" ", negativeWidth-negativeBarWidth) + strings.Join(negative, "") + pen.axis +
+			strings.Join(positive, "")
+		if r.value != "" {
+			line += strings.Repeat(" ", textChartWidth-negativeWidth-positiveWidth) + "  " + r.value
+		}
+		result.WriteString(strings.TrimRight(line, " "))
+		result.WriteString("\n")
+	}
+}
+
+// writeTextSparklines draws a sparkline for each series, one character per
+// value scaled between the lowest and highest value of the series, followed
+// by those values and the last one. Missing, NaN and infinite values are
+// left blank.
+func writeTextSparklines(result *strings.Builder, pen textChartPen, series []ChartSeries) {
+	labelWidth, length := 0, 0
+	for _, s := range series {
+		labelWidth = max(labelWidth, text.RuneWidthWithoutEscSequences(s.Name))
+		length = max(length, len(s.Values))
+	}
+
+	for j, s := range series {
+		lo, hi, last := math.Inf(1), math.Inf(-1), math.NaN()
+		for _, v := range s.Values {
+			if finiteValue(v) {
+				lo, hi, last = min(lo, v), max(hi, v), v
+			}
+		}
+
+		var spark strings.Builder
+		for _, v := range s.Values {
+			if !finiteValue(v) {
+				spark.WriteString(" ")
+				continue
+			}
+			// A series of one value is drawn at half height
+			spark.WriteString(pen.levels[textChartCell(v, lo, hi, len(pen.levels))])
+		}
+
+		line := ""
+		if labelWidth > 0 {
+			line = text.Pad(s.Name, labelWidth, ' ') + "  "
+		}
+		line += pen.paint(spark.String(), j)
+		if !math.IsNaN(last) {
+			line += strings.Repeat(" ", length-len(s.Values)) + fmt.Sprintf("  min %s  max %s  last %s",
+				formatDecimal(lo, -1, true), formatDecimal(hi, -1, true), formatDecimal(last, -1, true))
+		}
+		result.WriteString(strings.TrimRight(line, " "))
+		result.WriteString("\n")
+	}
+}
+
+// writeTextScatter draws the points of a scatter chart on a character grid
+// with the range of each axis at its ends, followed by a legend of the
+// series. Where points share a cell, the last series drawn shows.
+func writeTextScatter(result *strings.Builder, pen textChartPen, data *ScatterData) {
+	xlo, xhi, ylo, yhi := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	names := make([]string, len(data.Series))
+	for i, s := range data.Series {
+		names[i] = s.Name
+		for _, p := range s.Points {
+			if finiteValue(p.X) && finiteValue(p.Y) {
+				xlo, xhi = min(xlo, p.X), max(xhi, p.X)
+				ylo, yhi = min(ylo, p.Y), max(yhi, p.Y)
+			}
+		}
+	}
+	if xlo > xhi {
+		xlo, xhi, ylo, yhi = 0, 0, 0, 0
+	}
+
+	// Cells hold the point drawn in them
+	grid := make([][]string, textChartHeight)
+	for i := range grid {
+		grid[i] = make([]string, textChartWidth)
+		for j := range grid[i] {
+			grid[i][j] = " "
+		}
+	}
+	for j, s := range data.Series {
+		for _, p := range s.Points {
+			if finiteValue(p.X) && finiteValue(p.Y) {
+				grid[textChartHeight-1-textChartCell(p.Y, ylo, yhi, textChartHeight)][textChartCell(p.X, xlo, xhi, textChartWidth)] = pen.paint(pen.point(j), j)
+			}
+		}
+	}
+
+	top, bottom := formatDecimal(yhi, -1, true), formatDecimal(ylo, -1, true)
+	labelWidth := max(len(top), len(bottom))
+	for i, cells := range grid {
+		label := ""
+		switch i {
+		case 0:
+			label = top
+		case textChartHeight - 1:
+			label = bottom
+		}
+		result.WriteString(strings.TrimRight(fmt.Sprintf("%*s %s", labelWidth, label, pen.axis)+strings.Join(cells, ""), " "))
+		result.WriteString("\n")
+	}
+	result.WriteString(strings.Repeat(" ", labelWidth) + " " + pen.corner + strings.Repeat(pen.rule, textChartWidth) + "\n")
+	left, right := formatDecimal(xlo, -1, true), formatDecimal(xhi, -1, true)
+	gap := max(1, textChartWidth-len(left)-len(right))
+	result.WriteString(strings.Repeat(" ", labelWidth+2) + left + strings.Repeat(" ", gap) + right + "\n")
+
+	writeTextLegend(result, pen, pen.point, names)
+}
+
+// writeTextGantt draws a timeline with a date axis along the top and a bar
+// per task, grouped under section headings. Done and critical tasks have
+// their own bar characters; in colour, critical tasks use the scheme's error
+// colour, done tasks its success colour, active tasks its warning colour
+// and the rest its info colour.
+func writeTextGantt(result *strings.Builder, pen textChartPen, data *GanttData, bars []ganttBar) {
+	sectionOrder, sections, showSections := groupGanttBars(bars)
+	first, last, span := ganttSpan(bars)
+	indent := ""
+	if showSections {
+		indent = "  "
+	}
+	labelWidth := 0
+	for _, bar := range bars {
+		labelWidth = max(labelWidth,
+			text.RuneWidthWithoutEscSequences(indent+bar.task.Title),
+			text.RuneWidthWithoutEscSequences(bar.task.Section))
+	}
+	// column maps a time onto the timeline
+	column := func(at time.Time) int {
+		return min(textChartWidth, max(0, int(math.Round(float64(at.Sub(first))/float64(span)*textChartWidth))))
+	}
+
+	// Ticks are spaced so their labels fit, and a label that would run into
+	// the one before it is left out
+	axisFormat := data.AxisFormat
+	if axisFormat == "" {
+		axisFormat = "%Y-%m-%d"
+	}
+	tickWidth := text.RuneWidthWithoutEscSequences(formatStrftime(first, axisFormat))
+	step := ganttTickStep(span, textChartWidth/(tickWidth+1))
+	labels := []rune(strings.Repeat(" ", textChartWidth+tickWidth+1))
+	rule := make([]string, textChartWidth+1)
+	for i := range rule {
+		rule[i] = pen.rule
+	}
+	labelEnd := -1
+	for tick := first; !tick.After(last); tick = tick.Add(step) {
+		at := column(tick)
+		rule[at] = pen.tick
+		label := []rune(formatStrftime(tick, axisFormat))
+		if at <= labelEnd || at+len(label) > len(labels) {
+			continue
+		}
+		copy(labels[at:], label)
+		labelEnd = at + len(label)
+	}
+	margin := strings.Repeat(" ", labelWidth+1)
+	result.WriteString(strings.TrimRight(margin+string(labels), " ") + "\n")
+	result.WriteString(margin + strings.Join(rule, "") + "\n")
+
+	for _, section := range sectionOrder {
+		if showSections {
+			result.WriteString(section + "\n")
+		}
+		for _, bar := range sections[section] {
+			// Every task shows, however short
+			from := min(column(bar.start), textChartWidth-1)
+			to := max(column(bar.end), from+1)
+
+			mark, color := pen.mark(0), pen.scheme.Info
+			switch {
+			case strings.Contains(bar.task.Status, "crit"):
+				mark, color = pen.crit, pen.scheme.Error
+			case strings.Contains(bar.task.Status, "done"):
+				mark, color = pen.done, pen.scheme.Success
+			case strings.Contains(bar.task.Status, "active"):
+				color = pen.scheme.Warning
+			}
+			drawn := strings.Repeat(mark, to-from)
+			if len(pen.colors) > 0 {
+				drawn = applySchemeColor(drawn, color, false)
+			}
+			result.WriteString(text.Pad(indent+bar.task.Title, labelWidth, ' ') + " " + strings.Repeat(" ", from) + drawn + "\n")
+		}
+	}
+}
+
+// writeTextLegend writes the character and name of each series on one line,
+// unless there is a single unnamed series
+func writeTextLegend(result *strings.Builder, pen textChartPen, mark func(int) string, names []string) {
+	if len(names) == 0 || (len(names) == 1 && names[0] == "") {
+		return
+	}
+	entries := make([]string, len(names))
+	for i, name := range names {
+		entries[i] = pen.paint(mark(i), i) + " " + name
+	}
+	result.WriteString("\n")
+	result.WriteString(strings.TrimRight(strings.Join(entries, "  "), " "))
+	result.WriteString("\n")
+}
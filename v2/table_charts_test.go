@@ -0,0 +1,279 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTableRenderer_Charts(t *testing.T) {
+	tests := map[string]struct {
+		chart *ChartContent
+		want  string
+	}{
+		"grouped bars": {
+			chart: NewBarChart("Sales", []string{"Q1", "Q2"}, []ChartSeries{
+				{Name: "EU", Values: []float64{10, 5}},
+				{Name: "US", Values: []float64{20, math.NaN()}},
+			}),
+			want: "Sales\n\n" +
+				"Q1 |####################                      10\n" +
+				"   |========================================  20\n" +
+				"Q2 |##########                                5\n" +
+				"\n" +
+				"# EU  = US\n",
+		},
+		"stacked bars with negative values": {
+			chart: NewStackedBarChart("", []string{"A", "B"}, []ChartSeries{
+				{Name: "in", Values: []float64{30, 10}},
+				{Name: "out", Values: []float64{-10, 0}},
+				{Name: "extra", Values: []float64{10}},
+			}),
+			want: "A ========|########################********  30\n" +
+				"B         |########                          10\n" +
+				"\n" +
+				"# in  = out  * extra\n",
+		},
+		"pie": {
+			chart: NewPieChart("Share", []PieSlice{{Label: "EC2", Value: 12.5}, {Label: "S3", Value: 7.5}, {Label: "Free", Value: 0}}, false),
+			want: "Share\n\n" +
+				"EC2  |########################################  12.5 (62.5%)\n" +
+				"S3   |========================                  7.5 (37.5%)\n" +
+				"Free |                                          0\n",
+		},
+		"sparklines": {
+			chart: NewLineChart("Load", []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, []ChartSeries{
+				{Name: "cpu", Values: []float64{1, 3, math.NaN(), 8, 5}},
+				{Name: "memory", Values: []float64{4, 4, 4}},
+			}),
+			want: "Load\n\n" +
+				"cpu     _- #=  min 1  max 8  last 5\n" +
+				"memory  ===    min 4  max 4  last 4\n",
+		},
+		"single unnamed line": {
+			chart: NewLineChart("", []string{"Mon", "Tuesday", "Wed"}, []ChartSeries{
+				{Values: []float64{1, 4}},
+			}),
+			want: "_#  min 1  max 4  last 4\n",
+		},
+		"gantt": {
+			chart: NewGanttChart("Plan", []GanttTask{
+				{ID: "a", Title: "Design", StartDate: "2024-01-01", Duration: "3d", Section: "Dev", Status: "done"},
+				{ID: "b", Title: "Build", Duration: "5d", Dependencies: []string{"a"}, Section: "Dev", Status: "active"},
+				{ID: "c", Title: "Release", Duration: "1d", Dependencies: []string{"b"}, Section: "Ops", Status: "crit"},
+			}),
+			want: "Plan\n\n" +
+				"          2024-01-01                     2024-01-08\n" +
+				"          +------------------------------+---------\n" +
+				"Dev\n" +
+				"  Design  =============\n" +
+				"  Build                #######################\n" +
+				"Ops\n" +
+				"  Release                                     !!!!\n",
+		},
+		"scatter": {
+			chart: NewScatterChart("", []ScatterSeries{
+				{Name: "a", Points: []XYPoint{{X: 0, Y: 0}, {X: 10, Y: 100}}},
+				{Name: "b", Points: []XYPoint{{X: 5, Y: 50}}},
+			}),
+			want: "100 |                                       #\n" +
+				"    |\n" +
+				"    |\n" +
+				"    |\n" +
+				"    |\n" +
+				"    |                    =\n" +
+				"    |\n" +
+				"    |\n" +
+				"    |\n" +
+				"    |\n" +
+				"    |\n" +
+				"  0 |#\n" +
+				"    +----------------------------------------\n" +
+				"     0                                     10\n" +
+				"\n" +
+				"# a  = b\n",
+		},
+		"gantt without dates as text": {
+			chart: NewGanttChart("Plan", []GanttTask{{ID: "a", Title: "Design", Duration: "3d"}}),
+			want:  "Plan\nChart Type: gantt\nDesign (, 3d)\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			renderer := &tableRenderer{chartStyle: ChartStyleASCII}
+			got, err := renderer.Render(context.Background(), New().AddContent(tc.chart).Build())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("Render() =\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTableWithCharts_Unicode(t *testing.T) {
+	scheme := ColorScheme{Success: "green", Warning: "yellow", Error: "red", Info: "magenta"}
+	doc := New().
+		AddContent(NewBarChart("", []string{"Q1"}, []ChartSeries{{Name: "EU", Values: []float64{2}}, {Name: "US", Values: []float64{1}}})).
+		AddContent(NewLineChart("", nil, []ChartSeries{{Values: []float64{1, 2, 3}}})).
+		Build()
+
+	got, err := TableWithCharts("Default", ChartStyleUnicode, scheme).Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{
+		"Q1 │\x1b[35m" + strings.Repeat("█", 40) + "\x1b[0m  2\n",
+		"   │\x1b[32m" + strings.Repeat("▓", 20) + "\x1b[0m",
+		"\x1b[35m█\x1b[0m EU  \x1b[32m▓\x1b[0m US\n",
+		"\x1b[35m▁▅█\x1b[0m  min 1  max 3  last 3\n",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("Render() =\n%q\nwant it to contain %q", got, want)
+		}
+	}
+}
+
+func TestTableRenderer_ChartStyleAuto(t *testing.T) {
+	doc := New().AddContent(NewBarChart("", []string{"Q1"}, []ChartSeries{{Values: []float64{1}}})).Build()
+	want := "Q1 |########################################  1\n"
+
+	got, err := Table().Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("Render() = %q, want ASCII %q", got, want)
+	}
+
+	// A file is not a terminal, even when the test runs in one
+	file, err := os.Create(filepath.Join(t.TempDir(), "chart.txt"))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer file.Close()
+	if err := Table().Renderer.RenderTo(context.Background(), doc, file); err != nil {
+		t.Fatalf("RenderTo() error = %v", err)
+	}
+	written, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(written) != want {
+		t.Errorf("RenderTo(file) = %q, want ASCII %q", written, want)
+	}
+}
+
+func TestTableRenderer_ChartInSection(t *testing.T) {
+	section := NewSectionContent("Dashboard")
+	section.AddContent(NewBarChart("", []string{"Q1"}, []ChartSeries{{Values: []float64{3}}}))
+
+	got, err := (&tableRenderer{chartStyle: ChartStyleASCII}).Render(context.Background(), New().AddContent(section).Build())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "Q1 |########################################  3\n"
+	if !strings.Contains(string(got), want) {
+		t.Errorf("Render() =\n%s\nwant it to contain:\n%s", got, want)
+	}
+}
+
+// terminalTestWriter collects output and reports itself as a terminal
+type terminalTestWriter struct {
+	bytes.Buffer
+}
+
+func (w *terminalTestWriter) Write(_ context.Context, _ string, data []byte) error {
+	_, err := w.Buffer.Write(data)
+	return err
+}
+
+func (w *terminalTestWriter) writesToTerminal() bool { return true }
+
+func TestOutput_ChartStyleAuto(t *testing.T) {
+	doc := New().AddContent(NewBarChart("", []string{"Q1"}, []ChartSeries{{Values: []float64{1}}})).Build()
+
+	terminal := &terminalTestWriter{}
+	var plain bytes.Buffer
+	stdout := NewStdoutWriter()
+	stdout.SetWriter(&plain)
+	if stdout.writesToTerminal() {
+		t.Fatal("StdoutWriter writing to a buffer reports a terminal")
+	}
+
+	out := NewOutput(WithFormats(Table(), TableWithCharts("Default", ChartStyleASCII, DefaultColorScheme())), WithWriters(terminal, stdout))
+	if err := out.Render(context.Background(), doc); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	// The terminal gets Unicode from the automatic style and ASCII from the
+	// explicit one; other writers get ASCII from both
+	ascii := "Q1 |########################################  1\n"
+	unicode, explicit, _ := strings.Cut(terminal.String(), "\n")
+	if !strings.HasPrefix(unicode, "Q1 │") || !strings.Contains(unicode, strings.Repeat("█", 40)) || explicit != ascii {
+		t.Errorf("terminal writer got %q, want Unicode then ASCII", terminal.String())
+	}
+	if got, want := plain.String(), ascii+ascii; got != want {
+		t.Errorf("stdout writer got %q, want %q", got, want)
+	}
+}
+
+func TestTableRenderer_ChartsExtremeValues(t *testing.T) {
+	tests := map[string]struct {
+		chart    *ChartContent
+		want     []string
+		unwanted []string
+	}{
+		"scatter across the float range": {
+			chart: NewScatterChart("", []ScatterSeries{
+				{Points: []XYPoint{{X: -math.MaxFloat64, Y: -math.MaxFloat64}, {X: math.MaxFloat64, Y: math.MaxFloat64}}},
+			}),
+			want: []string{" |                                       #\n", " |#\n"},
+		},
+		"stacked bars beyond the float range": {
+			chart: NewStackedBarChart("", []string{"A"}, []ChartSeries{
+				{Values: []float64{math.MaxFloat64}},
+				{Values: []float64{math.MaxFloat64}},
+			}),
+			want: []string{"A |####################===================="},
+		},
+		"sparkline across the float range": {
+			chart: NewLineChart("", nil, []ChartSeries{{Values: []float64{-math.MaxFloat64, 0, math.MaxFloat64}}}),
+			want:  []string{"_=#  min "},
+		},
+		"pie shares beyond the float range": {
+			chart: NewPieChart("", []PieSlice{{Label: "A", Value: math.MaxFloat64}, {Label: "B", Value: math.MaxFloat64}}, false),
+			want:  []string{" (50.0%)\n"},
+		},
+		"pie with non-finite slices": {
+			chart:    NewPieChart("", []PieSlice{{Label: "A", Value: 3}, {Label: "B", Value: math.Inf(1)}, {Label: "C", Value: math.NaN()}}, false),
+			want:     []string{"A |########################################  3 (100.0%)\n", "B |\n", "C |\n"},
+			unwanted: []string{"NaN", "Inf"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := (&tableRenderer{chartStyle: ChartStyleASCII}).Render(context.Background(), New().AddContent(tc.chart).Build())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(string(got), want) {
+					t.Errorf("Render() =\n%s\nwant it to contain %q", got, want)
+				}
+			}
+			for _, unwanted := range tc.unwanted {
+				if strings.Contains(string(got), unwanted) {
+					t.Errorf("Render() =\n%s\nwant it not to contain %q", got, unwanted)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// tableExpandMode selects when the table renderer prints records as
+// vertical key/value blocks instead of a horizontal table
+type tableExpandMode int
+
+const (
+	expandNever  tableExpandMode = iota
+	expandAlways                 // Every table is expanded
+	expandAuto                   // Tables wider than the output width are expanded
+)
+
+// renderTableString renders a table as a horizontal grid or, depending on
+// the expand mode, as expanded records numbered from first. The result has
+// no trailing newline.
+func (t *tableRenderer) renderTableString(tableContent *TableContent, first int) string {
+	switch t.expand {
+	case expandAlways:
+		return t.renderExpandedTable(tableContent, first)
+	case expandAuto:
+		rendered := t.renderTable(tableContent).Render()
+		if width := t.outputWidth(); width > 0 && cellWidth(rendered) > width {
+			return t.renderExpandedTable(tableContent, first)
+		}
+		return rendered
+	default:
+		return t.renderTable(tableContent).Render()
+	}
+}
+
+// renderExpandedTable renders each record as a block of "Key | Value" lines
+// in schema order, headed by a "-[ RECORD n ]" separator, followed by a
+// "-[ FOOTER ]" block for tables with a footer. Hidden fields are skipped,
+// multi-line values continue on lines with an empty key, and
+// CollapsibleValues show both their summary and details.
+func (t *tableRenderer) renderExpandedTable(tableContent *TableContent, first int) string {
+	var result strings.Builder
+	if title := tableContent.Title(); title != "" {
+		result.WriteString(title)
+		result.WriteString("\n")
+	}
+
+	schema := tableContent.getSchema()
+	var keys []string
+	var fields []*Field
+	for _, key := range schema.GetKeyOrder() {
+		field := schema.FindField(key)
+		if field != nil && field.Hidden {
+			continue
+		}
+		keys = append(keys, key)
+		fields = append(fields, field)
+	}
+
+	records := tableContent.Records()
+	footer := tableContent.Footer()
+	if len(keys) == 0 || len(records) == 0 {
+		result.WriteString("(0 records)")
+		return result.String()
+	}
+
+	// There is room for details in the vertical layout, so show them
+	detailed := *t
+	detailed.collapsibleConfig.ForceExpansion = true
+
+	// Grouped columns are labelled "Group / Column"
+	names := schema.flatColumnHeaders(keys)
+	keyWidth, valueWidth := 0, 0
+	for _, name := range names {
+		keyWidth = max(keyWidth, text.RuneWidthWithoutEscSequences(name))
+	}
+	recordCount := len(records)
+	labels := make([]string, len(records))
+	for r := range records {
+		labels[r] = fmt.Sprintf("-[ RECORD %d ]", first+r)
+	}
+	if footer != nil {
+		records = append(records, footer)
+		labels = append(labels, "-[ FOOTER ]")
+	}
+	values := make([][]string, len(records))
+	for r, record := range records {
+		values[r] = make([]string, len(keys))
+		for i, key := range keys {
+			if val, exists := record[key]; exists {
+				values[r][i] = detailed.formatCellValue(val, fields[i])
+				if r < recordCount {
+					values[r][i] = styleCellANSI(values[r][i], cellStyle(fields[i], record, key))
+				}
+				valueWidth = max(valueWidth, cellWidth(values[r][i]))
+			}
+		}
+	}
+
+	for r, row := range values {
+		if r > 0 {
+			result.WriteString("\n")
+		}
+		result.WriteString(expandedRecordSeparator(labels[r], keyWidth, valueWidth))
+		for i := range keys {
+			for j, line := range strings.Split(row[i], "\n") {
+				name := names[i]
+				if j > 0 {
+					name = ""
+				}
+				result.WriteString("\n")
+				result.WriteString(strings.TrimRight(text.Pad(name, keyWidth, ' ')+" | "+line, " "))
+			}
+		}
+	}
+	return result.String()
+}
+
+// expandedRecordSeparator returns the line heading a record block, spanning
+// the key and value columns with a '+' where the column separator falls, as
+// in psql's expanded display
+func expandedRecordSeparator(label string, keyWidth, valueWidth int) string {
+	line := []byte(label + strings.Repeat("-", max(keyWidth+3+valueWidth-len(label), 0)))
+	if len(label) <= keyWidth+1 {
+		line[keyWidth+1] = '+'
+	}
+	return string(line)
+}
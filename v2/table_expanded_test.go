@@ -0,0 +1,122 @@
+package output
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTableExpanded(t *testing.T) {
+	doc := New().Table("Instances", []Record{
+		{"ID": "i-1", "Name": "web", "Secret": "x", "Notes": "line one\nline two"},
+		{"ID": "i-22", "Name": "db", "Secret": "y", "Notes": NewCollapsibleValue("2 tags", []string{"env=prod", "team=data"})},
+	}, WithSchema(
+		Field{Name: "Name"},
+		Field{Name: "ID"},
+		Field{Name: "Secret", Hidden: true},
+		Field{Name: "Notes"},
+	)).Build()
+
+	want := "Instances\n" +
+		"-[ RECORD 1 ]------\n" +
+		"Name  | web\n" +
+		"ID    | i-1\n" +
+		"Notes | line one\n" +
+		"      | line two\n" +
+		"-[ RECORD 2 ]------\n" +
+		"Name  | db\n" +
+		"ID    | i-22\n" +
+		"Notes | 2 tags\n" +
+		"      |   env=prod\n" +
+		"      |   team=data\n"
+
+	out, err := TableExpanded("Default").Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if string(out) != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestTableExpanded_SeparatorMarksColumn(t *testing.T) {
+	doc := New().Table("", []Record{{"InstanceIdentifier": "i-1"}}, WithKeys("InstanceIdentifier")).Build()
+	out, err := TableExpanded("Default").Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "-[ RECORD 1 ]------+----\nInstanceIdentifier | i-1\n"
+	if string(out) != want {
+		t.Errorf("Render() = %q, want %q", out, want)
+	}
+}
+
+func TestTableExpanded_Empty(t *testing.T) {
+	doc := New().Table("Empty", []Record{}, WithKeys("A")).Build()
+	out, err := TableExpanded("Default").Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := "Empty\n(0 records)\n"; string(out) != want {
+		t.Errorf("Render() = %q, want %q", out, want)
+	}
+}
+
+func TestTableExpanded_StreamingNumbersContinue(t *testing.T) {
+	records := make([]Record, streamingTablePageSize+1)
+	for i := range records {
+		records[i] = Record{"N": i}
+	}
+	table, err := NewStreamingTableContent("", countingSeq(records, new(int)), WithKeys("N"))
+	if err != nil {
+		t.Fatalf("NewStreamingTableContent() error = %v", err)
+	}
+
+	var buf strings.Builder
+	if err := TableExpanded("Default").Renderer.RenderTo(context.Background(), New().AddContent(table).Build(), &buf); err != nil {
+		t.Fatalf("RenderTo() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "-[ RECORD 1001 ]") {
+		t.Errorf("output missing record 1001")
+	}
+	if strings.Count(buf.String(), "-[ RECORD 1 ]") != 1 {
+		t.Errorf("record numbering restarted on a later page")
+	}
+}
+
+func TestTableWithAutoExpand(t *testing.T) {
+	doc := New().Table("", []Record{
+		{"ID": "i-1", "Name": "web", "Notes": "serves the public website"},
+	}, WithKeys("ID", "Name", "Notes")).Build()
+
+	tests := map[string]struct {
+		width        int
+		wantExpanded bool
+	}{
+		"fits horizontally": {width: 200},
+		"too wide":          {width: 30, wantExpanded: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := TableWithAutoExpandWidth("Default", tc.width).Renderer.Render(context.Background(), doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := strings.Contains(string(out), "-[ RECORD 1 ]"); got != tc.wantExpanded {
+				t.Errorf("expanded = %v, want %v\n%s", got, tc.wantExpanded, out)
+			}
+		})
+	}
+
+	t.Run("detects COLUMNS", func(t *testing.T) {
+		t.Setenv("COLUMNS", "30")
+		out, err := TableWithAutoExpand("Default").Renderer.Render(context.Background(), doc)
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if !strings.Contains(string(out), "-[ RECORD 1 ]") {
+			t.Errorf("table wider than $COLUMNS not expanded\n%s", out)
+		}
+	})
+}
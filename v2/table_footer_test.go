@@ -0,0 +1,214 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func footerTestTable(t *testing.T, opts ...TableOption) *TableContent {
+	t.Helper()
+	opts = append([]TableOption{
+		WithKeys("Service", "Cost"),
+		WithFooter(map[string]AggregateFunc{
+			"Service": LabelAggregate("Total"),
+			"Cost":    SumAggregate("Cost"),
+			"Missing": CountAggregate(),
+		}),
+	}, opts...)
+	table, err := NewTableContent("Costs", []Record{
+		{"Service": "EC2", "Cost": 12.5},
+		{"Service": "S3", "Cost": 2},
+	}, opts...)
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	return table
+}
+
+func TestTableContent_Footer(t *testing.T) {
+	footer := footerTestTable(t).Footer()
+	if footer["Service"] != "Total" || footer["Cost"] != 14.5 {
+		t.Errorf("Footer() = %v", footer)
+	}
+
+	plain, err := NewTableContent("", []Record{{"A": 1}})
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	if plain.Footer() != nil {
+		t.Errorf("Footer() without WithFooter = %v, want nil", plain.Footer())
+	}
+}
+
+func TestTableContent_FooterAfterTransformations(t *testing.T) {
+	table := footerTestTable(t, WithTransformations(
+		NewFilterOp(func(r Record) bool { return r["Service"] == "S3" }),
+	))
+	transformed, err := applyContentTransformations(context.Background(), table)
+	if err != nil {
+		t.Fatalf("applyContentTransformations() error = %v", err)
+	}
+	if got := transformed.(*TableContent).Footer()["Cost"]; got != float64(2) {
+		t.Errorf("footer Cost = %v, want 2", got)
+	}
+}
+
+func footerJoinTable(t *testing.T) *TableContent {
+	t.Helper()
+	table, err := NewTableContent("Owners", []Record{{"Service": "EC2", "Owner": "ops"}}, WithKeys("Service", "Owner"))
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	return table
+}
+
+func TestTableContent_FooterAfterColumnOperations(t *testing.T) {
+	tests := map[string]struct {
+		op   Operation
+		want Record // nil when the footer is dropped
+	}{
+		"rename":   {op: NewRenameColumnsOp(map[string]string{"Cost": "USD"}), want: Record{"Service": "Total", "USD": 14.5, "Missing": 2}},
+		"swap":     {op: NewRenameColumnsOp(map[string]string{"Cost": "Service", "Service": "Cost"}), want: Record{"Cost": "Total", "Service": 14.5, "Missing": 2}},
+		"select":   {op: NewSelectColumnsOp("Cost"), want: Record{"Cost": 14.5}},
+		"drop":     {op: NewDropColumnsOp("Service"), want: Record{"Cost": 14.5, "Missing": 2}},
+		"reorder":  {op: NewReorderColumnsOp("Cost"), want: Record{"Service": "Total", "Cost": 14.5, "Missing": 2}},
+		"group by": {op: NewGroupByOp([]string{"Service"}, map[string]AggregateFunc{"Count": CountAggregate()})},
+		"pivot":    {op: NewPivotOp(nil, "Service", "Cost", SumAggregate("Cost"))},
+		"unpivot":  {op: NewUnpivotOp([]string{"Cost"}, "Metric", "Value")},
+		"join":     {op: NewJoinOp(footerJoinTable(t), InnerJoin, []string{"Service"})},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			transformed, err := tc.op.Apply(context.Background(), footerTestTable(t))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			footer := transformed.(*TableContent).Footer()
+			if tc.want == nil {
+				if footer != nil {
+					t.Errorf("Footer() = %v, want nil", footer)
+				}
+				return
+			}
+			if len(footer) != len(tc.want) {
+				t.Fatalf("Footer() = %v, want %v", footer, tc.want)
+			}
+			for key, want := range tc.want {
+				if got := footer[key]; got != want {
+					t.Errorf("Footer()[%q] = %#v, want %#v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWithFooter_Render(t *testing.T) {
+	tests := map[string]struct {
+		format  Format
+		want    []string
+		notWant string
+	}{
+		"table": {
+			format: TableWithStyle("Default"),
+			want:   []string{"| TOTAL   | 14.5 |"},
+		},
+		"html": {
+			format: HTML(),
+			want:   []string{"</tbody>\n    <tfoot>\n      <tr>\n        <td>Total</td>\n        <td>14.5</td>\n      </tr>\n    </tfoot>"},
+		},
+		"markdown": {
+			format: Markdown(),
+			want:   []string{"| S3 | 2 |\n| **Total** | **14.5** |\n"},
+		},
+		"csv without footer": {
+			format:  CSV(),
+			want:    []string{"Service,Cost\nEC2,12.5\nS3,2\n"},
+			notWant: "Total",
+		},
+		"csv with footer": {
+			format: CSVWithOptions(WithCSVFooter()),
+			want:   []string{"Service,Cost\nEC2,12.5\nS3,2\nTotal,14.5\n"},
+		},
+		"yaml": {
+			format: YAML(),
+			want:   []string{"footer:\n    Service: Total\n    Cost: 14.5\n"},
+		},
+		"expanded": {
+			format: TableExpanded("Default"),
+			want:   []string{"-[ FOOTER ]", "Service | Total"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.format.Renderer.Render(context.Background(), New().AddContent(footerTestTable(t)).Build())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("output missing %q\n%s", want, out)
+				}
+			}
+			if tc.notWant != "" && strings.Contains(string(out), tc.notWant) {
+				t.Errorf("output contains %q\n%s", tc.notWant, out)
+			}
+		})
+	}
+}
+
+func TestWithFooter_JSON(t *testing.T) {
+	out, err := JSON().Renderer.Render(context.Background(), New().AddContent(footerTestTable(t)).Build())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	var parsed map[string]any
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if data := parsed[keyData].([]any); len(data) != 2 {
+		t.Errorf("data has %d records, want 2", len(data))
+	}
+	footer, ok := parsed[keyFooter].(map[string]any)
+	if !ok || footer["Service"] != "Total" || footer["Cost"] != 14.5 {
+		t.Errorf("footer = %v", parsed[keyFooter])
+	}
+	if !strings.Contains(string(out), `"Service": "Total",`) {
+		t.Errorf("footer keys not in schema order\n%s", out)
+	}
+}
+
+func TestWithFooter_ParseDocument(t *testing.T) {
+	for name, format := range map[string]Format{"json": JSON(), "yaml": YAML()} {
+		t.Run(name, func(t *testing.T) {
+			out, err := format.Renderer.Render(context.Background(), New().AddContent(footerTestTable(t)).Build())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			parse := ParseJSONDocument
+			if name == "yaml" {
+				parse = ParseYAMLDocument
+			}
+			doc, err := parse(bytes.NewReader(out))
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			footer := doc.GetContents()[0].(*TableContent).Footer()
+			if footer["Service"] != "Total" || footer["Cost"] != 14.5 {
+				t.Errorf("parsed footer = %v", footer)
+			}
+		})
+	}
+}
+
+func TestWithFooter_StreamingTableRejected(t *testing.T) {
+	_, err := NewStreamingTableContent("", countingSeq(nil, new(int)),
+		WithKeys("A"), WithFooter(map[string]AggregateFunc{"A": CountAggregate()}))
+	if err == nil {
+		t.Error("NewStreamingTableContent() with WithFooter error = nil, want error")
+	}
+}
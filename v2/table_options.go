@@ -11,6 +11,10 @@ type tableConfig struct {
 	keys            []string
 	autoSchema      bool
 	transformations []Operation
+	formatters      map[string]func(any) any
+	footer          map[string]AggregateFunc
+	styleRules      map[string][]StyleRule
+	columnGroups    []ColumnGroup
 }
 
 // TableOption configures table creation
@@ -107,6 +111,54 @@ func WithTransformations(ops ...Operation) TableOption {
 	}
 }
 
+// WithFieldFormatters registers named formatters for struct data. A struct
+// tag option `format=NAME` resolves NAME against these formatters first and
+// then against the built-in set; NewTableContent returns an error when a
+// name cannot be resolved. The map is copied, and nil formatters are skipped.
+func WithFieldFormatters(formatters map[string]func(any) any) TableOption {
+	return func(tc *tableConfig) {
+		copied := make(map[string]func(any) any, len(formatters))
+		for name, fn := range formatters {
+			if fn == nil {
+				continue
+			}
+			copied[name] = fn
+		}
+		tc.formatters = copied
+	}
+}
+
+// WithFooter adds a footer row whose cells are computed by aggregates, keyed
+// by column name, over the table records after transformations. The built-in
+// aggregates (SumAggregate, AverageAggregate, CountAggregate, MinAggregate,
+// MaxAggregate and LabelAggregate) can be used as with NewGroupByOp. Each
+// format renders the footer separately from the data, so it does not take
+// part in sorting or filtering. Columns without an aggregate are left empty.
+// The map is copied, and nil aggregates are skipped. Streaming tables do not
+// support footers, since aggregates need every record.
+//
+// Example usage:
+//
+//	builder.Table("costs", data,
+//	    output.WithKeys("Service", "Cost"),
+//	    output.WithFooter(map[string]output.AggregateFunc{
+//	        "Service": output.LabelAggregate("Total"),
+//	        "Cost":    output.SumAggregate("Cost"),
+//	    }),
+//	)
+func WithFooter(aggregates map[string]AggregateFunc) TableOption {
+	return func(tc *tableConfig) {
+		copied := make(map[string]AggregateFunc, len(aggregates))
+		for column, agg := range aggregates {
+			if agg == nil {
+				continue
+			}
+			copied[column] = agg
+		}
+		tc.footer = copied
+	}
+}
+
 // DetectSchemaFromData creates a schema from the provided data. Slice input
 // is scanned in full: the detected columns are the union of keys across all
 // rows, so columns that first appear in a later row are included rather than
@@ -115,7 +167,19 @@ func WithTransformations(ops ...Operation) TableOption {
 // and map input has no recoverable key order, so the detected column order is
 // alphabetical (see DetectSchemaFromMap). Use WithKeys or WithSchema when
 // column order matters.
+//
+// Slices of structs ([]T or []*T) are the exception: their schema comes from
+// the struct fields and `output` tags in declaration order (see
+// NewTableContent). Formatters named in tags are not resolved here, and an
+// invalid tag yields an empty schema; NewTableContent reports such errors.
 func DetectSchemaFromData(data any) *Schema {
+	if elemType := structElemType(data); elemType != nil {
+		if plan, err := planForStruct(elemType); err == nil {
+			return NewSchemaFromFields(plan.fieldsWithoutFormatters())
+		}
+		return &Schema{Fields: []Field{}, keyOrder: []string{}}
+	}
+
 	switch v := data.(type) {
 	case []Record:
 		return detectSchemaFromMaps(v)
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
 )
 
 // tableRenderer implements console table output format
@@ -16,6 +18,11 @@ type tableRenderer struct {
 	styleName         string
 	collapsibleConfig RendererConfig
 	maxColumnWidth    int // Maximum width for table columns (0 = no limit)
+	autoFit           bool
+	expand            tableExpandMode
+	width             int // Auto-fit and auto-expand width (0 = detect the terminal width)
+	chartStyle        ChartStyle
+	chartScheme       *ColorScheme // Chart colours (nil = DefaultColorScheme)
 }
 
 func (t *tableRenderer) Format() string {
@@ -30,12 +37,7 @@ func (t *tableRenderer) RenderTo(ctx context.Context, doc *Document, w io.Writer
 	if w == nil {
 		return fmt.Errorf("writer cannot be nil")
 	}
-	data, err := t.renderDocumentTable(ctx, doc)
-	if err != nil {
-		return err
-	}
-	_, err = w.Write(data)
-	return err
+	return t.forWriter(w).renderDocumentTableTo(ctx, doc, w)
 }
 
 func (t *tableRenderer) SupportsStreaming() bool {
@@ -53,6 +55,21 @@ func (t *tableRenderer) renderDocumentTable(ctx context.Context, doc *Document)
 		return nil, fmt.Errorf("document cannot be nil")
 	}
 
+	var result bytes.Buffer
+	if err := t.renderDocumentTableTo(ctx, doc, &result); err != nil {
+		return nil, err
+	}
+	return result.Bytes(), nil
+}
+
+// renderDocumentTableTo renders the document to w one content item at a time.
+// Each item is rendered into a buffer and written before the next one starts;
+// StreamingTableContent is written page by page (see writeStreamingTable).
+func (t *tableRenderer) renderDocumentTableTo(ctx context.Context, doc *Document, w io.Writer) error {
+	if doc == nil {
+		return fmt.Errorf("document cannot be nil")
+	}
+
 	var result bytes.Buffer
 	contents := doc.GetContents()
 
@@ -60,14 +77,14 @@ func (t *tableRenderer) renderDocumentTable(ctx context.Context, doc *Document)
 		// Check for context cancellation
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return ctx.Err()
 		default:
 		}
 
 		// Apply per-content transformations before rendering
 		transformed, err := applyContentTransformations(ctx, content)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		switch c := transformed.(type) {
@@ -76,10 +93,22 @@ func (t *tableRenderer) renderDocumentTable(ctx context.Context, doc *Document)
 				result.WriteString("\n")
 			}
 
-			tableWriter := t.renderTable(c)
-			result.WriteString(tableWriter.Render())
+			result.WriteString(t.renderTableString(c, 1))
 			result.WriteString("\n")
 
+		case *StreamingTableContent:
+			if i > 0 {
+				result.WriteString("\n")
+			}
+			if _, err := w.Write(result.Bytes()); err != nil {
+				return err
+			}
+			result.Reset()
+
+			if err := t.writeStreamingTable(ctx, c, w); err != nil {
+				return fmt.Errorf("failed to render table %s: %w", c.ID(), err)
+			}
+
 		case *DefaultCollapsibleSection:
 			if i > 0 {
 				result.WriteString("\n")
@@ -87,7 +116,7 @@ func (t *tableRenderer) renderDocumentTable(ctx context.Context, doc *Document)
 
 			sectionOutput, err := t.renderCollapsibleSection(c)
 			if err != nil {
-				return nil, fmt.Errorf("failed to render collapsible section: %w", err)
+				return fmt.Errorf("failed to render collapsible section: %w", err)
 			}
 			result.Write(sectionOutput)
 
@@ -104,7 +133,7 @@ func (t *tableRenderer) renderDocumentTable(ctx context.Context, doc *Document)
 			}
 
 			if err := t.renderSectionTable(ctx, c, &result); err != nil {
-				return nil, fmt.Errorf("failed to render section %q: %w", c.Title(), err)
+				return fmt.Errorf("failed to render section %q: %w", c.Title(), err)
 			}
 
 		case *RawContent:
@@ -114,6 +143,14 @@ func (t *tableRenderer) renderDocumentTable(ctx context.Context, doc *Document)
 			result.WriteString(string(c.Data()))
 			result.WriteString("\n")
 
+		case *ChartContent:
+			if i > 0 {
+				result.WriteString("\n")
+			}
+			if err := t.writeChart(&result, c); err != nil {
+				return fmt.Errorf("failed to render content %s: %w", c.ID(), err)
+			}
+
 		default:
 			// Fallback for unknown content types. Render the transformed
 			// content, not the original: rendering `content` here would
@@ -123,13 +160,69 @@ func (t *tableRenderer) renderDocumentTable(ctx context.Context, doc *Document)
 			}
 			contentBytes, err := transformed.AppendText(nil)
 			if err != nil {
-				return nil, fmt.Errorf("failed to render content %s: %w", transformed.ID(), err)
+				return fmt.Errorf("failed to render content %s: %w", transformed.ID(), err)
 			}
 			result.Write(contentBytes)
 		}
+
+		if _, err := w.Write(result.Bytes()); err != nil {
+			return err
+		}
+		result.Reset()
 	}
 
-	return result.Bytes(), nil
+	return nil
+}
+
+// streamingTablePageSize is the number of rows rendered per page when a
+// StreamingTableContent is written as a console table.
+const streamingTablePageSize = 1000
+
+// writeStreamingTable renders a streaming table in pages of
+// streamingTablePageSize rows. Column widths depend on every row of a table,
+// so each page is laid out as its own table with the header repeated; the
+// title is shown on the first page only. This keeps memory bounded by the
+// page size regardless of how many rows the sequence yields. Expanded record
+// numbers continue across pages.
+func (t *tableRenderer) writeStreamingTable(ctx context.Context, table *StreamingTableContent, w io.Writer) error {
+	page := &TableContent{
+		id:     table.ID(),
+		title:  table.Title(),
+		schema: table.getSchema(),
+	}
+
+	pages := 0
+	flush := func() error {
+		if _, err := io.WriteString(w, t.renderTableString(page, pages*streamingTablePageSize+1)+"\n"); err != nil {
+			return err
+		}
+		page.title = ""
+		page.records = page.records[:0]
+		return nil
+	}
+
+	for record, err := range table.All() {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page.records = append(page.records, record)
+		if len(page.records) == streamingTablePageSize {
+			if err := flush(); err != nil {
+				return err
+			}
+			pages++
+		}
+	}
+
+	// Write the final partial page, or an empty table when there were no rows
+	if len(page.records) > 0 || pages == 0 {
+		return flush()
+	}
+	return nil
 }
 
 // renderSectionTable recursively renders a section and its contents to any
@@ -168,9 +261,17 @@ func (t *tableRenderer) renderSectionTable(ctx context.Context, section *Section
 			if j > 0 {
 				result.WriteString("\n")
 			}
-			result.WriteString(t.renderTable(sub).Render())
+			result.WriteString(t.renderTableString(sub, 1))
 			result.WriteString("\n")
 
+		case *StreamingTableContent:
+			if j > 0 {
+				result.WriteString("\n")
+			}
+			if err := t.writeStreamingTable(ctx, sub, result); err != nil {
+				return fmt.Errorf("failed to render table %s: %w", sub.ID(), err)
+			}
+
 		case *TextContent:
 			if j > 0 {
 				result.WriteString("\n")
@@ -193,6 +294,14 @@ func (t *tableRenderer) renderSectionTable(ctx context.Context, section *Section
 			result.WriteString(string(sub.Data()))
 			result.WriteString("\n")
 
+		case *ChartContent:
+			if j > 0 {
+				result.WriteString("\n")
+			}
+			if err := t.writeChart(result, sub); err != nil {
+				return fmt.Errorf("failed to render content %s: %w", sub.ID(), err)
+			}
+
 		case *DefaultCollapsibleSection:
 			if j > 0 {
 				result.WriteString("\n")
@@ -254,43 +363,141 @@ func (t *tableRenderer) renderTable(tableContent *TableContent) table.Writer {
 		return tw // Return empty table
 	}
 
-	// Configure column widths if maxColumnWidth is set
-	if t.maxColumnWidth > 0 {
-		columnConfigs := make([]table.ColumnConfig, len(keyOrder))
-		for i := range keyOrder {
-			columnConfigs[i] = table.ColumnConfig{
-				Number:   i + 1, // Column numbers are 1-indexed
-				WidthMax: t.maxColumnWidth,
-			}
-		}
-		tw.SetColumnConfigs(columnConfigs)
+	schema := tableContent.getSchema()
+	fields := make([]*Field, len(keyOrder))
+	for i, key := range keyOrder {
+		fields[i] = schema.FindField(key)
 	}
 
 	// Set headers with proper order
-	headerRow := make(table.Row, len(keyOrder))
-	for i, key := range keyOrder {
-		headerRow[i] = key
-	}
-	tw.AppendHeader(headerRow)
+	headers := slices.Clone(keyOrder)
 
 	// Add data rows preserving key order
-	for _, record := range tableContent.Records() {
-		row := make(table.Row, len(keyOrder))
+	records := tableContent.Records()
+	rows := make([][]string, len(records))
+	for r, record := range records {
+		row := make([]string, len(keyOrder))
 		for i, key := range keyOrder {
 			if val, exists := record[key]; exists {
 				// Apply field formatter if available and format cell value
-				field := tableContent.getSchema().FindField(key)
-				row[i] = t.formatCellValue(val, field)
-			} else {
-				row[i] = ""
+				row[i] = styleCellANSI(t.formatCellValue(val, fields[i]), cellStyle(fields[i], record, key))
+			}
+		}
+		rows[r] = row
+	}
+
+	// The footer is laid out with the rows so it shares their column widths
+	footer := tableContent.Footer()
+	if footer != nil {
+		row := make([]string, len(keyOrder))
+		for i, key := range keyOrder {
+			if val, exists := footer[key]; exists {
+				row[i] = t.formatCellValue(val, fields[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	// Configure column alignment and widths from the fields and maxColumnWidth
+	columnConfigs := t.tableColumnConfigs(fields, keyOrder, records)
+	if width := t.autoFitWidth(); width > 0 {
+		headers, rows, columnConfigs = t.autoFitColumns(tw, fields, headers, rows, columnConfigs, width)
+	}
+	tw.SetColumnConfigs(columnConfigs)
+
+	// Column groups add a header row above the column names in which each
+	// group name is merged across its adjacent columns
+	if schema.hasColumnGroups(headers) {
+		groupRow := make(table.Row, len(headers))
+		for i, key := range headers {
+			groupRow[i] = ""
+			if group := schema.columnGroup(key); group != nil {
+				groupRow[i] = group.Name
 			}
 		}
-		tw.AppendRow(row)
+		tw.AppendHeader(groupRow, table.RowConfig{AutoMerge: true, AutoMergeAlign: text.AlignCenter})
+	}
+	headerRow := make(table.Row, len(headers))
+	for i, key := range headers {
+		headerRow[i] = schema.columnHeader(key)
+	}
+	tw.AppendHeader(headerRow)
+	for r, row := range rows {
+		tableRow := make(table.Row, len(row))
+		for i, cell := range row {
+			tableRow[i] = cell
+		}
+		if footer != nil && r == len(rows)-1 {
+			tw.AppendFooter(tableRow)
+			continue
+		}
+		tw.AppendRow(tableRow)
 	}
 
 	return tw
 }
 
+// autoFitWidth returns the width auto-fit lays tables out in, or 0 when
+// auto-fit is off or the terminal width is unknown
+func (t *tableRenderer) autoFitWidth() int {
+	if !t.autoFit {
+		return 0
+	}
+	return t.outputWidth()
+}
+
+// outputWidth returns the configured width, or the terminal width when none
+// is set (0 when unknown)
+func (t *tableRenderer) outputWidth() int {
+	if t.width > 0 {
+		return t.width
+	}
+	return detectTerminalWidth()
+}
+
+// autoFitColumns fits headers and rows within width (see fitColumns). Kept
+// columns are wrapped on word boundaries and keep their configured
+// alignment; dropped columns are listed in the table caption.
+func (t *tableRenderer) autoFitColumns(tw table.Writer, fields []*Field, headers []string, rows [][]string, configs []table.ColumnConfig, width int) ([]string, [][]string, []table.ColumnConfig) {
+	natural := make([]int, len(headers))
+	for i, header := range headers {
+		natural[i] = cellWidth(header)
+		for _, row := range rows {
+			natural[i] = max(natural[i], cellWidth(row[i]))
+		}
+		if configs[i].WidthMax > 0 {
+			natural[i] = min(natural[i], configs[i].WidthMax)
+		}
+		natural[i] = max(natural[i], configs[i].WidthMin)
+	}
+
+	layout := fitColumns(*tw.Style(), fields, headers, natural, width)
+
+	keptHeaders := make([]string, len(layout.keep))
+	columnConfigs := make([]table.ColumnConfig, len(layout.keep))
+	for k, i := range layout.keep {
+		keptHeaders[k] = headers[i]
+		columnConfigs[k] = configs[i]
+		columnConfigs[k].Number = k + 1
+		columnConfigs[k].WidthMax = layout.widths[k]
+		columnConfigs[k].WidthMaxEnforcer = text.WrapSoft
+		columnConfigs[k].WidthMin = min(configs[i].WidthMin, layout.widths[k])
+	}
+	for r, row := range rows {
+		kept := make([]string, len(layout.keep))
+		for k, i := range layout.keep {
+			kept[k] = row[i]
+		}
+		rows[r] = kept
+	}
+
+	if len(layout.dropped) > 0 {
+		note := "Columns hidden to fit width: " + strings.Join(layout.dropped, ", ")
+		tw.SetCaption("%s", text.WrapSoft(note, width))
+	}
+	return keptHeaders, rows, columnConfigs
+}
+
 // formatCellValue applies field formatter and handles CollapsibleValue rendering for table output with error recovery
 // This implements Requirements 6.1-6.7 for table renderer collapsible support
 func (t *tableRenderer) formatCellValue(val any, field *Field) string {
@@ -302,7 +509,7 @@ func (t *tableRenderer) formatCellValue(val any, field *Field) string {
 	}()
 
 	// Apply field formatter using base renderer functionality
-	processed := t.processFieldValue(val, field)
+	processed := formattedValueFor(t.processFieldValue(val, field), FormatTable)
 
 	// Check if result is CollapsibleValue (Requirement 6.1)
 	if cv, ok := processed.(CollapsibleValue); ok {
@@ -537,6 +744,46 @@ func NewTableRendererWithStyleAndWidth(styleName string, maxColumnWidth int) Ren
 	}
 }
 
+// NewTableRendererWithAutoFit creates a table renderer that fits tables to
+// width, or to the terminal width when width is 0. See TableWithAutoFit.
+func NewTableRendererWithAutoFit(styleName string, width int) Renderer {
+	return &tableRenderer{
+		styleName: styleName,
+		autoFit:   true,
+		width:     width,
+	}
+}
+
+// NewTableRendererExpanded creates a table renderer that prints every record
+// as a vertical block of key/value lines. See TableExpanded.
+func NewTableRendererExpanded(styleName string) Renderer {
+	return &tableRenderer{
+		styleName: styleName,
+		expand:    expandAlways,
+	}
+}
+
+// NewTableRendererWithAutoExpand creates a table renderer that switches to
+// expanded records for tables wider than width, or than the terminal when
+// width is 0. See TableWithAutoExpand.
+func NewTableRendererWithAutoExpand(styleName string, width int) Renderer {
+	return &tableRenderer{
+		styleName: styleName,
+		expand:    expandAuto,
+		width:     width,
+	}
+}
+
+// NewTableRendererWithCharts creates a table renderer that draws charts in
+// the given chart style and colour scheme. See TableWithCharts.
+func NewTableRendererWithCharts(styleName string, chartStyle ChartStyle, scheme ColorScheme) Renderer {
+	return &tableRenderer{
+		styleName:   styleName,
+		chartStyle:  chartStyle,
+		chartScheme: &scheme,
+	}
+}
+
 // renderCollapsibleSection renders a CollapsibleSection for table output (Requirement 15.7)
 func (t *tableRenderer) renderCollapsibleSection(section *DefaultCollapsibleSection) ([]byte, error) {
 	var result strings.Builder
@@ -570,9 +817,8 @@ func (t *tableRenderer) renderCollapsibleSection(section *DefaultCollapsibleSect
 
 			switch c := content.(type) {
 			case *TableContent:
-				tableWriter := t.renderTable(c)
 				// Indent nested content (Requirement 15.7)
-				lines := strings.Split(tableWriter.Render(), "\n")
+				lines := strings.Split(t.renderTableString(c, 1), "\n")
 				for _, line := range lines {
 					if strings.TrimSpace(line) != "" {
 						result.WriteString("  " + line + "\n")
@@ -0,0 +1,8 @@
+//go:build !unix && !windows
+
+package output
+
+// terminalWidth returns 0: the terminal width cannot be detected on this platform
+func terminalWidth(uintptr) int {
+	return 0
+}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package output
+
+import "golang.org/x/sys/unix"
+
+// terminalWidth returns the width of the terminal open on fd, or 0
+func terminalWidth(fd uintptr) int {
+	ws, err := unix.IoctlGetWinsize(int(fd), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0
+	}
+	return int(ws.Col)
+}
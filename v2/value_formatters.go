@@ -0,0 +1,399 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultDisplayFormats are the formats that show a FormattedValue's text
+// unless WithDisplayFormats says otherwise
+var defaultDisplayFormats = []string{FormatTable, FormatMarkdown, FormatHTML}
+
+// FormattedValue is a value together with its human-readable text. The
+// built-in formatters (NumberFormatter, BytesFormatter and so on) return it so
+// that display formats show the text while data formats keep the raw value:
+// by default table, Markdown and HTML output use Text, and JSON, YAML, CSV
+// and XLSX output use Raw.
+type FormattedValue struct {
+	Raw  any
+	Text string
+
+	formats []string // Formats that show Text; nil means defaultDisplayFormats
+}
+
+// NewFormattedValue creates a FormattedValue shown as text in the given
+// formats, or in table, Markdown and HTML output when none are given
+func NewFormattedValue(raw any, text string, formats ...string) FormattedValue {
+	return FormattedValue{Raw: raw, Text: text, formats: slices.Clone(formats)}
+}
+
+// String returns the formatted text
+func (v FormattedValue) String() string {
+	return v.Text
+}
+
+// ValueFor returns Text when format displays formatted values and Raw
+// otherwise
+func (v FormattedValue) ValueFor(format string) any {
+	if v.displayedIn(format) {
+		return v.Text
+	}
+	return v.Raw
+}
+
+// displayedIn reports whether format shows Text
+func (v FormattedValue) displayedIn(format string) bool {
+	if v.formats == nil {
+		return slices.Contains(defaultDisplayFormats, format)
+	}
+	return slices.Contains(v.formats, format)
+}
+
+// formattedValueFor resolves a FormattedValue for format; other values are
+// returned unchanged
+func formattedValueFor(val any, format string) any {
+	if fv, ok := val.(FormattedValue); ok {
+		return fv.ValueFor(format)
+	}
+	return val
+}
+
+// ValueFormatOption configures a built-in value formatter
+type ValueFormatOption func(*valueFormatConfig)
+
+type valueFormatConfig struct {
+	formats []string
+}
+
+// WithDisplayFormats sets the formats that show the formatted text, such as
+// FormatTable and FormatCSV for a CSV report meant for people. Other formats
+// keep the raw value. The default is table, Markdown and HTML.
+func WithDisplayFormats(formats ...string) ValueFormatOption {
+	return func(c *valueFormatConfig) {
+		c.formats = slices.Clone(formats)
+		if c.formats == nil {
+			c.formats = []string{}
+		}
+	}
+}
+
+// newValueFormatter wraps format in a field formatter. Values format cannot
+// handle, for which it returns false, are passed through unchanged, as are
+// values that are already formatted or collapsible.
+func newValueFormatter(format func(any) (string, bool), opts []ValueFormatOption) func(any) any {
+	var cfg valueFormatConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return func(val any) any {
+		switch val.(type) {
+		case nil, FormattedValue, CollapsibleValue:
+			return val
+		}
+		text, ok := format(val)
+		if !ok {
+			return val
+		}
+		return FormattedValue{Raw: val, Text: text, formats: cfg.formats}
+	}
+}
+
+// formatterNumber converts integer and floating point values to float64.
+// time.Duration is not a number for formatting purposes.
+func formatterNumber(val any) (float64, bool) {
+	if _, ok := val.(time.Duration); ok {
+		return 0, false
+	}
+	n, _, ok := exprNumber(val, false)
+	if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, false
+	}
+	return n, true
+}
+
+// formatDecimal formats n with precision decimals (the shortest exact
+// representation when precision is negative), grouping thousands with commas
+// when grouping is set
+func formatDecimal(n float64, precision int, grouping bool) string {
+	text := strconv.FormatFloat(n, 'f', precision, 64)
+	if !grouping {
+		return text
+	}
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	intPart, fraction, hasFraction := strings.Cut(text, ".")
+	var grouped strings.Builder
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	if hasFraction {
+		return sign + grouped.String() + "." + fraction
+	}
+	return sign + grouped.String()
+}
+
+// NumberFormatter formats numbers with precision decimals, or as many as
+// needed when precision is negative, and with comma thousands separators
+// when grouping is set: NumberFormatter(2, true) shows 1234.5 as "1,234.50".
+func NumberFormatter(precision int, grouping bool, opts ...ValueFormatOption) func(any) any {
+	return newValueFormatter(func(val any) (string, bool) {
+		n, ok := formatterNumber(val)
+		if !ok {
+			return "", false
+		}
+		return formatDecimal(n, precision, grouping), true
+	}, opts)
+}
+
+// PercentFormatter formats fractions as percentages with precision
+// decimals: PercentFormatter(1) shows 0.125 as "12.5%".
+func PercentFormatter(precision int, opts ...ValueFormatOption) func(any) any {
+	return newValueFormatter(func(val any) (string, bool) {
+		n, ok := formatterNumber(val)
+		if !ok {
+			return "", false
+		}
+		return formatDecimal(n*100, precision, true) + "%", true
+	}, opts)
+}
+
+// currencySymbols are the symbols CurrencyFormatter uses instead of the code
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"INR": "₹",
+	"KRW": "₩",
+}
+
+// zeroDecimalCurrencies have no minor unit
+var zeroDecimalCurrencies = []string{"JPY", "KRW"}
+
+// CurrencyFormatter formats amounts in the ISO 4217 currency code with
+// grouped thousands and two decimals (none for currencies without a minor
+// unit): CurrencyFormatter("USD") shows -1234.5 as "-$1,234.50". Currencies
+// without a known symbol are prefixed with their code, as in "SEK 1,234.50".
+func CurrencyFormatter(code string, opts ...ValueFormatOption) func(any) any {
+	code = strings.ToUpper(code)
+	precision := 2
+	if slices.Contains(zeroDecimalCurrencies, code) {
+		precision = 0
+	}
+	symbol, ok := currencySymbols[code]
+	if !ok {
+		symbol = code + " "
+	}
+	return newValueFormatter(func(val any) (string, bool) {
+		n, ok := formatterNumber(val)
+		if !ok {
+			return "", false
+		}
+		amount := formatDecimal(math.Abs(n), precision, true)
+		if n < 0 && strings.Trim(amount, "0.,") != "" {
+			return "-" + symbol + amount, true
+		}
+		return symbol + amount, true
+	}, opts)
+}
+
+// ByteUnits selects the unit system BytesFormatter uses
+type ByteUnits int
+
+const (
+	// BytesIEC uses powers of 1024: KiB, MiB, GiB and so on
+	BytesIEC ByteUnits = iota
+	// BytesSI uses powers of 1000: kB, MB, GB and so on
+	BytesSI
+)
+
+var (
+	iecByteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	siByteUnits  = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+)
+
+// BytesFormatter formats byte counts in the largest unit that keeps the
+// value at or above 1, with one decimal: BytesFormatter(BytesIEC) shows
+// 1288490189 as "1.2 GiB" and BytesFormatter(BytesSI) as "1.3 GB". Counts
+// below one kilobyte are shown exactly, as in "512 B".
+func BytesFormatter(units ByteUnits, opts ...ValueFormatOption) func(any) any {
+	base, names := 1024.0, iecByteUnits
+	if units == BytesSI {
+		base, names = 1000.0, siByteUnits
+	}
+	return newValueFormatter(func(val any) (string, bool) {
+		n, ok := formatterNumber(val)
+		if !ok {
+			return "", false
+		}
+		size := math.Abs(n)
+		unit := 0
+		for size >= base && unit < len(names)-1 {
+			size /= base
+			unit++
+		}
+		if n < 0 {
+			size = -size
+		}
+		if unit == 0 {
+			return formatDecimal(size, -1, false) + " B", true
+		}
+		return strconv.FormatFloat(size, 'f', 1, 64) + " " + names[unit], true
+	}, opts)
+}
+
+// DurationFormatter formats time.Duration values, and numbers as seconds,
+// with their two most significant units: "3h4m", "2d5h", "45s" or "350ms".
+func DurationFormatter(opts ...ValueFormatOption) func(any) any {
+	return newValueFormatter(func(val any) (string, bool) {
+		d, ok := val.(time.Duration)
+		if !ok {
+			seconds, ok := formatterNumber(val)
+			if !ok {
+				return "", false
+			}
+			d = time.Duration(seconds * float64(time.Second))
+		}
+		return formatCompactDuration(d), true
+	}, opts)
+}
+
+// formatCompactDuration writes d with its two most significant units of
+// days, hours, minutes and seconds. Durations under a second are shown in
+// milliseconds, or as d.String() below a millisecond.
+func formatCompactDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	if d < time.Second {
+		if d < time.Millisecond {
+			return sign + d.String()
+		}
+		return sign + strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+	}
+
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	}
+	var parts []string
+	remaining := d
+	for _, unit := range units {
+		if remaining < unit.size && len(parts) == 0 {
+			continue
+		}
+		count := remaining / unit.size
+		remaining -= count * unit.size
+		if count > 0 {
+			parts = append(parts, strconv.FormatInt(int64(count), 10)+unit.name)
+		}
+		if len(parts) == 2 || (len(parts) > 0 && count == 0) {
+			break
+		}
+	}
+	return sign + strings.Join(parts, "")
+}
+
+// formatterTime converts time.Time values and RFC 3339 strings to a time
+func formatterTime(val any) (time.Time, bool) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// TimeFormatter formats times, given as time.Time or RFC 3339 strings, with
+// layout in location. A nil location keeps each time's own location.
+func TimeFormatter(layout string, location *time.Location, opts ...ValueFormatOption) func(any) any {
+	return newValueFormatter(func(val any) (string, bool) {
+		t, ok := formatterTime(val)
+		if !ok {
+			return "", false
+		}
+		if location != nil {
+			t = t.In(location)
+		}
+		return t.Format(layout), true
+	}, opts)
+}
+
+// relativeTimeNow returns the time RelativeTimeFormatter measures from
+var relativeTimeNow = time.Now
+
+// RelativeTimeFormatter formats times, given as time.Time or RFC 3339
+// strings, relative to the time of rendering in the largest whole unit:
+// "just now", "5 minutes ago", "3 days ago" or "in 2 hours".
+func RelativeTimeFormatter(opts ...ValueFormatOption) func(any) any {
+	return newValueFormatter(func(val any) (string, bool) {
+		t, ok := formatterTime(val)
+		if !ok {
+			return "", false
+		}
+		return formatRelativeTime(relativeTimeNow().Sub(t)), true
+	}, opts)
+}
+
+// formatRelativeTime describes an offset from now; positive offsets are in
+// the past
+func formatRelativeTime(offset time.Duration) string {
+	future := offset < 0
+	if future {
+		offset = -offset
+	}
+	if offset < time.Minute {
+		return "just now"
+	}
+
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{365 * 24 * time.Hour, "year"},
+		{30 * 24 * time.Hour, "month"},
+		{7 * 24 * time.Hour, "week"},
+		{24 * time.Hour, "day"},
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+	}
+	for _, unit := range units {
+		if offset < unit.size {
+			continue
+		}
+		count := int64(offset / unit.size)
+		text := fmt.Sprintf("%d %s", count, unit.name)
+		if count != 1 {
+			text += "s"
+		}
+		if future {
+			return "in " + text
+		}
+		return text + " ago"
+	}
+	return "just now"
+}
@@ -0,0 +1,155 @@
+package output
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValueFormatters(t *testing.T) {
+	utc := time.Date(2026, 3, 4, 15, 4, 5, 0, time.UTC)
+	sydney := time.FixedZone("AEDT", 11*60*60)
+
+	tests := map[string]struct {
+		formatter func(any) any
+		input     any
+		want      string
+	}{
+		"number grouped":           {NumberFormatter(2, true), 1234567.891, "1,234,567.89"},
+		"number shortest":          {NumberFormatter(-1, true), -1234.5, "-1,234.5"},
+		"number without grouping":  {NumberFormatter(0, false), 1234, "1234"},
+		"percent":                  {PercentFormatter(1), 0.125, "12.5%"},
+		"currency USD":             {CurrencyFormatter("USD"), -1234.5, "-$1,234.50"},
+		"currency JPY":             {CurrencyFormatter("jpy"), 1500, "¥1,500"},
+		"currency without symbol":  {CurrencyFormatter("SEK"), 99, "SEK 99.00"},
+		"currency negative zero":   {CurrencyFormatter("EUR"), -0.001, "€0.00"},
+		"bytes IEC":                {BytesFormatter(BytesIEC), 1288490189, "1.2 GiB"},
+		"bytes SI":                 {BytesFormatter(BytesSI), int64(1288490189), "1.3 GB"},
+		"bytes small":              {BytesFormatter(BytesIEC), uint(512), "512 B"},
+		"duration":                 {DurationFormatter(), 3*time.Hour + 4*time.Minute + 12*time.Second, "3h4m"},
+		"duration days":            {DurationFormatter(), 53 * time.Hour, "2d5h"},
+		"duration skips zero unit": {DurationFormatter(), 3*time.Hour + 5*time.Second, "3h"},
+		"duration seconds number":  {DurationFormatter(), 45, "45s"},
+		"duration milliseconds":    {DurationFormatter(), 350 * time.Millisecond, "350ms"},
+		"time layout":              {TimeFormatter(time.DateTime, nil), utc, "2026-03-04 15:04:05"},
+		"time location":            {TimeFormatter("15:04 MST", sydney), utc, "02:04 AEDT"},
+		"time string":              {TimeFormatter(time.DateOnly, nil), "2026-03-04T15:04:05Z", "2026-03-04"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := tc.formatter(tc.input).(FormattedValue)
+			if !ok {
+				t.Fatalf("formatter returned %T, want FormattedValue", tc.formatter(tc.input))
+			}
+			if got.Text != tc.want {
+				t.Errorf("Text = %q, want %q", got.Text, tc.want)
+			}
+			if got.Raw != tc.input {
+				t.Errorf("Raw = %v, want %v", got.Raw, tc.input)
+			}
+		})
+	}
+}
+
+func TestValueFormatters_PassThrough(t *testing.T) {
+	for name, input := range map[string]any{"nil": nil, "text": "n/a", "bool": true} {
+		t.Run(name, func(t *testing.T) {
+			if got := NumberFormatter(2, true)(input); got != input {
+				t.Errorf("NumberFormatter(%v) = %v, want unchanged", input, got)
+			}
+		})
+	}
+	if got := DurationFormatter()("soon"); got != "soon" {
+		t.Errorf("DurationFormatter(soon) = %v, want unchanged", got)
+	}
+}
+
+func TestRelativeTimeFormatter(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	defer func(orig func() time.Time) { relativeTimeNow = orig }(relativeTimeNow)
+	relativeTimeNow = func() time.Time { return now }
+
+	tests := map[string]struct {
+		input time.Time
+		want  string
+	}{
+		"just now":   {now.Add(-20 * time.Second), "just now"},
+		"one minute": {now.Add(-time.Minute), "1 minute ago"},
+		"hours":      {now.Add(-5 * time.Hour), "5 hours ago"},
+		"days":       {now.Add(-3 * 24 * time.Hour), "3 days ago"},
+		"weeks":      {now.Add(-15 * 24 * time.Hour), "2 weeks ago"},
+		"years":      {now.Add(-800 * 24 * time.Hour), "2 years ago"},
+		"future":     {now.Add(2*time.Hour + time.Minute), "in 2 hours"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := RelativeTimeFormatter()(tc.input).(FormattedValue)
+			if got.Text != tc.want {
+				t.Errorf("Text = %q, want %q", got.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormattedValue_PerFormat(t *testing.T) {
+	build := func(opts ...ValueFormatOption) *Document {
+		table, err := NewTableContent("", []Record{{"Size": 1536}}, WithSchema(
+			Field{Name: "Size", Formatter: BytesFormatter(BytesIEC, opts...)},
+		))
+		if err != nil {
+			t.Fatalf("NewTableContent() error = %v", err)
+		}
+		return New().AddContent(table).Build()
+	}
+
+	tests := map[string]struct {
+		format Format
+		opts   []ValueFormatOption
+		want   string
+	}{
+		"table shows text":         {format: TableWithStyle("Default"), want: "1.5 KiB"},
+		"markdown shows text":      {format: Markdown(), want: "| 1.5 KiB |"},
+		"html shows text":          {format: HTML(), want: `<td data-sort-value="1536">1.5 KiB</td>`},
+		"json keeps raw":           {format: JSON(), want: `"Size": 1536`},
+		"yaml keeps raw":           {format: YAML(), want: "Size: 1536"},
+		"csv keeps raw":            {format: CSV(), want: "Size\n1536\n"},
+		"csv configured for text":  {format: CSV(), opts: []ValueFormatOption{WithDisplayFormats(FormatTable, FormatCSV)}, want: "Size\n1.5 KiB\n"},
+		"table configured for raw": {format: TableWithStyle("Default"), opts: []ValueFormatOption{WithDisplayFormats(FormatCSV)}, want: "| 1536 |"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.format.Renderer.Render(context.Background(), build(tc.opts...))
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !strings.Contains(string(out), tc.want) {
+				t.Errorf("output missing %q\n%s", tc.want, out)
+			}
+		})
+	}
+}
+
+func TestStructTagBuiltinFormatters(t *testing.T) {
+	type usage struct {
+		Name string        `output:"name"`
+		Size int64         `output:"size,format=bytes"`
+		Took time.Duration `output:"took,format=duration"`
+	}
+	table, err := NewTableContent("", []usage{{Name: "backup", Size: 3 << 30, Took: 90 * time.Minute}})
+	if err != nil {
+		t.Fatalf("NewTableContent() error = %v", err)
+	}
+	out, err := TableWithStyle("Default").Renderer.Render(context.Background(), New().AddContent(table).Build())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{"3.0 GiB", "1h30m"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
@@ -11,6 +11,13 @@ type Writer interface {
 	Write(ctx context.Context, format string, data []byte) error
 }
 
+// terminalWriter is implemented by writers that can tell whether they write
+// to a terminal, so Output can render terminal output for them (see
+// terminalRenderer)
+type terminalWriter interface {
+	writesToTerminal() bool
+}
+
 // WriterFunc is an adapter to allow the use of ordinary functions as Writers
 type WriterFunc func(ctx context.Context, format string, data []byte) error
 
@@ -0,0 +1,538 @@
+package output
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Spreadsheet limits from the OOXML specification as implemented by Excel.
+const (
+	xlsxMaxSheetNameLength = 31
+	xlsxMaxRows            = 1048576
+	xlsxMaxColumns         = 16384
+	xlsxMaxCellLength      = 32767
+	xlsxMaxColumnWidth     = 60
+)
+
+// Cell style indexes into the cellXfs list written by xlsxStylesXML.
+const (
+	xlsxStyleDefault = 0
+	xlsxStyleHeader  = 1
+	xlsxStyleDate    = 2
+)
+
+// xlsxZipModified is the modification time stamped on every archive entry,
+// so rendering the same document twice yields identical bytes.
+var xlsxZipModified = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// xlsxEpoch is day zero of Excel's 1900 date system (the 1900 leap-year bug
+// is absorbed by starting on 30 December rather than 1 January).
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxRenderer implements the XLSX spreadsheet format. Every table in the
+// document, including tables nested in sections and collapsible sections,
+// becomes a worksheet named after the table title. Other content types have
+// no spreadsheet representation and are skipped.
+//
+// Cells hold raw record values, as in CSV and JSON: field formatters are not
+// applied, except that a formatter returning a CollapsibleValue contributes
+// its summary. Field.Type selects the cell type, falling back to the Go type
+// of the value when the two disagree.
+type xlsxRenderer struct{}
+
+func (x *xlsxRenderer) Format() string {
+	return FormatXLSX
+}
+
+func (x *xlsxRenderer) Render(ctx context.Context, doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := x.RenderTo(ctx, doc, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderTo writes the workbook to w. The zip archive is written as sheets are
+// produced, but each sheet is built in memory first.
+func (x *xlsxRenderer) RenderTo(ctx context.Context, doc *Document, w io.Writer) error {
+	if doc == nil {
+		return fmt.Errorf("document cannot be nil")
+	}
+	if w == nil {
+		return fmt.Errorf("writer cannot be nil")
+	}
+
+	var tables []*TableContent
+	for _, content := range doc.GetContents() {
+		collected, err := x.collectTables(ctx, content)
+		if err != nil {
+			return err
+		}
+		tables = append(tables, collected...)
+	}
+
+	return x.writeWorkbook(ctx, tables, w)
+}
+
+func (x *xlsxRenderer) SupportsStreaming() bool {
+	return false
+}
+
+// collectTables applies per-content transformations and returns the tables
+// found in content, descending into sections to any depth.
+func (x *xlsxRenderer) collectTables(ctx context.Context, content Content) ([]*TableContent, error) {
+	if content == nil {
+		return nil, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	transformed, err := applyContentTransformations(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c := transformed.(type) {
+	case *TableContent:
+		return []*TableContent{c}, nil
+	case *StreamingTableContent:
+		table, err := c.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []*TableContent{table}, nil
+	case *SectionContent:
+		return x.collectNestedTables(ctx, c.Contents())
+	case *DefaultCollapsibleSection:
+		return x.collectNestedTables(ctx, c.Content())
+	default:
+		return nil, nil
+	}
+}
+
+func (x *xlsxRenderer) collectNestedTables(ctx context.Context, contents []Content) ([]*TableContent, error) {
+	var tables []*TableContent
+	for _, content := range contents {
+		collected, err := x.collectTables(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, collected...)
+	}
+	return tables, nil
+}
+
+// writeWorkbook writes the OOXML package. A workbook must contain at least
+// one sheet, so a document without tables produces a single empty sheet.
+func (x *xlsxRenderer) writeWorkbook(ctx context.Context, tables []*TableContent, w io.Writer) error {
+	sheetNames := xlsxSheetNames(tables)
+	if len(sheetNames) == 0 {
+		sheetNames = []string{"Sheet1"}
+	}
+
+	zw := zip.NewWriter(w)
+	parts := []struct {
+		name string
+		data string
+	}{
+		{"[Content_Types].xml", xlsxContentTypesXML(len(sheetNames))},
+		{"_rels/.rels", xlsxRootRelsXML},
+		{"xl/workbook.xml", xlsxWorkbookXML(sheetNames)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRelsXML(len(sheetNames))},
+		{"xl/styles.xml", xlsxStylesXML},
+	}
+	for _, part := range parts {
+		if err := xlsxWriteZipEntry(zw, part.name, []byte(part.data)); err != nil {
+			return err
+		}
+	}
+
+	if len(tables) == 0 {
+		if err := xlsxWriteZipEntry(zw, "xl/worksheets/sheet1.xml", x.sheetXML(nil)); err != nil {
+			return err
+		}
+	}
+	for i, table := range tables {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if rows := len(table.records) + 1; rows > xlsxMaxRows {
+			return fmt.Errorf("table %q has %d rows including the header, exceeding the xlsx limit of %d", table.Title(), rows, xlsxMaxRows)
+		}
+		if cols := len(table.getSchema().GetKeyOrder()); cols > xlsxMaxColumns {
+			return fmt.Errorf("table %q has %d columns, exceeding the xlsx limit of %d", table.Title(), cols, xlsxMaxColumns)
+		}
+		name := fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)
+		if err := xlsxWriteZipEntry(zw, name, x.sheetXML(table)); err != nil {
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize xlsx archive: %w", err)
+	}
+	return nil
+}
+
+// xlsxWriteZipEntry writes one compressed archive entry with a fixed
+// timestamp.
+func xlsxWriteZipEntry(zw *zip.Writer, name string, data []byte) error {
+	entry, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: xlsxZipModified,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create xlsx entry %s: %w", name, err)
+	}
+	if _, err := entry.Write(data); err != nil {
+		return fmt.Errorf("failed to write xlsx entry %s: %w", name, err)
+	}
+	return nil
+}
+
+// sheetXML builds a worksheet for table: a bold header row frozen in place,
+// then one row per record in schema key order. A nil table yields an empty
+// sheet.
+func (x *xlsxRenderer) sheetXML(table *TableContent) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	if table == nil || len(table.getSchema().GetKeyOrder()) == 0 {
+		buf.WriteString(`<sheetData/></worksheet>`)
+		return buf.Bytes()
+	}
+
+	schema := table.getSchema()
+	keyOrder := schema.GetKeyOrder()
+	fields := make([]*Field, len(keyOrder))
+	widths := make([]int, len(keyOrder))
+	for i, key := range keyOrder {
+		fields[i] = schema.FindField(key)
+	}
+
+	// Grouped columns get "Group / Column" headers
+	headers := schema.flatColumnHeaders(keyOrder)
+	for i, header := range headers {
+		widths[i] = utf8.RuneCountInString(header)
+	}
+
+	var rows bytes.Buffer
+	rows.WriteString(`<row r="1">`)
+	for col, header := range headers {
+		xlsxWriteStringCell(&rows, xlsxCellRef(col, 1), header, xlsxStyleHeader)
+	}
+	rows.WriteString(`</row>`)
+
+	for i, record := range table.records {
+		rowNum := i + 2
+		fmt.Fprintf(&rows, `<row r="%d">`, rowNum)
+		for col, key := range keyOrder {
+			val, exists := record[key]
+			if !exists || val == nil {
+				continue
+			}
+			text := xlsxWriteCell(&rows, xlsxCellRef(col, rowNum), val, fields[col])
+			widths[col] = max(widths[col], utf8.RuneCountInString(text))
+		}
+		rows.WriteString(`</row>`)
+	}
+
+	buf.WriteString(`<sheetViews><sheetView workbookViewId="0">`)
+	buf.WriteString(`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+	buf.WriteString(`<selection pane="bottomLeft" activeCell="A2" sqref="A2"/>`)
+	buf.WriteString(`</sheetView></sheetViews>`)
+
+	buf.WriteString(`<cols>`)
+	for i, width := range widths {
+		fmt.Fprintf(&buf, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, min(width+2, xlsxMaxColumnWidth))
+	}
+	buf.WriteString(`</cols>`)
+
+	buf.WriteString(`<sheetData>`)
+	buf.Write(rows.Bytes())
+	buf.WriteString(`</sheetData></worksheet>`)
+	return buf.Bytes()
+}
+
+// xlsxWriteCell writes val as a typed cell and returns its display text for
+// column width estimation.
+func xlsxWriteCell(buf *bytes.Buffer, ref string, val any, field *Field) string {
+	// A collapsible formatter contributes its summary, as in CSV output, and
+	// a formatted value its text when displayed in XLSX
+	if field != nil && field.Formatter != nil {
+		switch v := field.Formatter(val).(type) {
+		case CollapsibleValue:
+			summary := v.Summary()
+			xlsxWriteStringCell(buf, ref, summary, xlsxStyleDefault)
+			return summary
+		case FormattedValue:
+			if v.displayedIn(FormatXLSX) {
+				xlsxWriteStringCell(buf, ref, v.Text, xlsxStyleDefault)
+				return v.Text
+			}
+		}
+	}
+
+	fieldType := ""
+	if field != nil {
+		fieldType = field.Type
+	}
+
+	if t, ok := val.(time.Time); ok {
+		fmt.Fprintf(buf, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxStyleDate, strconv.FormatFloat(xlsxDateSerial(t), 'f', -1, 64))
+		return "yyyy-mm-dd hh:mm:ss"
+	}
+
+	switch fieldType {
+	case fieldTypeInt, fieldTypeUint, fieldTypeFloat:
+		if n, ok := xlsxNumber(val, true); ok {
+			return xlsxWriteNumberCell(buf, ref, n)
+		}
+	case fieldTypeBool:
+		if b, ok := xlsxBool(val, true); ok {
+			return xlsxWriteBoolCell(buf, ref, b)
+		}
+	case fieldTypeString:
+		text := formatValue(val)
+		xlsxWriteStringCell(buf, ref, text, xlsxStyleDefault)
+		return text
+	}
+
+	// Unknown or mismatched field type: use the Go type of the value
+	if n, ok := xlsxNumber(val, false); ok {
+		return xlsxWriteNumberCell(buf, ref, n)
+	}
+	if b, ok := xlsxBool(val, false); ok {
+		return xlsxWriteBoolCell(buf, ref, b)
+	}
+	text := formatValue(val)
+	xlsxWriteStringCell(buf, ref, text, xlsxStyleDefault)
+	return text
+}
+
+func xlsxWriteNumberCell(buf *bytes.Buffer, ref string, n float64) string {
+	text := strconv.FormatFloat(n, 'g', -1, 64)
+	fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, ref, text)
+	return text
+}
+
+func xlsxWriteBoolCell(buf *bytes.Buffer, ref string, b bool) string {
+	v := "0"
+	if b {
+		v = "1"
+	}
+	fmt.Fprintf(buf, `<c r="%s" t="b"><v>%s</v></c>`, ref, v)
+	return strconv.FormatBool(b)
+}
+
+// xlsxWriteStringCell writes an inline string cell. Inline strings avoid a
+// shared string table, so each sheet is self-contained.
+func xlsxWriteStringCell(buf *bytes.Buffer, ref, text string, style int) {
+	if utf8.RuneCountInString(text) > xlsxMaxCellLength {
+		text = string([]rune(text)[:xlsxMaxCellLength])
+	}
+	fmt.Fprintf(buf, `<c r="%s" t="inlineStr"`, ref)
+	if style != xlsxStyleDefault {
+		fmt.Fprintf(buf, ` s="%d"`, style)
+	}
+	buf.WriteString(`><is><t xml:space="preserve">`)
+	_ = xml.EscapeText(buf, []byte(text))
+	buf.WriteString(`</t></is></c>`)
+}
+
+// xlsxNumber converts numeric values to float64. With parseStrings, numeric
+// strings are accepted too, so a column declared as a number stays numeric
+// when its data arrived as text (for example from CSV). NaN and infinities
+// have no cell representation and are rejected.
+func xlsxNumber(val any, parseStrings bool) (float64, bool) {
+	var n float64
+	switch v := val.(type) {
+	case string:
+		if !parseStrings {
+			return 0, false
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false
+		}
+		n = parsed
+	default:
+		rv := reflect.ValueOf(val)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = float64(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = float64(rv.Uint())
+		case reflect.Float32, reflect.Float64:
+			n = rv.Float()
+		default:
+			return 0, false
+		}
+	}
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, false
+	}
+	return n, true
+}
+
+// xlsxBool converts boolean values, and with parseStrings also "true"/"false"
+// style strings.
+func xlsxBool(val any, parseStrings bool) (bool, bool) {
+	switch v := val.(type) {
+	case bool:
+		return v, true
+	case string:
+		if !parseStrings {
+			return false, false
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		return b, err == nil
+	}
+	return false, false
+}
+
+// xlsxDateSerial converts t to an Excel serial date in t's own location:
+// spreadsheets have no time zones, so the wall-clock time is kept.
+func xlsxDateSerial(t time.Time) float64 {
+	year, month, day := t.Date()
+	days := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Sub(xlsxEpoch).Hours() / 24
+	hour, minute, sec := t.Clock()
+	seconds := float64(hour*3600+minute*60+sec) + float64(t.Nanosecond())/1e9
+	return math.Round(days) + seconds/86400
+}
+
+// xlsxCellRef returns the A1-style reference for a zero-based column and a
+// one-based row.
+func xlsxCellRef(col, row int) string {
+	return xlsxColumnName(col) + strconv.Itoa(row)
+}
+
+// xlsxColumnName returns the column letters for a zero-based column index:
+// 0 -> A, 25 -> Z, 26 -> AA.
+func xlsxColumnName(col int) string {
+	var name []byte
+	for col >= 0 {
+		name = append([]byte{byte('A' + col%26)}, name...)
+		col = col/26 - 1
+	}
+	return string(name)
+}
+
+// xlsxSheetNames derives a valid, unique worksheet name for each table.
+// Excel forbids the characters : \ / ? * [ ], leading or trailing
+// apostrophes, names longer than 31 characters, and duplicate names
+// (compared case-insensitively). Untitled tables are named "Sheet<n>".
+func xlsxSheetNames(tables []*TableContent) []string {
+	names := make([]string, len(tables))
+	used := make(map[string]bool, len(tables))
+
+	for i, table := range tables {
+		base := strings.Map(func(r rune) rune {
+			switch r {
+			case ':', '\\', '/', '?', '*', '[', ']':
+				return '_'
+			}
+			if r < 0x20 {
+				return -1
+			}
+			return r
+		}, table.Title())
+		base = strings.Trim(strings.TrimSpace(base), "'")
+		if base == "" {
+			base = fmt.Sprintf("Sheet%d", i+1)
+		}
+		base = xlsxTruncateRunes(base, xlsxMaxSheetNameLength)
+
+		name := base
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			name = xlsxTruncateRunes(base, xlsxMaxSheetNameLength-len(suffix)) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+func xlsxTruncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	return string([]rune(s)[:limit])
+}
+
+func xlsxContentTypesXML(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+const xlsxRootRelsXML = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+func xlsxWorkbookXML(sheetNames []string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	b.WriteString(`<sheets>`)
+	for i, name := range sheetNames {
+		b.WriteString(`<sheet name="`)
+		_ = xml.EscapeText(&b, []byte(name))
+		fmt.Fprintf(&b, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func xlsxWorkbookRelsXML(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheets+1)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+// xlsxStylesXML defines the cell formats referenced by xlsxStyle*: the
+// default, a bold header and an ISO-style date-time.
+const xlsxStylesXML = xml.Header +
+	`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
@@ -0,0 +1,193 @@
+package output
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readXLSXParts renders doc as xlsx and returns the archive entries by name.
+func readXLSXParts(t *testing.T, doc *Document) map[string]string {
+	t.Helper()
+
+	data, err := XLSX().Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not a zip archive: %v", err)
+	}
+
+	parts := make(map[string]string)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+		parts[file.Name] = string(content)
+	}
+	return parts
+}
+
+func TestXLSXRenderer_Workbook(t *testing.T) {
+	doc := New().
+		Text("ignored in spreadsheets").
+		Table("Users", []Record{{"name": "Alice", "age": 30}}, WithKeys("name", "age")).
+		Section("Details", func(b *Builder) {
+			b.Table("Nested", []Record{{"id": 1}}, WithKeys("id"))
+		}).
+		Build()
+
+	parts := readXLSXParts(t, doc)
+
+	for _, name := range []string{
+		"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels",
+		"xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml",
+	} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("archive missing %s", name)
+		}
+	}
+
+	workbook := parts["xl/workbook.xml"]
+	for _, want := range []string{`<sheet name="Users" sheetId="1"`, `<sheet name="Nested" sheetId="2"`} {
+		if !strings.Contains(workbook, want) {
+			t.Errorf("workbook.xml missing %s:\n%s", want, workbook)
+		}
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	if !strings.Contains(sheet, `state="frozen"`) || !strings.Contains(sheet, `topLeftCell="A2"`) {
+		t.Errorf("header row is not frozen:\n%s", sheet)
+	}
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr" s="1"><is><t xml:space="preserve">name</t></is></c>`,
+		`<c r="B1" t="inlineStr" s="1"><is><t xml:space="preserve">age</t></is></c>`,
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">Alice</t></is></c>`,
+		`<c r="B2"><v>30</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet1.xml missing %s", want)
+		}
+	}
+}
+
+func TestXLSXRenderer_CellTypes(t *testing.T) {
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	doc := New().Table("Types", []Record{{
+		"count":   "42",
+		"enabled": true,
+		"ratio":   0.5,
+		"code":    7,
+		"when":    when,
+		"note":    "a < b",
+	}}, WithSchema(
+		Field{Name: "count", Type: fieldTypeInt},
+		Field{Name: "enabled", Type: fieldTypeBool},
+		Field{Name: "ratio"},
+		Field{Name: "code", Type: fieldTypeString},
+		Field{Name: "when", Type: fieldTypeTime},
+		Field{Name: "note"},
+	)).Build()
+
+	sheet := readXLSXParts(t, doc)["xl/worksheets/sheet1.xml"]
+
+	tests := map[string]string{
+		"numeric string in int column": `<c r="A2"><v>42</v></c>`,
+		"bool":                         `<c r="B2" t="b"><v>1</v></c>`,
+		"inferred float":               `<c r="C2"><v>0.5</v></c>`,
+		"int in string column":         `<c r="D2" t="inlineStr"><is><t xml:space="preserve">7</t></is></c>`,
+		"time as date serial":          `<c r="E2" s="2"><v>45352.5</v></c>`,
+		"escaped text":                 `<t xml:space="preserve">a &lt; b</t>`,
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			if !strings.Contains(sheet, want) {
+				t.Errorf("sheet missing %s:\n%s", want, sheet)
+			}
+		})
+	}
+}
+
+func TestXLSXRenderer_EmptyDocument(t *testing.T) {
+	parts := readXLSXParts(t, New().Text("no tables").Build())
+
+	if !strings.Contains(parts["xl/workbook.xml"], `<sheet name="Sheet1"`) {
+		t.Errorf("workbook without tables should contain Sheet1:\n%s", parts["xl/workbook.xml"])
+	}
+	if _, ok := parts["xl/worksheets/sheet1.xml"]; !ok {
+		t.Error("archive missing xl/worksheets/sheet1.xml")
+	}
+}
+
+func TestXLSXRenderer_Deterministic(t *testing.T) {
+	doc := New().Table("Users", []Record{{"name": "Alice"}}, WithKeys("name")).Build()
+
+	first, err := XLSX().Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	second, err := XLSX().Renderer.Render(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Error("rendering the same document twice produced different bytes")
+	}
+}
+
+func TestXLSXSheetNames(t *testing.T) {
+	titles := []string{"Users", "users", "", "a/b:c?", "'quoted'", strings.Repeat("x", 40), strings.Repeat("x", 40)}
+	tables := make([]*TableContent, len(titles))
+	for i, title := range titles {
+		tables[i] = &TableContent{title: title, schema: NewSchemaFromKeys(nil)}
+	}
+
+	want := []string{
+		"Users",
+		"users (2)",
+		"Sheet3",
+		"a_b_c_",
+		"quoted",
+		strings.Repeat("x", 31),
+		strings.Repeat("x", 27) + " (2)",
+	}
+	if got := xlsxSheetNames(tables); !slices.Equal(got, want) {
+		t.Errorf("xlsxSheetNames() = %q, want %q", got, want)
+	}
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 701: "ZZ", 702: "AAA"}
+	for col, want := range tests {
+		if got := xlsxColumnName(col); got != want {
+			t.Errorf("xlsxColumnName(%d) = %q, want %q", col, got, want)
+		}
+	}
+}
+
+func TestXLSXAppendRejected(t *testing.T) {
+	dir := t.TempDir()
+	fw, err := NewFileWriterWithOptions(dir, "out.{ext}", WithAppendMode())
+	if err != nil {
+		t.Fatalf("NewFileWriterWithOptions() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := fw.Write(ctx, FormatXLSX, []byte("first")); err != nil {
+		t.Fatalf("first Write() error = %v", err)
+	}
+	if err := fw.Write(ctx, FormatXLSX, []byte("second")); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("second Write() error = %v, want append not supported", err)
+	}
+}